@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 
 	"github.com/pkg/errors"
@@ -44,7 +45,9 @@ func getDsn() (string, error) {
 		return "", errors.New("env DB_NAME is not found")
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", dbUser, dbPassword, dbHost, dbName)
+	// INFO: times are stored in UTC whatever the time zone of the server is. The session time zone is set as well,
+	// since MySQL converts TIMESTAMP columns with it.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%s", dbUser, dbPassword, dbHost, dbName, url.QueryEscape("'+00:00'"))
 
 	return dsn, nil
 }
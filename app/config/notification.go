@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"todo-app/infrastructure/notification"
+	"todo-app/usecase"
+)
+
+// NewNotificationSender returns an SMTP sender when SMTP_HOST is set, otherwise a sender which only writes logs.
+func NewNotificationSender() usecase.NotificationSender {
+	host, ok := os.LookupEnv("SMTP_HOST")
+	if !ok {
+		return notification.NewLogSender()
+	}
+
+	port := getEnv("SMTP_PORT", "587")
+	from := getEnv("SMTP_FROM", "todo@localhost")
+
+	return notification.NewSMTPSender(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+}
+
+func getEnv(key, defaultValue string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type SchedulerConfig struct {
+	OverdueInterval  time.Duration
+	ReminderInterval time.Duration
+	PurgeInterval    time.Duration
+	TrashRetention   time.Duration
+	WebhookInterval  time.Duration
+}
+
+func NewSchedulerConfig() *SchedulerConfig {
+	overdueInterval, err := getDuration("OVERDUE_CHECK_INTERVAL", 10*time.Minute)
+	if err != nil {
+		panic(err)
+	}
+
+	reminderInterval, err := getDuration("REMINDER_INTERVAL", time.Hour)
+	if err != nil {
+		panic(err)
+	}
+
+	purgeInterval, err := getDuration("PURGE_INTERVAL", time.Hour)
+	if err != nil {
+		panic(err)
+	}
+
+	trashRetention, err := getDuration("TRASH_RETENTION", 30*24*time.Hour)
+	if err != nil {
+		panic(err)
+	}
+
+	webhookInterval, err := getDuration("WEBHOOK_INTERVAL", 10*time.Second)
+	if err != nil {
+		panic(err)
+	}
+
+	return &SchedulerConfig{
+		OverdueInterval:  overdueInterval,
+		ReminderInterval: reminderInterval,
+		PurgeInterval:    purgeInterval,
+		TrashRetention:   trashRetention,
+		WebhookInterval:  webhookInterval,
+	}
+}
+
+func getDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "env %s is invalid duration", key)
+	} else if d <= 0 {
+		return 0, errors.Errorf("env %s must be positive", key)
+	}
+
+	return d, nil
+}
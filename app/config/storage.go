@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"todo-app/infrastructure/storage"
+	"todo-app/usecase"
+)
+
+// NewFileStorage returns an S3 storage when S3_BUCKET is set, otherwise a storage on the local filesystem under STORAGE_DIR.
+func NewFileStorage() usecase.FileStorage {
+	bucket, ok := os.LookupEnv("S3_BUCKET")
+	if !ok {
+		return storage.NewLocalStorage(getEnv("STORAGE_DIR", "./data/attachments"))
+	}
+
+	endpoint := getEnv("S3_ENDPOINT", "https://s3.amazonaws.com")
+	region := getEnv("S3_REGION", "us-east-1")
+
+	fs, err := storage.NewS3Storage(endpoint, bucket, region, os.Getenv("S3_ACCESS_KEY_ID"), os.Getenv("S3_SECRET_ACCESS_KEY"))
+	if err != nil {
+		panic(err)
+	}
+
+	return fs
+}
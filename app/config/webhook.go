@@ -0,0 +1,17 @@
+package config
+
+import (
+	"time"
+	"todo-app/infrastructure/webhook"
+	"todo-app/usecase"
+)
+
+// NewWebhookSender returns a sender which gives up a delivery when the receiver does not respond within WEBHOOK_TIMEOUT.
+func NewWebhookSender() usecase.WebhookSender {
+	timeout, err := getDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	if err != nil {
+		panic(err)
+	}
+
+	return webhook.NewHTTPSender(timeout)
+}
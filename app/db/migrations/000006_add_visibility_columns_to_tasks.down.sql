@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_share_token,
+  DROP visibility,
+  DROP share_token;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+ADD visibility TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  ADD share_token CHAR(36) NOT NULL DEFAULT '',
+  ADD INDEX idx_tasks_tbl_share_token (share_token);
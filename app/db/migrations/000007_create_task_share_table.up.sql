@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS task_shares(
+  task_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  PRIMARY KEY (task_id, user_id),
+  CONSTRAINT fk_task_shares_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id),
+  CONSTRAINT fk_task_shares_tbl_user_id FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP last_notified_at;
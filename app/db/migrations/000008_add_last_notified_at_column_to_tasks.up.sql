@@ -0,0 +1,2 @@
+ALTER TABLE tasks
+ADD last_notified_at TIMESTAMP NULL DEFAULT NULL;
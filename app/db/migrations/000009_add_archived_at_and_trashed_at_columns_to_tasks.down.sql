@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_trashed_at,
+  DROP archived_at,
+  DROP trashed_at;
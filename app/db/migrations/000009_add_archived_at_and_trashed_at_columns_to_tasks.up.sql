@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+ADD archived_at TIMESTAMP NULL DEFAULT NULL,
+  ADD trashed_at TIMESTAMP NULL DEFAULT NULL,
+  ADD INDEX idx_tasks_tbl_trashed_at (trashed_at);
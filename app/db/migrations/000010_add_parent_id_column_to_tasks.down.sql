@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP FOREIGN KEY fk_tasks_tbl_parent_id;
+ALTER TABLE tasks DROP parent_id;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+ADD parent_id CHAR(36) NULL DEFAULT NULL,
+  ADD CONSTRAINT fk_tasks_tbl_parent_id FOREIGN KEY (parent_id) REFERENCES tasks(id) ON DELETE SET NULL;
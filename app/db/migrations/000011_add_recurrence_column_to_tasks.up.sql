@@ -0,0 +1,2 @@
+ALTER TABLE tasks
+ADD recurrence VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS labels(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  color CHAR(7) NOT NULL,
+  INDEX idx_labels_tbl_user_id (user_id),
+  CONSTRAINT fk_labels_tbl_user_id FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS task_labels(
+  task_id CHAR(36) NOT NULL,
+  label_id CHAR(36) NOT NULL,
+  PRIMARY KEY (task_id, label_id),
+  CONSTRAINT fk_task_labels_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id),
+  CONSTRAINT fk_task_labels_tbl_label_id FOREIGN KEY (label_id) REFERENCES labels(id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_priority,
+  DROP priority,
+  DROP created_at;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+ADD priority TINYINT UNSIGNED NOT NULL DEFAULT 4,
+  ADD created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD INDEX idx_tasks_tbl_priority (priority);
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP INDEX ft_tasks_tbl_name_detail;
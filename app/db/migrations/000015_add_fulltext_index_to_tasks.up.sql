@@ -0,0 +1,2 @@
+ALTER TABLE tasks
+ADD FULLTEXT INDEX ft_tasks_tbl_name_detail (name, detail) WITH PARSER ngram;
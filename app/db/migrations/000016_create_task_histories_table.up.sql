@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_histories(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  task_id CHAR(36) NOT NULL,
+  user_id VARCHAR(36) NOT NULL DEFAULT '',
+  action VARCHAR(16) NOT NULL,
+  changes JSON NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  INDEX idx_task_histories_tbl_task_id (task_id, created_at),
+  INDEX idx_task_histories_tbl_user_id (user_id, created_at)
+);
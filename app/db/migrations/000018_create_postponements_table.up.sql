@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS postponements(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  task_id CHAR(36) NOT NULL,
+  requester_id CHAR(36) NOT NULL,
+  approver_id CHAR(36) NOT NULL,
+  deadline TIMESTAMP NULL DEFAULT NULL,
+  reason VARCHAR(500) NOT NULL,
+  status TINYINT UNSIGNED NOT NULL,
+  comment VARCHAR(500) NOT NULL DEFAULT '',
+  created_at DATETIME(6) NOT NULL,
+  decided_at DATETIME(6) NULL DEFAULT NULL,
+  INDEX idx_postponements_tbl_task_id (task_id, created_at),
+  INDEX idx_postponements_tbl_approver_id (approver_id, status, created_at),
+  CONSTRAINT fk_postponements_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_assignee_id,
+  DROP assignee_id;
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+ADD assignee_id CHAR(36) NOT NULL DEFAULT '',
+  ADD INDEX idx_tasks_tbl_assignee_id (assignee_id);
+UPDATE tasks
+SET assignee_id = user_id;
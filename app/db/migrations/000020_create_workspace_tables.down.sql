@@ -0,0 +1,5 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_workspace_id,
+  DROP workspace_id;
+DROP TABLE IF EXISTS invitations;
+DROP TABLE IF EXISTS members;
+DROP TABLE IF EXISTS workspaces;
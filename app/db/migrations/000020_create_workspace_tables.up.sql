@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS workspaces(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  created_at DATETIME(6) NOT NULL
+);
+CREATE TABLE IF NOT EXISTS members(
+  workspace_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  role TINYINT UNSIGNED NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (workspace_id, user_id),
+  INDEX idx_members_tbl_user_id (user_id),
+  CONSTRAINT fk_members_tbl_workspace_id FOREIGN KEY (workspace_id) REFERENCES workspaces(id),
+  CONSTRAINT fk_members_tbl_user_id FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE TABLE IF NOT EXISTS invitations(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  workspace_id CHAR(36) NOT NULL,
+  token CHAR(36) NOT NULL UNIQUE,
+  role TINYINT UNSIGNED NOT NULL,
+  inviter_id CHAR(36) NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  expires_at DATETIME(6) NOT NULL,
+  accepted_by CHAR(36) NOT NULL DEFAULT '',
+  accepted_at DATETIME(6) NULL DEFAULT NULL,
+  INDEX idx_invitations_tbl_workspace_id (workspace_id, expires_at),
+  CONSTRAINT fk_invitations_tbl_workspace_id FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+);
+INSERT INTO workspaces (id, name, created_at)
+SELECT id,
+  'Personal',
+  NOW(6)
+FROM users;
+INSERT INTO members (workspace_id, user_id, role, created_at)
+SELECT id,
+  id,
+  3,
+  NOW(6)
+FROM users;
+ALTER TABLE tasks
+ADD workspace_id CHAR(36) NOT NULL DEFAULT '',
+  ADD INDEX idx_tasks_tbl_workspace_id (workspace_id);
+UPDATE tasks
+SET workspace_id = user_id;
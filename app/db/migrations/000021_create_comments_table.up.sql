@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS comments(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  task_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  parent_id CHAR(36) NULL DEFAULT NULL,
+  body VARCHAR(2000) NOT NULL DEFAULT '',
+  created_at DATETIME(6) NOT NULL,
+  updated_at DATETIME(6) NOT NULL,
+  deleted_at DATETIME(6) NULL DEFAULT NULL,
+  INDEX idx_comments_tbl_task_id (task_id, created_at),
+  CONSTRAINT fk_comments_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
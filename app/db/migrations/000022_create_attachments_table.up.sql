@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS attachments(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  task_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(255) NOT NULL,
+  size BIGINT NOT NULL,
+  storage_key VARCHAR(255) NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  INDEX idx_attachments_tbl_task_id (task_id, created_at),
+  CONSTRAINT fk_attachments_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
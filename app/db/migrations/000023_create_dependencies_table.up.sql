@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS dependencies(
+  task_id CHAR(36) NOT NULL,
+  blocker_id CHAR(36) NOT NULL,
+  created_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (task_id, blocker_id),
+  INDEX idx_dependencies_tbl_blocker_id (blocker_id),
+  CONSTRAINT fk_dependencies_tbl_task_id FOREIGN KEY (task_id) REFERENCES tasks(id),
+  CONSTRAINT fk_dependencies_tbl_blocker_id FOREIGN KEY (blocker_id) REFERENCES tasks(id)
+);
@@ -0,0 +1,19 @@
+SET @source_time_zone = @@GLOBAL.time_zone;
+UPDATE task_histories SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone);
+UPDATE postponements SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone),
+  decided_at = CONVERT_TZ(decided_at, '+00:00', @source_time_zone);
+UPDATE workspaces SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone);
+UPDATE members SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone);
+UPDATE invitations SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone),
+  expires_at = CONVERT_TZ(expires_at, '+00:00', @source_time_zone),
+  accepted_at = CONVERT_TZ(accepted_at, '+00:00', @source_time_zone);
+UPDATE comments SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone),
+  updated_at = CONVERT_TZ(updated_at, '+00:00', @source_time_zone),
+  deleted_at = CONVERT_TZ(deleted_at, '+00:00', @source_time_zone);
+UPDATE attachments SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone);
+UPDATE dependencies SET created_at = CONVERT_TZ(created_at, '+00:00', @source_time_zone);
+ALTER TABLE postponements DROP has_due_time,
+  DROP time_zone;
+ALTER TABLE tasks DROP has_due_time,
+  DROP time_zone;
+ALTER TABLE users DROP time_zone;
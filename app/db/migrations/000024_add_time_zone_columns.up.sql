@@ -0,0 +1,30 @@
+-- The times used to be stored in the time zone of the server, which is taken from the time_zone of MySQL, e.g. the one of
+-- the RDS parameter group. When it is SYSTEM, the times are converted from the time zone of the system and the existing
+-- users and tasks get UTC, so set it to the name of the zone beforehand, see the README.
+-- TIMESTAMP columns are converted by MySQL with the session time zone, so only DATETIME columns are moved to UTC.
+SET @source_time_zone = @@GLOBAL.time_zone;
+SET @user_time_zone = IF(@source_time_zone = 'SYSTEM', 'UTC', @source_time_zone);
+ALTER TABLE users
+ADD time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE tasks
+ADD has_due_time BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE postponements
+ADD has_due_time BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+UPDATE users SET time_zone = @user_time_zone;
+UPDATE tasks SET time_zone = @user_time_zone;
+UPDATE postponements SET time_zone = @user_time_zone;
+UPDATE task_histories SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00');
+UPDATE postponements SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00'),
+  decided_at = CONVERT_TZ(decided_at, @source_time_zone, '+00:00');
+UPDATE workspaces SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00');
+UPDATE members SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00');
+UPDATE invitations SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00'),
+  expires_at = CONVERT_TZ(expires_at, @source_time_zone, '+00:00'),
+  accepted_at = CONVERT_TZ(accepted_at, @source_time_zone, '+00:00');
+UPDATE comments SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00'),
+  updated_at = CONVERT_TZ(updated_at, @source_time_zone, '+00:00'),
+  deleted_at = CONVERT_TZ(deleted_at, @source_time_zone, '+00:00');
+UPDATE attachments SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00');
+UPDATE dependencies SET created_at = CONVERT_TZ(created_at, @source_time_zone, '+00:00');
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP INDEX idx_users_tbl_calendar_token,
+  DROP calendar_token;
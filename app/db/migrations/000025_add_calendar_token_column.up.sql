@@ -0,0 +1,3 @@
+ALTER TABLE users
+ADD calendar_token CHAR(36) NOT NULL DEFAULT '',
+  ADD INDEX idx_users_tbl_calendar_token (calendar_token);
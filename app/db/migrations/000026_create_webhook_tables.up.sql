@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS webhooks(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  workspace_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret CHAR(64) NOT NULL,
+  events VARCHAR(255) NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at DATETIME(6) NOT NULL,
+  updated_at DATETIME(6) NOT NULL,
+  INDEX idx_webhooks_tbl_workspace_id (workspace_id, created_at),
+  CONSTRAINT fk_webhooks_tbl_workspace_id FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+);
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  webhook_id CHAR(36) NOT NULL,
+  event_type VARCHAR(32) NOT NULL,
+  task_id CHAR(36) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  attempts TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  response_status SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+  error VARCHAR(255) NOT NULL DEFAULT '',
+  next_attempt_at DATETIME(6) NOT NULL,
+  last_attempt_at DATETIME(6) NULL DEFAULT NULL,
+  created_at DATETIME(6) NOT NULL,
+  INDEX idx_webhook_deliveries_tbl_webhook_id (webhook_id, created_at),
+  INDEX idx_webhook_deliveries_tbl_status (status, next_attempt_at),
+  CONSTRAINT fk_webhook_deliveries_tbl_webhook_id FOREIGN KEY (webhook_id) REFERENCES webhooks(id)
+);
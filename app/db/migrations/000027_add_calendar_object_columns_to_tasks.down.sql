@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP INDEX idx_tasks_tbl_calendar_name,
+  DROP calendar_uid,
+  DROP calendar_name;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+ADD calendar_uid VARCHAR(255) NOT NULL DEFAULT '',
+  ADD calendar_name VARCHAR(255) NOT NULL DEFAULT '',
+  ADD INDEX idx_tasks_tbl_calendar_name (assignee_id, calendar_name);
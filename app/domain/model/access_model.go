@@ -0,0 +1,44 @@
+package model
+
+// TaskAccess is what a user can do with a task. Accesses are ordered so that an access includes the lower ones.
+type TaskAccess int
+
+const (
+	NoAccess TaskAccess = iota
+	// ViewAccess allows to view the task.
+	ViewAccess
+	// WorkAccess allows to carry out the task as its assignee, see TaskChangeSatisfied.
+	WorkAccess
+	// ManageAccess allows every change of the task.
+	ManageAccess
+)
+
+// TaskAccessOf decides the access of the user to the task from the membership of the user in the workspace of the task,
+// which is nil unless the user is a member, and from the users the task is shared with.
+// Admins and owners manage every task of the workspace, members manage the tasks they created and work on the ones
+// assigned to them, and viewers only view. Users outside the workspace can only view the tasks visible to them.
+// Trashed tasks are accessible only to the users who manage them.
+func TaskAccessOf(t Task, userID UserID, m *Member, sharedUserIDs []UserID) TaskAccess {
+	var access TaskAccess
+
+	switch {
+	case m == nil || m.WorkspaceID != t.WorkspaceID || m.UserID != userID:
+		if t.VisibleTo(userID, sharedUserIDs) {
+			access = ViewAccess
+		}
+	case m.Role >= RoleAdmin:
+		access = ManageAccess
+	case m.Role == RoleMember && t.IsOwnedBy(userID):
+		access = ManageAccess
+	case m.Role == RoleMember && t.IsAssignedTo(userID):
+		access = WorkAccess
+	default:
+		access = ViewAccess
+	}
+
+	if t.IsTrashed() && access < ManageAccess {
+		return NoAccess
+	}
+
+	return access
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+)
+
+// TaskAssign delegates the task to the assignee. The creator stays the owner of the task.
+func TaskAssign(fetchedTask Task, assigneeID UserID) (*Task, error) {
+	if fetchedTask.IsTrashed() {
+		return nil, errors.New("trashed task cannot be assigned")
+	}
+
+	if assigneeID == "" {
+		return nil, errors.New("assignee is required")
+	}
+
+	t := fetchedTask
+	t.AssigneeID = assigneeID
+
+	return &t, nil
+}
+
+// TaskChangeSatisfied checks that the user with the access may change the task from before to after.
+// Users who manage the task may change anything, while the assignee may only change how the task is carried out,
+// i.e. its status, detail and deadline. Others may change nothing.
+func TaskChangeSatisfied(before, after Task, access TaskAccess) error {
+	if access >= ManageAccess {
+		return nil
+	}
+
+	if access < WorkAccess {
+		return errors.Errorf("user can neither manage nor work on task. taskID: %s", before.ID)
+	}
+
+	switch {
+	case before.Name != after.Name:
+		return errors.Errorf("only creator can change name of task. taskID: %s", before.ID)
+	case before.Priority != after.Priority:
+		return errors.Errorf("only creator can change priority of task. taskID: %s", before.ID)
+	case before.Recurrence != after.Recurrence:
+		return errors.Errorf("only creator can change recurrence of task. taskID: %s", before.ID)
+	}
+
+	return nil
+}
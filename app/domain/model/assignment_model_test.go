@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskAssign(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	creatorID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	assigneeID := UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")
+
+	tests := []struct {
+		name        string
+		input       Task
+		assigneeID  UserID
+		expectedErr error
+	}{
+		{
+			"normal case",
+			Task{UserID: creatorID, AssigneeID: creatorID},
+			assigneeID,
+			nil,
+		},
+		{
+			"empty assignee case",
+			Task{UserID: creatorID, AssigneeID: creatorID},
+			"",
+			errors.New("assignee is required"),
+		},
+		{
+			"trashed task case",
+			Task{UserID: creatorID, AssigneeID: creatorID, TrashedAt: &now},
+			assigneeID,
+			errors.New("trashed task cannot be assigned"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := TaskAssign(tt.input, tt.assigneeID)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.assigneeID, output.AssigneeID)
+				assert.Exactly(t, creatorID, output.UserID)
+				assert.True(t, output.IsAssignedTo(tt.assigneeID))
+			}
+		})
+	}
+}
+
+func TestTaskChangeSatisfied(t *testing.T) {
+	t.Parallel()
+
+	creatorID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	assigneeID := UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	before := Task{UserID: creatorID, AssigneeID: assigneeID, Name: "Venue Reservation", Status: Working, Deadline: deadline, Priority: P3}
+
+	changed := func(f func(*Task)) Task {
+		t := before
+		f(&t)
+
+		return t
+	}
+
+	tests := []struct {
+		name        string
+		after       Task
+		access      TaskAccess
+		expectedErr error
+	}{
+		{
+			"manager changes name case",
+			changed(func(t *Task) { t.Name = "Catering" }),
+			ManageAccess,
+			nil,
+		},
+		{
+			"assignee changes status and deadline case",
+			changed(func(t *Task) { t.Status = Completed; t.Deadline = deadline.AddDate(0, 0, 1) }),
+			WorkAccess,
+			nil,
+		},
+		{
+			"assignee changes name case",
+			changed(func(t *Task) { t.Name = "Catering" }),
+			WorkAccess,
+			errors.New("only creator can change name of task"),
+		},
+		{
+			"assignee changes priority case",
+			changed(func(t *Task) { t.Priority = P1 }),
+			WorkAccess,
+			errors.New("only creator can change priority of task"),
+		},
+		{
+			"assignee changes recurrence case",
+			changed(func(t *Task) { t.Recurrence = "FREQ=DAILY" }),
+			WorkAccess,
+			errors.New("only creator can change recurrence of task"),
+		},
+		{
+			"viewer case",
+			before,
+			ViewAccess,
+			errors.New("user can neither manage nor work on task"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := TaskChangeSatisfied(before, tt.after, tt.access); err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Attachment is a file attached to a task. The content is kept in a file storage under StorageKey.
+type Attachment struct {
+	ID          AttachmentID
+	TaskID      TaskID
+	UserID      UserID
+	Name        string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   time.Time
+}
+
+type AttachmentID string
+
+const (
+	// MaxAttachmentSize is the largest file which can be attached, in bytes.
+	MaxAttachmentSize        = 10 << 20
+	maxAttachmentNameLength  = 255
+	defaultAttachmentName    = "file"
+	attachmentStorageKeyRoot = "tasks"
+)
+
+// attachmentContentTypes are the media types of the files which can be attached.
+var attachmentContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// NewAttachment creates an attachment of a file to the task by the user.
+// The content type has to be detected from the content, since the one sent by clients cannot be trusted.
+func NewAttachment(id AttachmentID, t Task, userID UserID, name, contentType string, size int64, now time.Time) (*Attachment, error) {
+	if t.IsTrashed() {
+		return nil, errors.New("file cannot be attached to trashed task")
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid content type. contentType: %s", contentType)
+	}
+
+	a := &Attachment{
+		ID:          id,
+		TaskID:      t.ID,
+		UserID:      userID,
+		Name:        attachmentName(name),
+		ContentType: mediaType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("%s/%s/%s", attachmentStorageKeyRoot, t.ID, id),
+		CreatedAt:   now,
+	}
+
+	if err := AttachmentSpecSatisfied(*a); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Attachment spec. a: %+v", a)
+	}
+
+	return a, nil
+}
+
+func AttachmentSpecSatisfied(a Attachment) error {
+	if a.Size <= 0 {
+		return errors.New("file is empty")
+	}
+
+	if a.Size > MaxAttachmentSize {
+		return errors.Errorf("file exceeds %d MB", MaxAttachmentSize>>20)
+	}
+
+	if !attachmentContentTypes[a.ContentType] {
+		return errors.Errorf("file type is not allowed. contentType: %s", a.ContentType)
+	}
+
+	return nil
+}
+
+// AttachmentDeletable reports whether the user can delete the attachment, which the uploader and the users who manage the task can.
+func AttachmentDeletable(a Attachment, userID UserID, access TaskAccess) bool {
+	return a.UserID == userID || access >= ManageAccess
+}
+
+// attachmentName drops the directories and control characters from the name of an uploaded file,
+// and shortens a long name from its start to keep the extension.
+func attachmentName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, name)
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return defaultAttachmentName
+	}
+
+	for utf8.RuneCountInString(name) > maxAttachmentNameLength {
+		_, size := utf8.DecodeRuneInString(name)
+		name = name[size:]
+	}
+
+	return name
+}
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	task := Task{ID: "task"}
+
+	tests := []struct {
+		name                string
+		task                Task
+		fileName            string
+		contentType         string
+		size                int64
+		expectedName        string
+		expectedContentType string
+		expectedErr         error
+	}{
+		{
+			"normal case",
+			task,
+			"screenshot.png",
+			"image/png",
+			1024,
+			"screenshot.png",
+			"image/png",
+			nil,
+		},
+		{
+			"content type with parameter case",
+			task,
+			"notes.txt",
+			"text/plain; charset=utf-8",
+			10,
+			"notes.txt",
+			"text/plain",
+			nil,
+		},
+		{
+			"name with directories case",
+			task,
+			"C:\\Users\\me\\..\\invoice.pdf",
+			"application/pdf",
+			MaxAttachmentSize,
+			"invoice.pdf",
+			"application/pdf",
+			nil,
+		},
+		{
+			"name with control characters case",
+			task,
+			"bad\r\nname.png",
+			"image/png",
+			1,
+			"badname.png",
+			"image/png",
+			nil,
+		},
+		{
+			"empty name case",
+			task,
+			"",
+			"image/png",
+			1,
+			"file",
+			"image/png",
+			nil,
+		},
+		{
+			"too long name case",
+			task,
+			strings.Repeat("a", maxAttachmentNameLength) + ".png",
+			"image/png",
+			1,
+			strings.Repeat("a", maxAttachmentNameLength-4) + ".png",
+			"image/png",
+			nil,
+		},
+		{
+			"empty file case",
+			task,
+			"empty.txt",
+			"text/plain",
+			0,
+			"",
+			"",
+			errors.New("file is empty"),
+		},
+		{
+			"too large file case",
+			task,
+			"large.png",
+			"image/png",
+			MaxAttachmentSize + 1,
+			"",
+			"",
+			errors.New("file exceeds 10 MB"),
+		},
+		{
+			"not allowed type case",
+			task,
+			"page.html",
+			"text/html; charset=utf-8",
+			1,
+			"",
+			"",
+			errors.New("file type is not allowed"),
+		},
+		{
+			"invalid type case",
+			task,
+			"file",
+			"",
+			1,
+			"",
+			"",
+			errors.New("invalid content type"),
+		},
+		{
+			"trashed task case",
+			Task{ID: "task", TrashedAt: &now},
+			"screenshot.png",
+			"image/png",
+			1,
+			"",
+			"",
+			errors.New("file cannot be attached to trashed task"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := NewAttachment("id", tt.task, "user", tt.fileName, tt.contentType, tt.size, now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedName, output.Name)
+				assert.Exactly(t, tt.expectedContentType, output.ContentType)
+				assert.Exactly(t, "tasks/task/id", output.StorageKey)
+			}
+		})
+	}
+}
+
+func TestAttachmentDeletable(t *testing.T) {
+	t.Parallel()
+
+	a := Attachment{ID: "id", TaskID: "task", UserID: "uploader"}
+
+	assert.True(t, AttachmentDeletable(a, "uploader", WorkAccess))
+	assert.True(t, AttachmentDeletable(a, "manager", ManageAccess))
+	assert.False(t, AttachmentDeletable(a, "assignee", WorkAccess))
+}
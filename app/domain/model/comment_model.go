@@ -0,0 +1,158 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Comment is a message in the discussion of a task. A reply has the comment it replies to as its parent.
+// Deleted comments are kept without their body, so that the replies to them stay in the thread.
+type Comment struct {
+	ID        CommentID
+	TaskID    TaskID
+	UserID    UserID
+	ParentID  *CommentID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+type CommentID string
+
+// CommentThread is a comment with the replies to it, the oldest first.
+type CommentThread struct {
+	Comment *Comment
+	Replies []*CommentThread
+}
+
+// Discussion is the comment threads of a task, the oldest first, with the users who wrote them,
+// as seen by a user with the access to the task.
+type Discussion struct {
+	Threads []*CommentThread
+	Users   map[UserID]*User
+	Access  TaskAccess
+}
+
+const maxCommentBodyLength = 2000
+
+// NewComment creates a comment on the task by the user. The parent is the comment to reply to, or nil to start a thread.
+func NewComment(id CommentID, t Task, userID UserID, parent *Comment, body string, now time.Time) (*Comment, error) {
+	if t.IsTrashed() {
+		return nil, errors.New("trashed task cannot be commented")
+	}
+
+	c := &Comment{
+		ID:        id,
+		TaskID:    t.ID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(body),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if parent != nil {
+		if parent.TaskID != t.ID {
+			return nil, errors.Errorf("parent comment is on another task. parentID: %s", parent.ID)
+		}
+
+		if parent.IsDeleted() {
+			return nil, errors.New("deleted comment cannot be replied to")
+		}
+
+		c.ParentID = &parent.ID
+	}
+
+	if err := CommentSpecSatisfied(*c); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Comment spec. c: %+v", c)
+	}
+
+	return c, nil
+}
+
+func CommentSpecSatisfied(c Comment) error {
+	if c.Body == "" {
+		return errors.New("comment is required")
+	}
+
+	if utf8.RuneCountInString(c.Body) > maxCommentBodyLength {
+		return errors.Errorf("comment exceeds %d characters", maxCommentBodyLength)
+	}
+
+	return nil
+}
+
+// CommentEdit replaces the body of the comment.
+func CommentEdit(fetched Comment, body string, now time.Time) (*Comment, error) {
+	if fetched.IsDeleted() {
+		return nil, errors.New("deleted comment cannot be edited")
+	}
+
+	c := fetched
+	c.Body = strings.TrimSpace(body)
+	c.UpdatedAt = now
+
+	if err := CommentSpecSatisfied(c); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Comment spec. c: %+v", c)
+	}
+
+	return &c, nil
+}
+
+// CommentDelete removes the body of the comment and marks it as deleted.
+func CommentDelete(fetched Comment, now time.Time) (*Comment, error) {
+	if fetched.IsDeleted() {
+		return nil, errors.New("comment is already deleted")
+	}
+
+	c := fetched
+	c.Body = ""
+	c.DeletedAt = &now
+
+	return &c, nil
+}
+
+// CommentModeratable reports whether the user can delete the comment, which the author and the users who manage the task can.
+func CommentModeratable(c Comment, userID UserID, access TaskAccess) bool {
+	return c.IsWrittenBy(userID) || access >= ManageAccess
+}
+
+func (c Comment) IsWrittenBy(userID UserID) bool {
+	return c.UserID == userID
+}
+
+func (c Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+func (c Comment) IsEdited() bool {
+	return !c.IsDeleted() && c.UpdatedAt.After(c.CreatedAt)
+}
+
+// CommentThreads arranges the comments, given the oldest first, into threads.
+// Replies whose parent is missing start threads by themselves.
+func CommentThreads(comments []*Comment) []*CommentThread {
+	threads := make(map[CommentID]*CommentThread, len(comments))
+	for _, c := range comments {
+		threads[c.ID] = &CommentThread{Comment: c}
+	}
+
+	roots := []*CommentThread{}
+
+	for _, c := range comments {
+		if c.ParentID != nil {
+			if parent, ok := threads[*c.ParentID]; ok {
+				parent.Replies = append(parent.Replies, threads[c.ID])
+
+				continue
+			}
+		}
+
+		roots = append(roots, threads[c.ID])
+	}
+
+	return roots
+}
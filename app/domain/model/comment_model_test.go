@@ -0,0 +1,156 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComment(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	task := Task{ID: "task"}
+	parent := &Comment{ID: "parent", TaskID: "task"}
+
+	tests := []struct {
+		name        string
+		task        Task
+		parent      *Comment
+		body        string
+		expectedErr error
+	}{
+		{
+			"normal case",
+			task,
+			nil,
+			" Venue is booked ",
+			nil,
+		},
+		{
+			"reply case",
+			task,
+			parent,
+			"Thanks",
+			nil,
+		},
+		{
+			"empty body case",
+			task,
+			nil,
+			" ",
+			errors.New("comment is required"),
+		},
+		{
+			"too long body case",
+			task,
+			nil,
+			strings.Repeat("a", maxCommentBodyLength+1),
+			errors.New("comment exceeds 2000 characters"),
+		},
+		{
+			"parent on another task case",
+			task,
+			&Comment{ID: "other", TaskID: "other"},
+			"Thanks",
+			errors.New("parent comment is on another task"),
+		},
+		{
+			"deleted parent case",
+			task,
+			&Comment{ID: "deleted", TaskID: "task", DeletedAt: &now},
+			"Thanks",
+			errors.New("deleted comment cannot be replied to"),
+		},
+		{
+			"trashed task case",
+			Task{ID: "task", TrashedAt: &now},
+			nil,
+			"Thanks",
+			errors.New("trashed task cannot be commented"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := NewComment("id", tt.task, "user", tt.parent, tt.body, now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, strings.TrimSpace(tt.body), output.Body)
+				if tt.parent != nil {
+					assert.Exactly(t, tt.parent.ID, *output.ParentID)
+				} else {
+					assert.Nil(t, output.ParentID)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentEditAndDelete(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	later := now.Add(time.Minute)
+	comment := Comment{ID: "id", TaskID: "task", UserID: "author", Body: "Venue is booked", CreatedAt: now, UpdatedAt: now}
+
+	edited, err := CommentEdit(comment, "Venue is booked for Friday", later)
+	assert.Nil(t, err)
+	assert.Exactly(t, "Venue is booked for Friday", edited.Body)
+	assert.True(t, edited.IsEdited())
+
+	_, err = CommentEdit(comment, "", later)
+	assert.Contains(t, err.Error(), "comment is required")
+
+	assert.True(t, CommentModeratable(comment, "author", ViewAccess))
+	assert.True(t, CommentModeratable(comment, "manager", ManageAccess))
+	assert.False(t, CommentModeratable(comment, "assignee", WorkAccess))
+
+	deleted, err := CommentDelete(*edited, later)
+	assert.Nil(t, err)
+	assert.True(t, deleted.IsDeleted())
+	assert.False(t, deleted.IsEdited())
+	assert.Empty(t, deleted.Body)
+
+	_, err = CommentDelete(*deleted, later)
+	assert.Contains(t, err.Error(), "comment is already deleted")
+
+	_, err = CommentEdit(*deleted, "Again", later)
+	assert.Contains(t, err.Error(), "deleted comment cannot be edited")
+}
+
+func TestCommentThreads(t *testing.T) {
+	t.Parallel()
+
+	root := CommentID("root")
+	reply := CommentID("reply")
+	gone := CommentID("gone")
+	comments := []*Comment{
+		{ID: root},
+		{ID: reply, ParentID: &root},
+		{ID: "other"},
+		{ID: "nested", ParentID: &reply},
+		{ID: "orphan", ParentID: &gone},
+	}
+
+	threads := CommentThreads(comments)
+
+	assert.Len(t, threads, 3)
+	assert.Exactly(t, root, threads[0].Comment.ID)
+	assert.Exactly(t, reply, threads[0].Replies[0].Comment.ID)
+	assert.Exactly(t, CommentID("nested"), threads[0].Replies[0].Replies[0].Comment.ID)
+	assert.Exactly(t, CommentID("other"), threads[1].Comment.ID)
+	assert.Exactly(t, CommentID("orphan"), threads[2].Comment.ID)
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Dependency means that the task is blocked by the blocker, i.e. it cannot be completed before the blocker is.
+type Dependency struct {
+	TaskID    TaskID
+	BlockerID TaskID
+	CreatedAt time.Time
+}
+
+// TaskDependencies are the tasks which block a task and the tasks which are blocked by it.
+// Blocked tells whether the task is blocked by incomplete tasks, even by the ones which are not listed.
+type TaskDependencies struct {
+	BlockedBy []*Task
+	Blocking  []*Task
+	Blocked   bool
+}
+
+// NewDependency makes the task blocked by the blocker. Both have to be in the same workspace,
+// and the dependencies of the workspace must not make a cycle with the new one.
+func NewDependency(t, blocker Task, dependencies []*Dependency, now time.Time) (*Dependency, error) {
+	if t.ID == blocker.ID {
+		return nil, errors.New("task cannot be blocked by itself")
+	}
+
+	if t.WorkspaceID != blocker.WorkspaceID {
+		return nil, errors.Errorf("blocking task is in another workspace. blockerID: %s", blocker.ID)
+	}
+
+	if t.IsTrashed() || blocker.IsTrashed() {
+		return nil, errors.New("trashed task cannot be blocked or block")
+	}
+
+	for _, d := range dependencies {
+		if d.TaskID == t.ID && d.BlockerID == blocker.ID {
+			return nil, errors.Errorf("task is already blocked by the task. blockerID: %s", blocker.ID)
+		}
+	}
+
+	// INFO: the new dependency closes a cycle when the blocker is already blocked by the task, directly or not
+	if path := DependencyPath(dependencies, blocker.ID, t.ID); path != nil {
+		return nil, errors.Errorf("dependency makes a cycle: %s", formatDependencyCycle(append([]TaskID{t.ID}, path...)))
+	}
+
+	return &Dependency{TaskID: t.ID, BlockerID: blocker.ID, CreatedAt: now}, nil
+}
+
+// DependencyPath returns the shortest chain of tasks from the task to the blocker, where each task is blocked by the next one,
+// or nil when the task is not blocked by the blocker even indirectly.
+func DependencyPath(dependencies []*Dependency, taskID, blockerID TaskID) []TaskID {
+	blockers := make(map[TaskID][]TaskID)
+	for _, d := range dependencies {
+		blockers[d.TaskID] = append(blockers[d.TaskID], d.BlockerID)
+	}
+
+	previous := map[TaskID]TaskID{taskID: taskID}
+	queue := []TaskID{taskID}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if id == blockerID {
+			var path []TaskID
+			for ; id != taskID; id = previous[id] {
+				path = append([]TaskID{id}, path...)
+			}
+
+			return append([]TaskID{taskID}, path...)
+		}
+
+		for _, b := range blockers[id] {
+			if _, ok := previous[b]; !ok {
+				previous[b] = id
+				queue = append(queue, b)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IncompleteBlockers returns the blockers which still block a task. Trashed tasks do not block any more.
+func IncompleteBlockers(blockers []*Task) []*Task {
+	var incomplete []*Task
+
+	for _, b := range blockers {
+		if b.Status != Completed && !b.IsTrashed() {
+			incomplete = append(incomplete, b)
+		}
+	}
+
+	return incomplete
+}
+
+// BlockersSatisfied checks that a completed task is not blocked by incomplete tasks.
+func BlockersSatisfied(t Task, blockers []*Task) error {
+	if t.Status != Completed {
+		return nil
+	}
+
+	if incomplete := IncompleteBlockers(blockers); len(incomplete) > 0 {
+		return errors.Errorf("task is blocked by incomplete tasks. blockerID: %s", incomplete[0].ID)
+	}
+
+	return nil
+}
+
+func formatDependencyCycle(ids []TaskID) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, string(id))
+	}
+
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDependency(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	a := Task{ID: "a", WorkspaceID: "workspace"}
+	b := Task{ID: "b", WorkspaceID: "workspace"}
+	c := Task{ID: "c", WorkspaceID: "workspace"}
+
+	// INFO: c is blocked by b, which is blocked by a
+	dependencies := []*Dependency{{TaskID: "b", BlockerID: "a"}, {TaskID: "c", BlockerID: "b"}}
+
+	tests := []struct {
+		name        string
+		task        Task
+		blocker     Task
+		expectedErr error
+	}{
+		{
+			"normal case",
+			c,
+			a,
+			nil,
+		},
+		{
+			"direct cycle case",
+			a,
+			b,
+			errors.New("dependency makes a cycle: a -> b -> a"),
+		},
+		{
+			"indirect cycle case",
+			a,
+			c,
+			errors.New("dependency makes a cycle: a -> c -> b -> a"),
+		},
+		{
+			"self case",
+			a,
+			a,
+			errors.New("task cannot be blocked by itself"),
+		},
+		{
+			"duplicate case",
+			b,
+			a,
+			errors.New("task is already blocked by the task"),
+		},
+		{
+			"another workspace case",
+			c,
+			Task{ID: "d", WorkspaceID: "other"},
+			errors.New("blocking task is in another workspace"),
+		},
+		{
+			"trashed task case",
+			c,
+			Task{ID: "d", WorkspaceID: "workspace", TrashedAt: &now},
+			errors.New("trashed task cannot be blocked or block"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := NewDependency(tt.task, tt.blocker, dependencies, now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, &Dependency{TaskID: tt.task.ID, BlockerID: tt.blocker.ID, CreatedAt: now}, output)
+			}
+		})
+	}
+}
+
+func TestDependencyPath(t *testing.T) {
+	t.Parallel()
+
+	dependencies := []*Dependency{
+		{TaskID: "a", BlockerID: "b"},
+		{TaskID: "b", BlockerID: "c"},
+		{TaskID: "c", BlockerID: "d"},
+		{TaskID: "a", BlockerID: "d"},
+		{TaskID: "d", BlockerID: "b"},
+	}
+
+	assert.Exactly(t, []TaskID{"a", "d"}, DependencyPath(dependencies, "a", "d"))
+	assert.Exactly(t, []TaskID{"b", "c", "d"}, DependencyPath(dependencies, "b", "d"))
+	assert.Exactly(t, []TaskID{"a"}, DependencyPath(dependencies, "a", "a"))
+	assert.Nil(t, DependencyPath(dependencies, "d", "a"))
+}
+
+func TestBlockersSatisfied(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	completed := &Task{ID: "completed", Status: Completed}
+	trashed := &Task{ID: "trashed", Status: Working, TrashedAt: &now}
+	behind := &Task{ID: "behind", Status: Behind}
+
+	assert.Nil(t, BlockersSatisfied(Task{Status: Completed}, []*Task{completed, trashed}))
+	assert.Nil(t, BlockersSatisfied(Task{Status: Working}, []*Task{behind}))
+	assert.Contains(t, BlockersSatisfied(Task{Status: Completed}, []*Task{completed, behind}).Error(), "task is blocked by incomplete tasks. blockerID: behind")
+	assert.Exactly(t, []*Task{behind}, IncompleteBlockers([]*Task{completed, trashed, behind}))
+}
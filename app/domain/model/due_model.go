@@ -0,0 +1,110 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Due is the deadline of a task as its user sets it: a day, or a time of the day when HasTime is set.
+// The day is the one in the location of At, which is the time zone of the user.
+type Due struct {
+	At      time.Time
+	HasTime bool
+}
+
+// DueOn makes a deadline on the day of the date in its location.
+func DueOn(date time.Time) Due {
+	return Due{At: startOfDay(date), HasTime: false}
+}
+
+// DueAt makes a deadline at the time of the day, to the minute.
+func DueAt(at time.Time) Due {
+	return Due{At: at.Truncate(time.Minute), HasTime: true}
+}
+
+// Day returns the start of the day of the deadline in its location.
+func (d Due) Day() time.Time {
+	return startOfDay(d.At)
+}
+
+// End returns the moment from which the task is behind, i.e. the due time or the start of the next day.
+func (d Due) End() time.Time {
+	if d.HasTime {
+		return d.At
+	}
+
+	return d.Day().AddDate(0, 0, 1)
+}
+
+// After reports whether the deadline is later than the other one.
+func (d Due) After(other Due) bool {
+	return d.End().After(other.End())
+}
+
+func (d Due) String() string {
+	if d.At.IsZero() {
+		return ""
+	}
+
+	if !d.HasTime {
+		return d.At.Format("2006-01-02")
+	}
+
+	return d.At.Format("2006-01-02 15:04") + " " + d.At.Location().String()
+}
+
+// Due returns the deadline of the task in its time zone.
+func (t Task) Due() Due {
+	return Due{At: t.Deadline.In(LoadLocation(t.TimeZone)), HasTime: t.HasDueTime}
+}
+
+// Location returns the time zone in which the deadline of the task was set.
+func (t Task) Location() *time.Location {
+	return LoadLocation(t.TimeZone)
+}
+
+// DefaultTimeZone is the time zone of the users who have not chosen theirs.
+const DefaultTimeZone = "UTC"
+
+var locations sync.Map
+
+// LoadLocation returns the time zone of the IANA name, or UTC when the name is unknown.
+// Locations are cached since they are looked up for every task.
+func LoadLocation(name string) *time.Location {
+	// INFO: the zone of the server is the same location, so that times in it are not converted back and forth
+	if name == time.Local.String() {
+		return time.Local
+	}
+
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	locations.Store(name, loc)
+
+	return loc
+}
+
+// TimeZoneSpecSatisfied checks that the name is an IANA time zone like "Asia/Tokyo".
+func TimeZoneSpecSatisfied(name string) error {
+	if name == "" || name == "Local" {
+		return errors.Errorf("time zone is required. name: %q", name)
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return errors.Errorf("unknown time zone. name: %s", name)
+	}
+
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDue(t *testing.T) {
+	t.Parallel()
+
+	tokyo := LoadLocation("Asia/Tokyo")
+	berlin := LoadLocation("Europe/Berlin")
+
+	day := DueOn(time.Date(2022, 1, 26, 18, 30, 0, 0, tokyo))
+	assert.Exactly(t, time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), day.At)
+	assert.Exactly(t, time.Date(2022, 1, 27, 0, 0, 0, 0, tokyo), day.End())
+	assert.Exactly(t, "2022-01-26", day.String())
+
+	at := DueAt(time.Date(2022, 1, 26, 18, 30, 45, 0, berlin))
+	assert.Exactly(t, time.Date(2022, 1, 26, 18, 30, 0, 0, berlin), at.End())
+	assert.Exactly(t, time.Date(2022, 1, 26, 0, 0, 0, 0, berlin), at.Day())
+	assert.Exactly(t, "2022-01-26 18:30 Europe/Berlin", at.String())
+
+	// INFO: the end of the day in Tokyo is 16:00 in Berlin
+	assert.True(t, at.After(day))
+	assert.False(t, day.After(at))
+	assert.False(t, day.After(DueOn(time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo))))
+}
+
+func TestTaskDue(t *testing.T) {
+	t.Parallel()
+
+	// INFO: the deadline is read from the database in UTC
+	task := Task{Deadline: time.Date(2022, 1, 25, 15, 0, 0, 0, time.UTC), TimeZone: "Asia/Tokyo"}
+	assert.Exactly(t, "2022-01-26", task.Due().String())
+
+	task = Task{Deadline: time.Date(2022, 1, 25, 15, 0, 0, 0, time.UTC), HasDueTime: true, TimeZone: "Europe/Berlin"}
+	assert.Exactly(t, "2022-01-25 16:00 Europe/Berlin", task.Due().String())
+
+	assert.Exactly(t, time.UTC, Task{TimeZone: "Mars/Olympus"}.Location())
+}
+
+func TestTimeZoneSpecSatisfied(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, TimeZoneSpecSatisfied("Asia/Tokyo"))
+	assert.Nil(t, TimeZoneSpecSatisfied("UTC"))
+	assert.NotNil(t, TimeZoneSpecSatisfied(""))
+	assert.NotNil(t, TimeZoneSpecSatisfied("Local"))
+	assert.NotNil(t, TimeZoneSpecSatisfied("Mars/Olympus"))
+}
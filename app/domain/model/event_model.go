@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type EventType string
+
+const (
+	TaskCreated   EventType = "task.created"
+	TaskUpdated   EventType = "task.updated"
+	TaskCompleted EventType = "task.completed"
+	TaskBehind    EventType = "task.behind"
+	TaskPostponed EventType = "task.postponed"
+)
+
+// EventTypes are all the types of the events, in the order in which they are listed to users.
+var EventTypes = []EventType{TaskCreated, TaskUpdated, TaskCompleted, TaskBehind, TaskPostponed}
+
+func ParseEventType(name string) (EventType, error) {
+	for _, t := range EventTypes {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+
+	return "", errors.Errorf("unknown event. name: %s", name)
+}
+
+type TaskEvent struct {
+	Type       EventType
+	TaskID     TaskID
+	UserID     UserID
+	OccurredAt time.Time
+}
+
+func NewTaskEvent(eventType EventType, t Task, occurredAt time.Time) TaskEvent {
+	return TaskEvent{
+		Type:       eventType,
+		TaskID:     t.ID,
+		UserID:     t.UserID,
+		OccurredAt: occurredAt,
+	}
+}
+
+// TaskChangeEvents returns the events of a change of the task by a user. A nil before means the task is created.
+// Any other change is an update, which is also a completion or a fall behind when it changes the status so,
+// and a postponement when it moves the due later. A change of none of the fields in the history has no events.
+func TaskChangeEvents(before *Task, after Task, occurredAt time.Time) []TaskEvent {
+	if before == nil {
+		return []TaskEvent{NewTaskEvent(TaskCreated, after, occurredAt)}
+	}
+
+	if len(TaskChanges(before, after)) == 0 {
+		return nil
+	}
+
+	events := []TaskEvent{NewTaskEvent(TaskUpdated, after, occurredAt)}
+
+	if before.Status != after.Status {
+		switch after.Status {
+		case Completed:
+			events = append(events, NewTaskEvent(TaskCompleted, after, occurredAt))
+		case Behind:
+			events = append(events, NewTaskEvent(TaskBehind, after, occurredAt))
+		}
+	}
+
+	if after.Due().After(before.Due()) {
+		events = append(events, NewTaskEvent(TaskPostponed, after, occurredAt))
+	}
+
+	return events
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskChangeEvents(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.UTC)
+	before := Task{ID: "t1", UserID: "u1", Name: "Reserve the venue", Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.UTC), TimeZone: "UTC"}
+
+	renamed := before
+	renamed.Name = "Reserve the hall"
+
+	completed := before
+	completed.Status = Completed
+
+	behind := before
+	behind.Status = Behind
+
+	postponed := before
+	postponed.Deadline = before.Deadline.AddDate(0, 0, 3)
+
+	advanced := before
+	advanced.Deadline = before.Deadline.AddDate(0, 0, -1)
+
+	reopened := completed
+	reopened.Status = Working
+
+	tests := []struct {
+		name     string
+		before   *Task
+		after    Task
+		expected []EventType
+	}{
+		{"created", nil, before, []EventType{TaskCreated}},
+		{"updated", &before, renamed, []EventType{TaskUpdated}},
+		{"completed", &before, completed, []EventType{TaskUpdated, TaskCompleted}},
+		{"fell behind", &before, behind, []EventType{TaskUpdated, TaskBehind}},
+		{"postponed", &before, postponed, []EventType{TaskUpdated, TaskPostponed}},
+		{"deadline is moved earlier", &before, advanced, []EventType{TaskUpdated}},
+		{"reopened", &completed, reopened, []EventType{TaskUpdated}},
+		{"nothing is changed", &before, before, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := TaskChangeEvents(tt.before, tt.after, now)
+
+			var types []EventType
+			for _, e := range events {
+				assert.Exactly(t, TaskEvent{Type: e.Type, TaskID: "t1", UserID: "u1", OccurredAt: now}, e)
+				types = append(types, e.Type)
+			}
+
+			assert.Exactly(t, tt.expected, types)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+type HistoryID string
+
+type HistoryAction string
+
+const (
+	HistoryCreate HistoryAction = "create"
+	HistoryUpdate HistoryAction = "update"
+)
+
+// SystemUserID is the actor of the changes made by the server itself, e.g. marking overdue tasks as behind.
+const SystemUserID UserID = ""
+
+// TaskHistory is an append-only record of a change of a task made by a user.
+type TaskHistory struct {
+	ID        HistoryID
+	TaskID    TaskID
+	UserID    UserID
+	Action    HistoryAction
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+// FieldChange is the values of a field of a task before and after a change.
+// The values of an unset field, e.g. of the deadline before creation, are empty.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// TaskTimeline is the history of a task together with the users who made the changes and the assignee of the task.
+type TaskTimeline struct {
+	Histories []*TaskHistory
+	Users     map[UserID]*User
+}
+
+// IsBySystem reports whether the change was made by the server rather than a user.
+func (h TaskHistory) IsBySystem() bool {
+	return h.UserID == SystemUserID
+}
+
+// historyFields are the fields recorded in the history. Bookkeeping fields like the notification count are left out,
+// and so is the share token, which must not leak through the history.
+var historyFields = []struct {
+	name  string
+	value func(Task) string
+}{
+	{"name", func(t Task) string { return t.Name }},
+	{"detail", func(t Task) string { return t.Detail }},
+	{"status", func(t Task) string { return t.Status.String() }},
+	{"deadline", func(t Task) string { return t.Due().String() }},
+	{"recurrence", func(t Task) string { return string(t.Recurrence) }},
+	{"priority", func(t Task) string { return t.Priority.String() }},
+	{"visibility", func(t Task) string { return t.Visibility.String() }},
+	{"assignee_id", func(t Task) string { return string(t.AssigneeID) }},
+	{"parent_id", func(t Task) string {
+		if t.ParentID == nil {
+			return ""
+		}
+
+		return string(*t.ParentID)
+	}},
+	{"postponed_count", func(t Task) string { return strconv.Itoa(t.PostponedCount) }},
+	{"completion_date", func(t Task) string { return formatHistoryTime(t.CompletionDate) }},
+	{"archived_at", func(t Task) string { return formatHistoryTime(t.ArchivedAt) }},
+	{"trashed_at", func(t Task) string { return formatHistoryTime(t.TrashedAt) }},
+}
+
+// TaskChanges returns the changes of the recorded fields from before to after.
+// A nil before means the task is created, so that every field which is set is a change.
+func TaskChanges(before *Task, after Task) []FieldChange {
+	var changes []FieldChange
+
+	for _, f := range historyFields {
+		a := f.value(after)
+
+		var b string
+		if before != nil {
+			b = f.value(*before)
+		}
+
+		if b != a {
+			changes = append(changes, FieldChange{Field: f.name, Before: b, After: a})
+		}
+	}
+
+	return changes
+}
+
+// NewTaskHistory records the change of the task by the user. A nil before means the task is created.
+// It returns nil when none of the recorded fields changed.
+func NewTaskHistory(id HistoryID, userID UserID, before *Task, after Task, now time.Time) *TaskHistory {
+	changes := TaskChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	action := HistoryUpdate
+	if before == nil {
+		action = HistoryCreate
+	}
+
+	return &TaskHistory{
+		ID:        id,
+		TaskID:    after.ID,
+		UserID:    userID,
+		Action:    action,
+		Changes:   changes,
+		CreatedAt: now,
+	}
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
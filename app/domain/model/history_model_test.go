@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaskHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.UTC)
+	deadline := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+	task := Task{ID: "task_id", UserID: "user_id", Name: "Report", Deadline: deadline, Priority: P4, ShareToken: "token"}
+
+	renamed := task
+	renamed.Name = "Weekly report"
+	renamed.Deadline = deadline.AddDate(0, 0, 1)
+
+	reshared := task
+	reshared.ShareToken = "new_token"
+	reshared.NotificationCount = 1
+
+	tests := []struct {
+		name           string
+		before         *Task
+		after          Task
+		expectedOutput *TaskHistory
+	}{
+		{
+			"normal case: create",
+			nil,
+			task,
+			&TaskHistory{
+				ID:     "history_id",
+				TaskID: "task_id",
+				UserID: "user_id",
+				Action: HistoryCreate,
+				Changes: []FieldChange{
+					{Field: "name", After: "Report"},
+					{Field: "status", After: "working"},
+					{Field: "deadline", After: "2022-01-31"},
+					{Field: "priority", After: "P4"},
+					{Field: "visibility", After: "private"},
+					{Field: "postponed_count", After: "0"},
+				},
+				CreatedAt: now,
+			},
+		},
+		{
+			"normal case: update",
+			&task,
+			renamed,
+			&TaskHistory{
+				ID:     "history_id",
+				TaskID: "task_id",
+				UserID: "user_id",
+				Action: HistoryUpdate,
+				Changes: []FieldChange{
+					{Field: "name", Before: "Report", After: "Weekly report"},
+					{Field: "deadline", Before: "2022-01-31", After: "2022-02-01"},
+				},
+				CreatedAt: now,
+			},
+		},
+		{
+			"normal case: only unrecorded fields changed",
+			&task,
+			reshared,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output := NewTaskHistory("history_id", "user_id", tt.before, tt.after, now)
+			assert.Exactly(t, tt.expectedOutput, output)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Invitation is a link to join a workspace with a role. Whoever opens the link first can accept it, only once.
+type Invitation struct {
+	ID          InvitationID
+	WorkspaceID WorkspaceID
+	Token       string
+	Role        Role
+	InviterID   UserID
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	AcceptedBy  UserID
+	AcceptedAt  *time.Time
+}
+
+type InvitationID string
+
+const InvitationTTL = 7 * 24 * time.Hour
+
+// NewInvitation creates an invitation by the inviter, who has to be able to grant the role.
+func NewInvitation(id InvitationID, inviter Member, role Role, now time.Time) (*Invitation, error) {
+	if _, ok := roleNames[role]; !ok {
+		return nil, errors.Errorf("invalid role. role: %d", role)
+	}
+
+	if inviter.WorkspaceID == PersonalWorkspaceID(inviter.UserID) {
+		return nil, errors.New("nobody can be invited to personal workspace")
+	}
+
+	if !inviter.Role.CanGrant(role) {
+		return nil, errors.Errorf("%s cannot invite %s", inviter.Role, role)
+	}
+
+	return &Invitation{
+		ID:          id,
+		WorkspaceID: inviter.WorkspaceID,
+		Token:       string(CreateUUID()),
+		Role:        role,
+		InviterID:   inviter.UserID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(InvitationTTL),
+		AcceptedBy:  "",
+		AcceptedAt:  nil,
+	}, nil
+}
+
+// InvitationAccept accepts the invitation for the user, who becomes a member of the workspace with the invited role.
+func InvitationAccept(fetched Invitation, userID UserID, now time.Time) (*Invitation, *Member, error) {
+	if fetched.IsAccepted() {
+		return nil, nil, errors.Errorf("invitation is already accepted. id: %s", fetched.ID)
+	}
+
+	if fetched.IsExpired(now) {
+		return nil, nil, errors.Errorf("invitation is expired. id: %s", fetched.ID)
+	}
+
+	i := fetched
+	i.AcceptedBy = userID
+	i.AcceptedAt = &now
+
+	return &i, NewMember(i.WorkspaceID, userID, i.Role, now), nil
+}
+
+func (i Invitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+func (i Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
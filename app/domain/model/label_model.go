@@ -0,0 +1,106 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Label tags tasks. Labels are owned per user, so each user organizes the tasks they can view with their own labels.
+type Label struct {
+	ID     LabelID
+	UserID UserID
+	Name   string
+	Color  string
+}
+
+type LabelID string
+
+// LabelMatch decides whether a task has to carry all the labels of a filter or any of them.
+type LabelMatch int
+
+const (
+	MatchAll LabelMatch = iota
+	MatchAny
+)
+
+var labelMatchNames = map[LabelMatch]string{
+	MatchAll: "and",
+	MatchAny: "or",
+}
+
+func (m LabelMatch) String() string {
+	if name, ok := labelMatchNames[m]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// ParseLabelMatch parses "and" or "or". An empty name means MatchAll.
+func ParseLabelMatch(name string) (LabelMatch, error) {
+	if name == "" {
+		return MatchAll, nil
+	}
+
+	for m, n := range labelMatchNames {
+		if n == name {
+			return m, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown label match. name: %s", name)
+}
+
+const maxLabelNameLength = 50
+
+// DefaultLabelColor is the color of the labels which are made without choosing one, e.g. by an import.
+const DefaultLabelColor = "#6c757d"
+
+var colorValidater = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+func NewLabel(id LabelID, userID UserID, name, color string) (*Label, error) {
+	l := &Label{
+		ID:     id,
+		UserID: userID,
+		Name:   strings.TrimSpace(name),
+		Color:  strings.ToLower(color),
+	}
+
+	if err := LabelSpecSatisfied(*l); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Label spec. l: %+v", l)
+	}
+
+	return l, nil
+}
+
+func LabelSpecSatisfied(l Label) error {
+	if l.Name == "" {
+		return errors.New("label name is required")
+	}
+
+	if utf8.RuneCountInString(l.Name) > maxLabelNameLength {
+		return errors.Errorf("label name exceeds %d characters. name: %s", maxLabelNameLength, l.Name)
+	}
+
+	if !colorValidater.MatchString(l.Color) {
+		return errors.Errorf("label color must be formatted as #rrggbb. color: %s", l.Color)
+	}
+
+	return nil
+}
+
+func LabelSet(fetchedLabel Label, name, color string) (*Label, error) {
+	l, err := NewLabel(fetchedLabel.ID, fetchedLabel.UserID, name, color)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set label")
+	}
+
+	return l, nil
+}
+
+func (l Label) IsOwnedBy(userID UserID) bool {
+	return l.UserID == userID
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		labelName     string
+		color         string
+		expectedName  string
+		expectedColor string
+		expectedErr   error
+	}{
+		{"normal case", " Work ", "#FF8800", "Work", "#ff8800", nil},
+		{"error case: empty name", " ", "#ff8800", "", "", errors.New("label name is required")},
+		{"error case: too long name", strings.Repeat("a", 51), "#ff8800", "", "", errors.New("label name exceeds 50 characters")},
+		{"error case: invalid color", "Work", "red", "", "", errors.New("label color must be formatted as #rrggbb")},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := NewLabel("label", "owner", tt.labelName, tt.color)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedName, output.Name)
+				assert.Exactly(t, tt.expectedColor, output.Color)
+			}
+		})
+	}
+}
+
+func TestParseLabelMatch(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]LabelMatch{"": MatchAll, "and": MatchAll, "or": MatchAny} {
+		output, err := ParseLabelMatch(name)
+		assert.Nil(t, err)
+		assert.Exactly(t, expected, output)
+	}
+
+	_, err := ParseLabelMatch("xor")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Postponement is a request to move the deadline of a task which has already been postponed
+// POSTPONED_COUNT_LIMIT times. The deadline is only moved when the approver accepts the request.
+type Postponement struct {
+	ID          PostponementID
+	TaskID      TaskID
+	RequesterID UserID
+	ApproverID  UserID
+	// Deadline is the requested deadline, in the same form as the one of a task.
+	Deadline   time.Time
+	HasDueTime bool
+	TimeZone   string
+	Reason     string
+	Status     PostponementStatus
+	Comment    string
+	CreatedAt  time.Time
+	DecidedAt  *time.Time
+}
+
+type PostponementID string
+
+type PostponementStatus int
+
+const (
+	Pending PostponementStatus = iota
+	Approved
+	Rejected
+)
+
+var postponementStatusNames = map[PostponementStatus]string{
+	Pending:  "pending",
+	Approved: "approved",
+	Rejected: "rejected",
+}
+
+func (s PostponementStatus) String() string {
+	if name, ok := postponementStatusNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+const (
+	maxPostponementReasonLength  = 500
+	maxPostponementCommentLength = 500
+)
+
+// PostponementLimitReached reports whether the task has been postponed as often as it can be without approval.
+func (t Task) PostponementLimitReached() bool {
+	return t.PostponedCount >= POSTPONED_COUNT_LIMIT
+}
+
+// NeedsApprovalToPostpone reports whether moving the deadline of the task later needs an approved postponement.
+func (t Task) NeedsApprovalToPostpone(due Due) bool {
+	return due.After(t.Due()) && t.PostponementLimitReached()
+}
+
+// Due returns the requested deadline in its time zone.
+func (p Postponement) Due() Due {
+	return Due{At: p.Deadline.In(LoadLocation(p.TimeZone)), HasTime: p.HasDueTime}
+}
+
+// PostponementApprover returns the user who decides on the postponement of the task requested by the requester.
+// It is the creator of the task, unless the creator requests it. Then it is the oldest admin or owner of the workspace
+// among the members other than the creator, since nobody approves their own postponement.
+func PostponementApprover(t Task, requesterID UserID, members []*Member) (UserID, error) {
+	if t.UserID != requesterID {
+		return t.UserID, nil
+	}
+
+	for _, m := range members {
+		if m.UserID != requesterID && m.Role >= RoleAdmin {
+			return m.UserID, nil
+		}
+	}
+
+	return "", errors.Errorf("nobody but the requester can approve postponement. taskID: %s", t.ID)
+}
+
+func NewPostponement(id PostponementID, t Task, requesterID, approverID UserID, due Due, reason string, now time.Time) (*Postponement, error) {
+	if t.IsTrashed() {
+		return nil, errors.New("trashed task cannot be postponed")
+	}
+
+	if requesterID == approverID {
+		return nil, errors.New("requester cannot approve own postponement")
+	}
+
+	if due.HasTime {
+		due = DueAt(due.At)
+	} else {
+		due = DueOn(due.At)
+	}
+
+	if !t.NeedsApprovalToPostpone(due) {
+		return nil, errors.Errorf("postponement does not need approval. taskID: %s, deadline: %s", t.ID, due)
+	}
+
+	p := &Postponement{
+		ID:          id,
+		TaskID:      t.ID,
+		RequesterID: requesterID,
+		ApproverID:  approverID,
+		Deadline:    due.At,
+		HasDueTime:  due.HasTime,
+		TimeZone:    due.At.Location().String(),
+		Reason:      strings.TrimSpace(reason),
+		Status:      Pending,
+		Comment:     "",
+		CreatedAt:   now,
+		DecidedAt:   nil,
+	}
+
+	if err := PostponementSpecSatisfied(*p); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Postponement spec. p: %+v", p)
+	}
+
+	return p, nil
+}
+
+func PostponementSpecSatisfied(p Postponement) error {
+	if p.Reason == "" {
+		return errors.New("reason for postponement is required")
+	}
+
+	if utf8.RuneCountInString(p.Reason) > maxPostponementReasonLength {
+		return errors.Errorf("reason for postponement exceeds %d characters", maxPostponementReasonLength)
+	}
+
+	if utf8.RuneCountInString(p.Comment) > maxPostponementCommentLength {
+		return errors.Errorf("comment on postponement exceeds %d characters", maxPostponementCommentLength)
+	}
+
+	return nil
+}
+
+// PostponementDecide approves or rejects the pending postponement with the comment of the approver.
+func PostponementDecide(fetched Postponement, approved bool, comment string, now time.Time) (*Postponement, error) {
+	if fetched.Status != Pending {
+		return nil, errors.Errorf("postponement is already %s. id: %s", fetched.Status, fetched.ID)
+	}
+
+	p := fetched
+	p.Status = Rejected
+	p.Comment = strings.TrimSpace(comment)
+	p.DecidedAt = &now
+
+	if approved {
+		p.Status = Approved
+	}
+
+	if err := PostponementSpecSatisfied(p); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Postponement spec. p: %+v", p)
+	}
+
+	return &p, nil
+}
+
+// TaskPostpone moves the deadline of the task as the approved postponement.
+// The postponed count is left as is, since it counts the postponements which did not need approval.
+func TaskPostpone(fetchedTask Task, p Postponement) (*Task, error) {
+	if fetchedTask.IsTrashed() {
+		return nil, errors.New("trashed task cannot be postponed")
+	}
+
+	if p.Status != Approved {
+		return nil, errors.Errorf("postponement is not approved. id: %s", p.ID)
+	}
+
+	t := fetchedTask
+	t.Deadline = p.Deadline
+	t.HasDueTime = p.HasDueTime
+	t.TimeZone = p.TimeZone
+
+	if t.Status == Behind {
+		t.Status = Working
+	}
+
+	return calculate(t), nil
+}
+
+func (p Postponement) IsPending() bool {
+	return p.Status == Pending
+}
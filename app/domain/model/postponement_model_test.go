@@ -0,0 +1,139 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskNeedsApprovalToPostpone(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Date(2022, 1, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name           string
+		postponedCount int
+		deadline       time.Time
+		expectedOutput bool
+	}{
+		{"postponed below limit", POSTPONED_COUNT_LIMIT - 1, deadline.AddDate(0, 0, 1), false},
+		{"postponed to limit", POSTPONED_COUNT_LIMIT, deadline.AddDate(0, 0, 1), true},
+		{"same day at limit", POSTPONED_COUNT_LIMIT, deadline.Add(10 * time.Hour), false},
+		{"brought forward at limit", POSTPONED_COUNT_LIMIT, deadline.AddDate(0, 0, -1), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{Deadline: deadline, TimeZone: time.Local.String(), PostponedCount: tt.postponedCount}
+			assert.Exactly(t, tt.expectedOutput, task.NeedsApprovalToPostpone(DueOn(tt.deadline)))
+		})
+	}
+}
+
+func TestNewPostponement(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.Local)
+	deadline := time.Date(2022, 1, 31, 0, 0, 0, 0, time.Local)
+	task := Task{ID: "task_id", UserID: "creator", Deadline: deadline, TimeZone: time.Local.String(), PostponedCount: POSTPONED_COUNT_LIMIT}
+
+	output, err := NewPostponement("id", task, "requester", "creator", DueOn(deadline.AddDate(0, 0, 7)), " Venue is closed ", now)
+	assert.Nil(t, err)
+	assert.Exactly(t, &Postponement{
+		ID:          "id",
+		TaskID:      "task_id",
+		RequesterID: "requester",
+		ApproverID:  "creator",
+		Deadline:    deadline.AddDate(0, 0, 7),
+		TimeZone:    time.Local.String(),
+		Reason:      "Venue is closed",
+		Status:      Pending,
+		CreatedAt:   now,
+	}, output)
+
+	_, err = NewPostponement("id", task, "requester", "creator", DueOn(deadline.AddDate(0, 0, 7)), "", now)
+	assert.NotNil(t, err)
+
+	_, err = NewPostponement("id", task, "requester", "creator", DueOn(deadline.AddDate(0, 0, 7)), strings.Repeat("a", maxPostponementReasonLength+1), now)
+	assert.NotNil(t, err)
+
+	_, err = NewPostponement("id", task, "creator", "creator", DueOn(deadline.AddDate(0, 0, 7)), "Venue is closed", now)
+	assert.NotNil(t, err)
+
+	task.PostponedCount = 0
+	_, err = NewPostponement("id", task, "requester", "creator", DueOn(deadline.AddDate(0, 0, 7)), "Venue is closed", now)
+	assert.NotNil(t, err)
+}
+
+func TestPostponementApprover(t *testing.T) {
+	t.Parallel()
+
+	task := Task{ID: "task_id", UserID: "creator"}
+	members := []*Member{{UserID: "creator", Role: RoleOwner}, {UserID: "member", Role: RoleMember}, {UserID: "admin", Role: RoleAdmin}}
+
+	tests := []struct {
+		name        string
+		requesterID UserID
+		members     []*Member
+		expected    UserID
+		expectedErr bool
+	}{
+		{"normal case: other user requests", "member", nil, "creator", false},
+		{"normal case: creator requests", "creator", members, "admin", false},
+		{"error case: nobody but creator can approve", "creator", members[:2], "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := PostponementApprover(task, tt.requesterID, tt.members)
+			assert.Exactly(t, tt.expectedErr, err != nil, "unexpected error: %v", err)
+			assert.Exactly(t, tt.expected, output)
+		})
+	}
+}
+
+func TestPostponementDecide(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.Local)
+	pending := Postponement{ID: "id", Reason: "Venue is closed", Status: Pending}
+
+	output, err := PostponementDecide(pending, true, " OK ", now)
+	assert.Nil(t, err)
+	assert.Exactly(t, Approved, output.Status)
+	assert.Exactly(t, "OK", output.Comment)
+	assert.Exactly(t, &now, output.DecidedAt)
+
+	output, err = PostponementDecide(pending, false, "", now)
+	assert.Nil(t, err)
+	assert.Exactly(t, Rejected, output.Status)
+
+	_, err = PostponementDecide(*output, true, "", now)
+	assert.NotNil(t, err)
+}
+
+func TestTaskPostpone(t *testing.T) {
+	t.Parallel()
+
+	dl := time.Now().AddDate(0, 0, 7)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+	task := Task{ID: "task_id", Status: Behind, Deadline: deadline.AddDate(0, 0, -14), PostponedCount: POSTPONED_COUNT_LIMIT}
+
+	output, err := TaskPostpone(task, Postponement{TaskID: "task_id", Deadline: deadline, Status: Approved})
+	assert.Nil(t, err)
+	assert.Exactly(t, deadline, output.Deadline)
+	assert.Exactly(t, Working, output.Status)
+	assert.Exactly(t, POSTPONED_COUNT_LIMIT, output.PostponedCount)
+
+	_, err = TaskPostpone(task, Postponement{TaskID: "task_id", Deadline: deadline, Status: Rejected})
+	assert.NotNil(t, err)
+}
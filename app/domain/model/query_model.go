@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// TaskQuery selects a page of the tasks which the viewer can see, i.e. owned by or shared with the viewer.
+// Archived and trashed tasks are excluded. The zero values of the filters mean no filtering.
+type TaskQuery struct {
+	ViewerID     UserID
+	OwnerID      UserID
+	AssigneeID   UserID
+	WorkspaceID  WorkspaceID
+	Statuses     []Status
+	DeadlineFrom *time.Time
+	DeadlineTo   *time.Time
+	// Text matches the name or the detail of tasks.
+	Text       string
+	LabelIDs   []LabelID
+	LabelMatch LabelMatch
+	Sort       TaskSort
+	Limit      int
+	// Cursor is the opaque position returned as TaskPage.NextCursor. An empty cursor means the first page.
+	Cursor string
+}
+
+// TaskPage is a page of tasks. NextCursor is empty on the last page.
+type TaskPage struct {
+	Tasks      []*Task
+	NextCursor string
+}
+
+// PageSize returns the limit of the query, falling back to DefaultPageSize and capped at MaxPageSize.
+func (q TaskQuery) PageSize() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultPageSize
+	case q.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return q.Limit
+	}
+}
+
+func TaskQuerySpecSatisfied(q TaskQuery) error {
+	if q.ViewerID == "" {
+		return errors.New("viewer of query is required")
+	}
+
+	if q.DeadlineFrom != nil && q.DeadlineTo != nil && q.DeadlineFrom.After(*q.DeadlineTo) {
+		return errors.Errorf("deadline range is reversed. from: %s, to: %s", q.DeadlineFrom, q.DeadlineTo)
+	}
+
+	for _, s := range q.Statuses {
+		if _, ok := statusNames[s]; !ok {
+			return errors.Errorf("invalid status. status: %d", s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskQuerySpecSatisfied(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2022, 1, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		input       TaskQuery
+		expectedErr error
+	}{
+		{"normal case", TaskQuery{ViewerID: "user_id", Statuses: []Status{Working, Behind}, DeadlineFrom: &from, DeadlineTo: &to}, nil},
+		{"normal case: same day range", TaskQuery{ViewerID: "user_id", DeadlineFrom: &from, DeadlineTo: &from}, nil},
+		{"error case: no viewer", TaskQuery{}, errors.New("viewer of query is required")},
+		{"error case: reversed range", TaskQuery{ViewerID: "user_id", DeadlineFrom: &to, DeadlineTo: &from}, errors.New("deadline range is reversed")},
+		{"error case: unknown status", TaskQuery{ViewerID: "user_id", Statuses: []Status{Status(9)}}, errors.New("invalid status")},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TaskQuerySpecSatisfied(tt.input)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskQueryPageSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          int
+		expectedOutput int
+	}{
+		{"normal case: default", 0, DefaultPageSize},
+		{"normal case: negative", -1, DefaultPageSize},
+		{"normal case: given", 20, 20},
+		{"normal case: capped", MaxPageSize + 1, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Exactly(t, tt.expectedOutput, TaskQuery{Limit: tt.input}.PageSize())
+		})
+	}
+}
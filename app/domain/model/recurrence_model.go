@@ -0,0 +1,288 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Recurrence is an RRULE-style rule, e.g. "FREQ=WEEKLY;BYDAY=MO,TH", which makes a task repeat.
+// The supported rules are
+//   - FREQ=DAILY[;INTERVAL=N]: every N days after the deadline
+//   - FREQ=DAILY[;INTERVAL=N];FROM=COMPLETION: every N days after the completion
+//   - FREQ=WEEKLY[;INTERVAL=N][;BYDAY=MO,TU,...]: on the given weekdays of every N weeks
+//   - FREQ=MONTHLY[;INTERVAL=N];BYMONTHDAY=D: on day D of every N months, or the last day of shorter months
+//   - FREQ=MONTHLY[;INTERVAL=N];BYDAY=-1FR: on the last given weekday of every N months
+//
+// An empty rule means the task does not repeat.
+type Recurrence string
+
+const NoRecurrence Recurrence = ""
+
+const (
+	daily   = "DAILY"
+	weekly  = "WEEKLY"
+	monthly = "MONTHLY"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type recurrenceRule struct {
+	freq           string
+	interval       int
+	weekdays       map[time.Weekday]bool
+	monthDay       int
+	lastWeekday    *time.Weekday
+	fromCompletion bool
+}
+
+// ParseRecurrence validates the rule and normalizes it to upper case.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence(strings.ToUpper(strings.TrimSpace(rule)))
+
+	if _, err := r.rule(); err != nil {
+		return NoRecurrence, err
+	}
+
+	return r, nil
+}
+
+func (r Recurrence) IsRecurring() bool {
+	return r != NoRecurrence
+}
+
+func (r Recurrence) rule() (*recurrenceRule, error) {
+	if !r.IsRecurring() {
+		return nil, nil
+	}
+
+	rule := &recurrenceRule{interval: 1}
+
+	for _, part := range strings.Split(string(r), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid recurrence part. part: %s", part)
+		}
+
+		switch key, value := kv[0], kv[1]; key {
+		case "FREQ":
+			if value != daily && value != weekly && value != monthly {
+				return nil, errors.Errorf("unsupported recurrence frequency. freq: %s", value)
+			}
+
+			rule.freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, errors.Errorf("recurrence interval must be a positive number. interval: %s", value)
+			}
+
+			rule.interval = n
+		case "BYDAY":
+			if err := rule.parseByDay(value); err != nil {
+				return nil, err
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return nil, errors.Errorf("recurrence month day must be between 1 and 31. bymonthday: %s", value)
+			}
+
+			rule.monthDay = n
+		case "FROM":
+			if value != "COMPLETION" {
+				return nil, errors.Errorf("unsupported recurrence basis. from: %s", value)
+			}
+
+			rule.fromCompletion = true
+		default:
+			return nil, errors.Errorf("unsupported recurrence part. key: %s", key)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid recurrence. recurrence: %s", r)
+	}
+
+	return rule, nil
+}
+
+func (rule *recurrenceRule) parseByDay(value string) error {
+	if strings.HasPrefix(value, "-1") {
+		wd, ok := weekdayNames[strings.TrimPrefix(value, "-1")]
+		if !ok {
+			return errors.Errorf("invalid recurrence weekday. byday: %s", value)
+		}
+
+		rule.lastWeekday = &wd
+
+		return nil
+	}
+
+	rule.weekdays = map[time.Weekday]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		wd, ok := weekdayNames[name]
+		if !ok {
+			return errors.Errorf("invalid recurrence weekday. byday: %s", value)
+		}
+
+		rule.weekdays[wd] = true
+	}
+
+	return nil
+}
+
+func (rule *recurrenceRule) validate() error {
+	switch rule.freq {
+	case "":
+		return errors.New("recurrence frequency is required")
+	case daily:
+		if rule.weekdays != nil || rule.lastWeekday != nil || rule.monthDay != 0 {
+			return errors.New("daily recurrence cannot have BYDAY or BYMONTHDAY")
+		}
+	case weekly:
+		if rule.lastWeekday != nil || rule.monthDay != 0 {
+			return errors.New("weekly recurrence can only have plain weekdays")
+		}
+	case monthly:
+		if rule.weekdays != nil || (rule.lastWeekday == nil) == (rule.monthDay == 0) {
+			return errors.New("monthly recurrence needs either BYMONTHDAY or a last weekday like BYDAY=-1FR")
+		}
+	}
+
+	if rule.fromCompletion && rule.freq != daily {
+		return errors.New("only daily recurrence can repeat from the completion")
+	}
+
+	return nil
+}
+
+// next returns the first date of the rule after base.
+func (rule *recurrenceRule) next(base time.Time) time.Time {
+	switch rule.freq {
+	case weekly:
+		return rule.nextWeekly(base)
+	case monthly:
+		return rule.nextMonthly(base)
+	default:
+		return base.AddDate(0, 0, rule.interval)
+	}
+}
+
+func (rule *recurrenceRule) nextWeekly(base time.Time) time.Time {
+	if len(rule.weekdays) == 0 {
+		return base.AddDate(0, 0, 7*rule.interval)
+	}
+
+	// INFO: weeks start on Monday, and only every interval-th week counted from the week of base is eligible
+	weekStart := base.AddDate(0, 0, -(int(base.Weekday())+6)%7)
+
+	for d := 1; ; d++ {
+		c := base.AddDate(0, 0, d)
+		week := int(c.Sub(weekStart).Hours()/24+0.5) / 7
+
+		if week%rule.interval == 0 && rule.weekdays[c.Weekday()] {
+			return c
+		}
+	}
+}
+
+func (rule *recurrenceRule) nextMonthly(base time.Time) time.Time {
+	for m := 0; ; m += rule.interval {
+		first := time.Date(base.Year(), base.Month()+time.Month(m), 1, 0, 0, 0, 0, base.Location())
+		last := first.AddDate(0, 1, -1)
+
+		var c time.Time
+
+		if rule.lastWeekday != nil {
+			c = last.AddDate(0, 0, -(int(last.Weekday())-int(*rule.lastWeekday)+7)%7)
+		} else if rule.monthDay > last.Day() {
+			c = last
+		} else {
+			c = first.AddDate(0, 0, rule.monthDay-1)
+		}
+
+		if c.After(base) {
+			return c
+		}
+	}
+}
+
+// TaskRepeat sets the recurrence rule of the task. NoRecurrence stops the repetition.
+func TaskRepeat(fetchedTask Task, recurrence Recurrence) (*Task, error) {
+	if _, err := recurrence.rule(); err != nil {
+		return nil, errors.Wrap(err, "failed to set recurrence")
+	}
+
+	t := fetchedTask
+	t.Recurrence = recurrence
+
+	return &t, nil
+}
+
+// NextOccurrence generates the next occurrence of a completed recurring task with a fresh deadline and counts.
+// The recurrence rule is handed over to the next occurrence, so that the completed one no longer repeats
+// and reopening and completing it again does not generate another occurrence.
+// It returns the completed task without the rule and the next occurrence.
+func NextOccurrence(id TaskID, completed Task, now time.Time) (*Task, *Task, error) {
+	if completed.Status != Completed {
+		return nil, nil, errors.Errorf("only completed task can recur. taskID: %s", completed.ID)
+	}
+
+	rule, err := completed.Recurrence.rule()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get recurrence rule")
+	} else if rule == nil {
+		return nil, nil, errors.Errorf("task does not recur. taskID: %s", completed.ID)
+	}
+
+	// INFO: the occurrences follow the days in the time zone of the task and keep its due time
+	due := completed.Due()
+	today := startOfDay(now.In(due.At.Location()))
+
+	var day time.Time
+
+	if rule.fromCompletion {
+		day = rule.next(today)
+	} else {
+		// INFO: occurrences which already passed while the task was late are skipped
+		day = rule.next(due.Day())
+		for day.Before(today) {
+			day = rule.next(day)
+		}
+	}
+
+	due.At = time.Date(day.Year(), day.Month(), day.Day(), due.At.Hour(), due.At.Minute(), 0, 0, day.Location())
+
+	next, err := NewTask(id, completed.UserID, completed.Name, completed.Detail, due)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create next occurrence")
+	}
+
+	next.AssigneeID = completed.AssigneeID
+	next.WorkspaceID = completed.WorkspaceID
+	next.ParentID = completed.ParentID
+	next.Recurrence = completed.Recurrence
+	next.Priority = completed.Priority
+
+	next, err = TaskShare(*next, completed.Visibility)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to share next occurrence")
+	}
+
+	done := completed
+	done.Recurrence = NoRecurrence
+
+	return &done, next, nil
+}
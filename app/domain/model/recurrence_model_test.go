@@ -0,0 +1,186 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput Recurrence
+		expectedErr    error
+	}{
+		{"normal case: no recurrence", "", NoRecurrence, nil},
+		{"normal case: lower case", "freq=weekly;byday=mo,th", Recurrence("FREQ=WEEKLY;BYDAY=MO,TH"), nil},
+		{"normal case: after completion", "FREQ=DAILY;INTERVAL=3;FROM=COMPLETION", Recurrence("FREQ=DAILY;INTERVAL=3;FROM=COMPLETION"), nil},
+		{"normal case: last weekday", "FREQ=MONTHLY;BYDAY=-1FR", Recurrence("FREQ=MONTHLY;BYDAY=-1FR"), nil},
+		{"error case: unknown frequency", "FREQ=YEARLY", NoRecurrence, errors.New("unsupported recurrence frequency")},
+		{"error case: zero interval", "FREQ=DAILY;INTERVAL=0", NoRecurrence, errors.New("recurrence interval must be a positive number")},
+		{"error case: unknown weekday", "FREQ=WEEKLY;BYDAY=XX", NoRecurrence, errors.New("invalid recurrence weekday")},
+		{"error case: monthly without day", "FREQ=MONTHLY", NoRecurrence, errors.New("monthly recurrence needs either")},
+		{"error case: weekly after completion", "FREQ=WEEKLY;FROM=COMPLETION", NoRecurrence, errors.New("only daily recurrence")},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := ParseRecurrence(tt.input)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	t.Parallel()
+
+	// INFO: 2022-01-26 is Wednesday
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	completionDate := time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name             string
+		recurrence       Recurrence
+		deadline         time.Time
+		now              time.Time
+		expectedDeadline time.Time
+	}{
+		{
+			"daily",
+			"FREQ=DAILY",
+			deadline,
+			deadline,
+			time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"every 3 days after completion",
+			"FREQ=DAILY;INTERVAL=3;FROM=COMPLETION",
+			deadline,
+			completionDate,
+			time.Date(2022, 1, 30, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"weekly on the same weekday",
+			"FREQ=WEEKLY",
+			deadline,
+			deadline,
+			time.Date(2022, 2, 2, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"weekly on Monday and Thursday",
+			"FREQ=WEEKLY;BYDAY=MO,TH",
+			deadline,
+			deadline,
+			time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"every 2 weeks on Monday",
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+			deadline,
+			deadline,
+			time.Date(2022, 2, 7, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"monthly on day 31 in a shorter month",
+			"FREQ=MONTHLY;BYMONTHDAY=31",
+			time.Date(2022, 1, 31, 0, 0, 0, 0, time.Local),
+			deadline,
+			time.Date(2022, 2, 28, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"monthly on a later day of the same month",
+			"FREQ=MONTHLY;BYMONTHDAY=28",
+			deadline,
+			deadline,
+			time.Date(2022, 1, 28, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"monthly on the last Friday",
+			"FREQ=MONTHLY;BYDAY=-1FR",
+			time.Date(2022, 1, 28, 0, 0, 0, 0, time.Local),
+			deadline,
+			time.Date(2022, 2, 25, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"passed occurrences are skipped",
+			"FREQ=DAILY",
+			deadline,
+			time.Date(2022, 2, 3, 9, 0, 0, 0, time.Local),
+			time.Date(2022, 2, 3, 0, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			completed := Task{
+				ID:                "done",
+				UserID:            "owner",
+				Name:              "Weekly report",
+				Status:            Completed,
+				CompletionDate:    &completionDate,
+				Deadline:          tt.deadline,
+				TimeZone:          time.Local.String(),
+				Recurrence:        tt.recurrence,
+				NotificationCount: 2,
+				PostponedCount:    1,
+			}
+
+			done, next, err := NextOccurrence("next", completed, tt.now)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			assert.Exactly(t, NoRecurrence, done.Recurrence)
+			assert.Exactly(t, TaskID("next"), next.ID)
+			assert.Exactly(t, tt.expectedDeadline, next.Deadline)
+			assert.Exactly(t, tt.recurrence, next.Recurrence)
+			assert.Exactly(t, Working, next.Status)
+			assert.Exactly(t, 0, next.NotificationCount)
+			assert.Exactly(t, 0, next.PostponedCount)
+		})
+	}
+}
+
+func TestNextOccurrenceOfWorkingTask(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := NextOccurrence("next", Task{ID: "working", Status: Working, Recurrence: "FREQ=DAILY"}, time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestNextOccurrenceKeepsDueTime(t *testing.T) {
+	t.Parallel()
+
+	// INFO: summer time starts in Berlin on 2022-03-27, and the next occurrence is still due at 09:00 there
+	berlin := LoadLocation("Europe/Berlin")
+	completed := Task{ID: "done", Status: Completed, Deadline: time.Date(2022, 3, 26, 9, 0, 0, 0, berlin), HasDueTime: true, TimeZone: "Europe/Berlin", Recurrence: "FREQ=DAILY"}
+
+	_, next, err := NextOccurrence("next", completed, time.Date(2022, 3, 26, 7, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Exactly(t, time.Date(2022, 3, 27, 9, 0, 0, 0, berlin), next.Deadline)
+	assert.Exactly(t, "Europe/Berlin", next.TimeZone)
+	assert.True(t, next.HasDueTime)
+}
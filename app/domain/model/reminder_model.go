@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ReminderKind int
+
+const (
+	DueSoon ReminderKind = iota
+	DueToday
+	Overdue
+)
+
+// reminderOffsets are the days relative to the deadline on which a reminder becomes due.
+// The number of offsets matches NOTIFICATION_COUNT_LIMIT.
+var reminderOffsets = []int{-1, 0, 1, 2, 3}
+
+// ReminderDue reports whether a reminder of the task should be sent at now.
+// Reminders which were missed are sent one per day, so that the owner is not flooded.
+// The days are counted in the time zone of the task.
+func ReminderDue(t Task, now time.Time) bool {
+	if t.Status == Completed || t.NotificationCount >= NOTIFICATION_COUNT_LIMIT {
+		return false
+	}
+
+	loc := t.Location()
+	today := truncateDay(now, loc)
+
+	if t.LastNotifiedAt != nil && !truncateDay(*t.LastNotifiedAt, loc).Before(today) {
+		return false
+	}
+
+	due := 0
+
+	for _, offset := range reminderOffsets {
+		if !today.Before(t.Due().Day().AddDate(0, 0, offset)) {
+			due++
+		}
+	}
+
+	return t.NotificationCount < due
+}
+
+func ReminderKindAt(t Task, now time.Time) ReminderKind {
+	today := truncateDay(now, t.Location())
+	day := t.Due().Day()
+
+	switch {
+	case today.Before(day):
+		return DueSoon
+	case today.Equal(day):
+		return DueToday
+	default:
+		return Overdue
+	}
+}
+
+// TaskNotified records that a reminder of the task was sent at now.
+func TaskNotified(fetchedTask Task, now time.Time) (*Task, error) {
+	t := fetchedTask
+	t.NotificationCount++
+	t.LastNotifiedAt = &now
+
+	if err := TaskSpecSatisfied(t); err != nil {
+		return nil, errors.Wrap(err, "failed to record notification")
+	}
+
+	return &t, nil
+}
+
+func truncateDay(t time.Time, loc *time.Location) time.Time {
+	return startOfDay(t.In(loc))
+}
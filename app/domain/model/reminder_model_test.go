@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderDue(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	notifiedToday := time.Date(2022, 1, 26, 8, 0, 0, 0, time.Local)
+	notifiedYesterday := time.Date(2022, 1, 25, 8, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		status            Status
+		notificationCount int
+		lastNotifiedAt    *time.Time
+		now               time.Time
+		expected          bool
+	}{
+		{"two days before deadline", Working, 0, nil, time.Date(2022, 1, 24, 10, 0, 0, 0, time.Local), false},
+		{"a day before deadline", Working, 0, nil, time.Date(2022, 1, 25, 10, 0, 0, 0, time.Local), true},
+		{"on the day of deadline", Working, 1, &notifiedYesterday, time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local), true},
+		{"already notified today", Working, 1, &notifiedToday, time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local), false},
+		{"already notified for the day", Working, 2, &notifiedYesterday, time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local), false},
+		{"after deadline", Behind, 2, &notifiedToday, time.Date(2022, 1, 27, 10, 0, 0, 0, time.Local), true},
+		{"count reaches limit", Behind, 5, &notifiedToday, time.Date(2022, 2, 27, 10, 0, 0, 0, time.Local), false},
+		{"completed", Completed, 0, nil, time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{Status: tt.status, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: tt.notificationCount, LastNotifiedAt: tt.lastNotifiedAt}
+			assert.Exactly(t, tt.expected, ReminderDue(task, tt.now))
+		})
+	}
+}
+
+func TestReminderKindAt(t *testing.T) {
+	t.Parallel()
+
+	task := Task{Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()}
+
+	assert.Exactly(t, DueSoon, ReminderKindAt(task, time.Date(2022, 1, 25, 23, 0, 0, 0, time.Local)))
+	assert.Exactly(t, DueToday, ReminderKindAt(task, time.Date(2022, 1, 26, 23, 0, 0, 0, time.Local)))
+	assert.Exactly(t, Overdue, ReminderKindAt(task, time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local)))
+
+	// INFO: the days are the ones of the time zone of the task, whatever the zone of now is
+	tokyo := LoadLocation("Asia/Tokyo")
+	task = Task{Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo"}
+
+	assert.Exactly(t, DueSoon, ReminderKindAt(task, time.Date(2022, 1, 25, 14, 0, 0, 0, time.UTC)))
+	assert.Exactly(t, DueToday, ReminderKindAt(task, time.Date(2022, 1, 25, 15, 0, 0, 0, time.UTC)))
+}
+
+func TestTaskNotified(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+
+	output, err := TaskNotified(Task{NotificationCount: 4}, now)
+	assert.Nil(t, err)
+	assert.Exactly(t, 5, output.NotificationCount)
+	assert.Exactly(t, &now, output.LastNotifiedAt)
+
+	_, err = TaskNotified(Task{NotificationCount: 5}, now)
+	assert.Contains(t, err.Error(), "notification counts exceeds limit")
+}
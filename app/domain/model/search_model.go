@@ -0,0 +1,130 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// TaskSearch looks up the tasks which the viewer can see by the words in their names or details.
+// Archived and trashed tasks are excluded.
+type TaskSearch struct {
+	ViewerID UserID
+	Text     string
+	Limit    int
+}
+
+// TaskHit is a task found by a search. A higher score means a better match.
+type TaskHit struct {
+	Task   *Task
+	Score  float64
+	Name   []Fragment
+	Detail []Fragment
+}
+
+// Fragment is a part of a text, which is highlighted when it matches a search term.
+type Fragment struct {
+	Text  string
+	Match bool
+}
+
+// Terms returns the distinct words of the search text in lower case.
+func (s TaskSearch) Terms() []string {
+	var terms []string
+
+	seen := map[string]bool{}
+
+	for _, f := range strings.Fields(strings.ToLower(s.Text)) {
+		if !seen[f] {
+			seen[f] = true
+			terms = append(terms, f)
+		}
+	}
+
+	return terms
+}
+
+// PageSize returns the limit of the search, falling back to DefaultPageSize and capped at MaxPageSize.
+func (s TaskSearch) PageSize() int {
+	return TaskQuery{Limit: s.Limit}.PageSize()
+}
+
+func TaskSearchSpecSatisfied(s TaskSearch) error {
+	if s.ViewerID == "" {
+		return errors.New("viewer of search is required")
+	}
+
+	if len(s.Terms()) == 0 {
+		return errors.New("search text is required")
+	}
+
+	return nil
+}
+
+// TaskHighlight returns the hit with the terms highlighted in the name and the detail of the task.
+func TaskHighlight(hit TaskHit, terms []string) *TaskHit {
+	h := hit
+	h.Name = Highlight(hit.Task.Name, terms)
+	h.Detail = Highlight(hit.Task.Detail, terms)
+
+	return &h
+}
+
+// Highlight splits the text into fragments, marking the case-insensitive occurrences of the terms.
+// The longest term wins where terms overlap.
+func Highlight(text string, terms []string) []Fragment {
+	var fragments []Fragment
+
+	add := func(s string, match bool) {
+		if s == "" {
+			return
+		}
+
+		if n := len(fragments); n > 0 && fragments[n-1].Match == match {
+			fragments[n-1].Text += s
+
+			return
+		}
+
+		fragments = append(fragments, Fragment{Text: s, Match: match})
+	}
+
+	start := 0
+
+	for i := 0; i < len(text); {
+		if n := matchLength(text[i:], terms); n > 0 {
+			add(text[start:i], false)
+			add(text[i:i+n], true)
+			i += n
+			start = i
+
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+
+	add(text[start:], false)
+
+	return fragments
+}
+
+// matchLength returns the length in bytes of the longest term which the text starts with, or 0.
+func matchLength(text string, terms []string) int {
+	longest := 0
+
+	for _, term := range terms {
+		n := len(term)
+		if term == "" || n > len(text) || n <= longest {
+			continue
+		}
+
+		if (n == len(text) || utf8.RuneStart(text[n])) && strings.EqualFold(text[:n], term) {
+			longest = n
+		}
+	}
+
+	return longest
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskSearchTerms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput []string
+	}{
+		{"normal case", "Weekly  report", []string{"weekly", "report"}},
+		{"normal case: duplicated terms", "report Report", []string{"report"}},
+		{"normal case: blank", "  ", nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Exactly(t, tt.expectedOutput, TaskSearch{Text: tt.input}.Terms())
+		})
+	}
+}
+
+func TestTaskSearchSpecSatisfied(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       TaskSearch
+		expectedErr error
+	}{
+		{"normal case", TaskSearch{ViewerID: "user_id", Text: "report"}, nil},
+		{"error case: no viewer", TaskSearch{Text: "report"}, errors.New("viewer of search is required")},
+		{"error case: blank text", TaskSearch{ViewerID: "user_id", Text: " "}, errors.New("search text is required")},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TaskSearchSpecSatisfied(tt.input)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		text           string
+		terms          []string
+		expectedOutput []Fragment
+	}{
+		{
+			"normal case",
+			"Write the weekly Report",
+			[]string{"report", "weekly"},
+			[]Fragment{{"Write the ", false}, {"weekly", true}, {" ", false}, {"Report", true}},
+		},
+		{
+			"normal case: substring",
+			"reports",
+			[]string{"port"},
+			[]Fragment{{"re", false}, {"port", true}, {"s", false}},
+		},
+		{
+			"normal case: longest term wins",
+			"report",
+			[]string{"rep", "report"},
+			[]Fragment{{"report", true}},
+		},
+		{
+			"normal case: adjacent matches are merged",
+			"abab",
+			[]string{"ab"},
+			[]Fragment{{"abab", true}},
+		},
+		{
+			"normal case: multibyte text",
+			"週次レポートを書く",
+			[]string{"レポート"},
+			[]Fragment{{"週次", false}, {"レポート", true}, {"を書く", false}},
+		},
+		{
+			"normal case: no match",
+			"report",
+			[]string{"memo"},
+			[]Fragment{{"report", false}},
+		},
+		{
+			"normal case: empty text",
+			"",
+			[]string{"memo"},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Exactly(t, tt.expectedOutput, Highlight(tt.text, tt.terms))
+		})
+	}
+}
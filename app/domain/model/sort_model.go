@@ -0,0 +1,69 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type SortKey int
+
+const (
+	SortByDeadline SortKey = iota
+	SortByPriority
+	SortByStatus
+	SortByCreatedAt
+	SortByName
+)
+
+var sortKeyNames = map[SortKey]string{
+	SortByDeadline:  "deadline",
+	SortByPriority:  "priority",
+	SortByStatus:    "status",
+	SortByCreatedAt: "created",
+	SortByName:      "name",
+}
+
+func (k SortKey) String() string {
+	if name, ok := sortKeyNames[k]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// TaskSort is the order of a task list. Ascending priority lists P1 first,
+// and ascending status lists behind tasks first, then working and completed ones.
+type TaskSort struct {
+	Key  SortKey
+	Desc bool
+}
+
+// DefaultTaskSort lists the tasks with the closest deadline first.
+var DefaultTaskSort = TaskSort{Key: SortByDeadline}
+
+// ParseTaskSort parses a sort name like "priority" or "-created", where the leading "-" means descending.
+// An empty name means DefaultTaskSort.
+func ParseTaskSort(name string) (TaskSort, error) {
+	if name == "" {
+		return DefaultTaskSort, nil
+	}
+
+	desc := strings.HasPrefix(name, "-")
+
+	for k, n := range sortKeyNames {
+		if n == strings.TrimPrefix(name, "-") {
+			return TaskSort{Key: k, Desc: desc}, nil
+		}
+	}
+
+	return TaskSort{}, errors.Errorf("unknown sort. name: %s", name)
+}
+
+func (s TaskSort) String() string {
+	if s.Desc {
+		return "-" + s.Key.String()
+	}
+
+	return s.Key.String()
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput TaskSort
+		expectedErr    error
+	}{
+		{"normal case: default", "", DefaultTaskSort, nil},
+		{"normal case: ascending", "priority", TaskSort{Key: SortByPriority}, nil},
+		{"normal case: descending", "-created", TaskSort{Key: SortByCreatedAt, Desc: true}, nil},
+		{"error case: unknown key", "color", TaskSort{}, errors.New("unknown sort")},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := ParseTaskSort(tt.input)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedOutput, output)
+				assert.Exactly(t, tt.expectedOutput.String(), output.String())
+			}
+		})
+	}
+}
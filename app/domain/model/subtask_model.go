@@ -0,0 +1,131 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TaskTree is a task with its subtasks at arbitrary depth.
+type TaskTree struct {
+	Task     *Task
+	Children []*TaskTree
+}
+
+// NewSubtask creates a subtask of the parent, which has the same creator, assignee and workspace as the parent.
+func NewSubtask(id TaskID, parent Task, name, detail string, due Due) (*Task, error) {
+	if parent.Status == Completed {
+		return nil, errors.Errorf("subtask cannot be added to completed task. parentID: %s", parent.ID)
+	}
+
+	if parent.IsTrashed() {
+		return nil, errors.Errorf("subtask cannot be added to trashed task. parentID: %s", parent.ID)
+	}
+
+	t, err := NewTask(id, parent.UserID, name, detail, due)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create subtask")
+	}
+
+	parentID := parent.ID
+	t.ParentID = &parentID
+	t.AssigneeID = parent.AssigneeID
+	t.WorkspaceID = parent.WorkspaceID
+
+	return t, nil
+}
+
+// Progress returns the percentage of completed leaf tasks in the tree.
+// A task without subtasks is either 0 or 100 percent done.
+func (tree TaskTree) Progress() int {
+	done, total := tree.countLeaves()
+
+	return done * 100 / total
+}
+
+func (tree TaskTree) countLeaves() (int, int) {
+	if len(tree.Children) == 0 {
+		if tree.Task.Status == Completed {
+			return 1, 1
+		}
+
+		return 0, 1
+	}
+
+	done, total := 0, 0
+
+	for _, c := range tree.Children {
+		d, t := c.countLeaves()
+		done += d
+		total += t
+	}
+
+	return done, total
+}
+
+// Descendants returns all the subtasks in the tree in depth-first order.
+func (tree TaskTree) Descendants() []*Task {
+	var tasks []*Task
+
+	for _, c := range tree.Children {
+		tasks = append(tasks, c.Task)
+		tasks = append(tasks, c.Descendants()...)
+	}
+
+	return tasks
+}
+
+// TaskTreeComplete completes the root task of the tree.
+// With cascade, the incomplete subtasks are completed as well. Otherwise they make the completion fail.
+// It returns the tasks which have been changed.
+func TaskTreeComplete(tree TaskTree, cascade bool, now time.Time) ([]*Task, error) {
+	var changed []*Task
+
+	for _, d := range tree.Descendants() {
+		if d.Status == Completed {
+			continue
+		}
+
+		if !cascade {
+			return nil, errors.Errorf("subtasks are not completed. subtaskID: %s", d.ID)
+		}
+
+		changed = append(changed, TaskComplete(*d, now))
+	}
+
+	if tree.Task.Status != Completed {
+		changed = append(changed, TaskComplete(*tree.Task, now))
+	}
+
+	return changed, nil
+}
+
+func TaskComplete(fetchedTask Task, now time.Time) *Task {
+	t := fetchedTask
+	t.Status = Completed
+
+	return calculateAt(t, now)
+}
+
+// TaskCompleteOn completes the task on the day of the time in the time zone of the task, or today when it is nil.
+func TaskCompleteOn(fetchedTask Task, at *time.Time, now time.Time) *Task {
+	t := fetchedTask
+	t.Status = Completed
+	t.CompletionDate = nil
+
+	if at != nil {
+		day := startOfDay(at.In(t.Location()))
+		t.CompletionDate = &day
+	}
+
+	return calculateAt(t, now)
+}
+
+func TaskReopen(fetchedTask Task, now time.Time) *Task {
+	t := fetchedTask
+	t.Status = Working
+	t.CompletionDate = nil
+	t.ArchivedAt = nil
+
+	return calculateAt(t, now)
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtaskSpecSatisfied(t *testing.T) {
+	t.Parallel()
+
+	completedParent := &Task{ID: "parent", Status: Completed}
+	workingParent := &Task{ID: "parent", Status: Working}
+	completedChild := &Task{ID: "child1", Status: Completed}
+	workingChild := &Task{ID: "child2", Status: Working}
+
+	tests := []struct {
+		name        string
+		input       Task
+		parent      *Task
+		children    []*Task
+		expectedErr error
+	}{
+		{
+			"normal case: completed task with completed subtasks",
+			Task{Status: Completed},
+			workingParent,
+			[]*Task{completedChild},
+			nil,
+		},
+		{
+			"normal case: working task with working subtasks",
+			Task{Status: Working},
+			workingParent,
+			[]*Task{workingChild},
+			nil,
+		},
+		{
+			"error case: completed task with working subtasks",
+			Task{Status: Completed},
+			nil,
+			[]*Task{completedChild, workingChild},
+			errors.New("subtasks are not completed"),
+		},
+		{
+			"error case: working task under completed parent",
+			Task{Status: Working},
+			completedParent,
+			nil,
+			errors.New("parent task is completed"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := SubtaskSpecSatisfied(tt.input, tt.parent, tt.children); err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestNewSubtask(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	parent := Task{ID: "parent", UserID: "owner", Status: Working, Deadline: deadline}
+
+	output, err := NewSubtask("child", parent, "Book hall", "", DueOn(deadline))
+	assert.Nil(t, err)
+	assert.Exactly(t, TaskID("parent"), *output.ParentID)
+	assert.Exactly(t, UserID("owner"), output.UserID)
+
+	parent.Status = Completed
+	_, err = NewSubtask("child", parent, "Book hall", "", DueOn(deadline))
+	assert.Contains(t, err.Error(), "subtask cannot be added to completed task")
+}
+
+func TestTaskTreeProgressAndComplete(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 25, 10, 0, 0, 0, time.Local)
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+
+	tree := TaskTree{
+		Task: &Task{ID: "root", Status: Working, Deadline: deadline},
+		Children: []*TaskTree{
+			{Task: &Task{ID: "child1", Status: Completed, Deadline: deadline}},
+			{
+				Task: &Task{ID: "child2", Status: Working, Deadline: deadline},
+				Children: []*TaskTree{
+					{Task: &Task{ID: "grandchild1", Status: Completed, Deadline: deadline}},
+					{Task: &Task{ID: "grandchild2", Status: Working, Deadline: deadline}},
+				},
+			},
+		},
+	}
+
+	assert.Exactly(t, 66, tree.Progress())
+	assert.Exactly(t, 0, TaskTree{Task: &Task{Status: Working}}.Progress())
+	assert.Exactly(t, 100, TaskTree{Task: &Task{Status: Completed}}.Progress())
+
+	_, err := TaskTreeComplete(tree, false, now)
+	assert.Contains(t, err.Error(), "subtasks are not completed. subtaskID: child2")
+
+	changed, err := TaskTreeComplete(tree, true, now)
+	assert.Nil(t, err)
+
+	var ids []TaskID
+	for _, c := range changed {
+		assert.Exactly(t, Completed, c.Status)
+		assert.NotNil(t, c.CompletionDate)
+		ids = append(ids, c.ID)
+	}
+
+	assert.Exactly(t, []TaskID{"child2", "grandchild2", "root"}, ids)
+}
@@ -1,21 +1,47 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
 
+// Task is a todo in a workspace. UserID is the creator who owns the task, and AssigneeID is the user who works on it,
+// which is the creator unless the task is assigned to another user.
+// Deadline is the due time, or the start of the due day when the task has no due time, in the IANA TimeZone
+// of the user who set it.
+// CalendarUID and CalendarName are the UID and the object name which a calendar client gave the todo of the task
+// when it created the task, and empty for the other tasks, whose todos take the task ID as both.
 type Task struct {
 	ID                TaskID
 	UserID            UserID
+	AssigneeID        UserID
+	WorkspaceID       WorkspaceID
+	ParentID          *TaskID
 	Name              string
 	Detail            string
 	Status            Status
 	CompletionDate    *time.Time
 	Deadline          time.Time
+	HasDueTime        bool
+	TimeZone          string
+	Recurrence        Recurrence
+	Priority          Priority
 	NotificationCount int
+	LastNotifiedAt    *time.Time
 	PostponedCount    int
+	Visibility        Visibility
+	ShareToken        string
+	CalendarUID       string
+	CalendarName      string
+	ArchivedAt        *time.Time
+	TrashedAt         *time.Time
+	CreatedAt         time.Time
+	// Version is incremented on every update to detect concurrent updates.
+	Version int
 }
 
 type TaskID string
@@ -28,26 +54,147 @@ const (
 	Behind
 )
 
+// Priority ranks tasks from P1, the most urgent, to P4. New tasks have DefaultPriority.
+type Priority int
+
+const (
+	P1 Priority = iota + 1
+	P2
+	P3
+	P4
+)
+
+const DefaultPriority = P4
+
+func (p Priority) String() string {
+	if p < P1 || p > P4 {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("P%d", p)
+}
+
+// ParsePriority parses a priority name like "P1". An empty name means DefaultPriority.
+func ParsePriority(name string) (Priority, error) {
+	if name == "" {
+		return DefaultPriority, nil
+	}
+
+	for p := P1; p <= P4; p++ {
+		if strings.EqualFold(p.String(), name) {
+			return p, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown priority. name: %s", name)
+}
+
+// Visibility controls who can see a task besides its owner.
+// Shared tasks are visible to the users they are shared with,
+// and public tasks are additionally visible to anyone who has the share link.
+type Visibility int
+
+const (
+	Private Visibility = iota
+	Shared
+	Public
+)
+
+var visibilityNames = map[Visibility]string{
+	Private: "private",
+	Shared:  "shared",
+	Public:  "public",
+}
+
+func (v Visibility) String() string {
+	if name, ok := visibilityNames[v]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func ParseVisibility(name string) (Visibility, error) {
+	for v, n := range visibilityNames {
+		if n == name {
+			return v, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown visibility. name: %s", name)
+}
+
+var statusNames = map[Status]string{
+	Working:   "working",
+	Completed: "completed",
+	Behind:    "behind",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func ParseStatus(name string) (Status, error) {
+	for s, n := range statusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown status. name: %s", name)
+}
+
 const (
 	NOTIFICATION_COUNT_LIMIT = 5
 	POSTPONED_COUNT_LIMIT    = 3
 )
 
+const (
+	maxTaskNameLength   = 50
+	maxTaskDetailLength = 300
+	maxCalendarIDLength = 255
+)
+
 var getNow = time.Now
 
-func NewTask(id TaskID, userID UserID, name string, detail string, deadline time.Time) (*Task, error) {
-	dl := time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.Local)
+// NewTask creates a task due on the day or at the time of the due, in the time zone of the due.
+func NewTask(id TaskID, userID UserID, name string, detail string, due Due) (*Task, error) {
+	if due.HasTime {
+		due = DueAt(due.At)
+	} else {
+		due = DueOn(due.At)
+	}
 
 	t := &Task{
 		ID:                id,
 		UserID:            userID,
+		AssigneeID:        userID,
+		WorkspaceID:       PersonalWorkspaceID(userID),
+		ParentID:          nil,
 		Name:              name,
 		Detail:            detail,
 		Status:            Working,
 		CompletionDate:    nil,
-		Deadline:          dl,
+		Deadline:          due.At,
+		HasDueTime:        due.HasTime,
+		TimeZone:          due.At.Location().String(),
+		Recurrence:        NoRecurrence,
+		Priority:          DefaultPriority,
 		NotificationCount: 0,
+		LastNotifiedAt:    nil,
 		PostponedCount:    0,
+		Visibility:        Private,
+		ShareToken:        "",
+		CalendarUID:       "",
+		CalendarName:      "",
+		ArchivedAt:        nil,
+		TrashedAt:         nil,
+		CreatedAt:         time.Time{},
+		Version:           1,
 	}
 
 	if err := TaskSpecSatisfied(*t); err != nil {
@@ -58,6 +205,14 @@ func NewTask(id TaskID, userID UserID, name string, detail string, deadline time
 }
 
 func TaskSpecSatisfied(t Task) error {
+	if utf8.RuneCountInString(t.Name) > maxTaskNameLength {
+		return errors.Errorf("task name exceeds %d characters. name: %s", maxTaskNameLength, t.Name)
+	}
+
+	if utf8.RuneCountInString(t.Detail) > maxTaskDetailLength {
+		return errors.Errorf("task detail exceeds %d characters", maxTaskDetailLength)
+	}
+
 	if t.NotificationCount > NOTIFICATION_COUNT_LIMIT {
 		return errors.Errorf("notification counts exceeds limit. t.notificationCount: %+v", t.NotificationCount)
 	}
@@ -69,28 +224,177 @@ func TaskSpecSatisfied(t Task) error {
 	return nil
 }
 
-func TaskSet(fetchedTask Task, name, detail string, status Status, deadline time.Time) (*Task, error) {
-	t, err := NewTask(fetchedTask.ID, fetchedTask.UserID, name, detail, deadline)
+// SubtaskSpecSatisfied checks the task against its parent and direct children.
+// A completed task must not have incomplete subtasks, so a task cannot be reopened while its parent is completed.
+func SubtaskSpecSatisfied(t Task, parent *Task, children []*Task) error {
+	if parent != nil && parent.Status == Completed && t.Status != Completed {
+		return errors.Errorf("parent task is completed. parentID: %s", parent.ID)
+	}
+
+	if t.Status != Completed {
+		return nil
+	}
+
+	for _, c := range children {
+		if c.Status != Completed {
+			return errors.Errorf("subtasks are not completed. subtaskID: %s", c.ID)
+		}
+	}
+
+	return nil
+}
+
+func TaskSet(fetchedTask Task, name, detail string, status Status, due Due) (*Task, error) {
+	if fetchedTask.IsTrashed() {
+		return nil, errors.New("trashed task cannot be updated")
+	}
+
+	t, err := NewTask(fetchedTask.ID, fetchedTask.UserID, name, detail, due)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set task")
 	}
 
 	t.Status = status
+	t.AssigneeID = fetchedTask.AssigneeID
+	t.WorkspaceID = fetchedTask.WorkspaceID
+	t.ParentID = fetchedTask.ParentID
+	t.Recurrence = fetchedTask.Recurrence
+	t.Priority = fetchedTask.Priority
+	t.CreatedAt = fetchedTask.CreatedAt
 	t.PostponedCount = fetchedTask.PostponedCount
 	t.NotificationCount = fetchedTask.NotificationCount
+	t.LastNotifiedAt = fetchedTask.LastNotifiedAt
+	t.Visibility = fetchedTask.Visibility
+	t.ShareToken = fetchedTask.ShareToken
+	t.CalendarUID = fetchedTask.CalendarUID
+	t.CalendarName = fetchedTask.CalendarName
+	t.Version = fetchedTask.Version
+
+	if status == Completed {
+		t.ArchivedAt = fetchedTask.ArchivedAt
+	}
 
-	if t.Deadline.After(fetchedTask.Deadline) {
+	// INFO: editing a task which stays completed keeps the day it was completed, e.g. when a sync client uploads it again
+	if status == Completed && fetchedTask.Status == Completed {
+		t.CompletionDate = fetchedTask.CompletionDate
+	}
+
+	if t.Due().After(fetchedTask.Due()) {
 		t.PostponedCount++
 	}
 
 	return calculate(*t), nil
 }
 
+// TaskVersionSatisfied checks that the task has not been updated since the version was read.
+func TaskVersionSatisfied(t Task, version int) error {
+	if t.Version != version {
+		return errors.Errorf("task has been updated by someone else. taskID: %s, version: %d, current version: %d", t.ID, version, t.Version)
+	}
+
+	return nil
+}
+
+func TaskPrioritize(fetchedTask Task, priority Priority) (*Task, error) {
+	if priority < P1 || priority > P4 {
+		return nil, errors.Errorf("invalid priority. priority: %d", priority)
+	}
+
+	t := fetchedTask
+	t.Priority = priority
+
+	return &t, nil
+}
+
+// TaskShare changes the visibility of the task.
+// A share token is issued when the task becomes public and revoked when it stops being public.
+func TaskShare(fetchedTask Task, visibility Visibility) (*Task, error) {
+	if _, ok := visibilityNames[visibility]; !ok {
+		return nil, errors.Errorf("invalid visibility. visibility: %d", visibility)
+	}
+
+	t := fetchedTask
+	t.Visibility = visibility
+
+	switch {
+	case visibility != Public:
+		t.ShareToken = ""
+	case t.ShareToken == "":
+		t.ShareToken = string(CreateUUID())
+	}
+
+	return &t, nil
+}
+
+// TaskLinkCalendar keeps the UID and the object name of the todo from which a calendar client creates the task,
+// since clients choose them by themselves, e.g. `…@google.com`, and the task has an ID of its own.
+func TaskLinkCalendar(fetchedTask Task, uid, name string) (*Task, error) {
+	for _, id := range []string{uid, name} {
+		if id == "" || utf8.RuneCountInString(id) > maxCalendarIDLength {
+			return nil, errors.Errorf("calendar UID and object name must have 1 to %d characters. uid: %s, name: %s", maxCalendarIDLength, uid, name)
+		}
+	}
+
+	t := fetchedTask
+	t.CalendarUID = uid
+	t.CalendarName = name
+
+	return &t, nil
+}
+
+func (t Task) IsOwnedBy(userID UserID) bool {
+	return t.UserID == userID
+}
+
+func (t Task) IsAssignedTo(userID UserID) bool {
+	return t.AssigneeID == userID
+}
+
+// VisibleTo reports whether the user can view the task, given the users it is shared with.
+// The assignee can view the task regardless of its visibility.
+func (t Task) VisibleTo(userID UserID, sharedUserIDs []UserID) bool {
+	if t.IsOwnedBy(userID) {
+		return true
+	}
+
+	if t.IsTrashed() {
+		return false
+	}
+
+	if t.IsAssignedTo(userID) {
+		return true
+	}
+
+	if t.Visibility == Private {
+		return false
+	}
+
+	for _, id := range sharedUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TaskRefresh re-evaluates the status of the task at now.
+// It reports whether the status has changed, e.g. a working task which passed its deadline became behind.
+func TaskRefresh(fetchedTask Task, now time.Time) (*Task, bool) {
+	t := calculateAt(fetchedTask, now)
+
+	return t, t.Status != fetchedTask.Status
+}
+
 func calculate(t Task) *Task {
-	now := getNow()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return calculateAt(t, getNow())
+}
+
+// calculateAt evaluates the status in the time zone of the task, so that "today" is the day of the user who set the deadline.
+func calculateAt(t Task, now time.Time) *Task {
+	today := startOfDay(now.In(t.Location()))
 
-	if t.Status != Completed && today.After(t.Deadline) {
+	if t.Status != Completed && !now.Before(t.Due().End()) {
 		t.Status = Behind
 	}
 
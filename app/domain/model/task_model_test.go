@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,7 +32,7 @@ func TestNewTask(t *testing.T) {
 			"Venue Reservation",
 			"Reserve venue for conference",
 			time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local),
-			&Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, AssigneeID: userID, WorkspaceID: PersonalWorkspaceID(userID), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, Priority: P4, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0, Version: 1},
 			nil,
 		},
 		{
@@ -39,7 +40,7 @@ func TestNewTask(t *testing.T) {
 			"Venue Reservation",
 			"Reserve venue for conference",
 			time.Date(2022, 1, 25, 0, 0, 0, 0, time.Local),
-			&Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 25, 0, 0, 0, 0, time.Local), NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, AssigneeID: userID, WorkspaceID: PersonalWorkspaceID(userID), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, Priority: P4, CompletionDate: nil, Deadline: time.Date(2022, 1, 25, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0, Version: 1},
 			nil,
 		},
 	}
@@ -49,7 +50,7 @@ func TestNewTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			output, err := NewTask(id, userID, tt.taskName, tt.detail, tt.deadline)
+			output, err := NewTask(id, userID, tt.taskName, tt.detail, DueOn(tt.deadline))
 			if err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -81,19 +82,34 @@ func TestTaskSpecSatisfied(t *testing.T) {
 	}{
 		{
 			"normal case: count is under the limit",
-			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), NotificationCount: 5, PostponedCount: 3},
+			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String(), NotificationCount: 5, PostponedCount: 3},
 			nil,
 		},
 		{
 			"error case: notification counts exceeds limit",
-			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), NotificationCount: 6, PostponedCount: 0},
+			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String(), NotificationCount: 6, PostponedCount: 0},
 			errors.New("notification counts exceeds limit"),
 		},
 		{
 			"error case: postponed counts exceeds limit",
-			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), NotificationCount: 0, PostponedCount: 4},
+			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 4},
 			errors.New("postponed counts exceeds limit"),
 		},
+		{
+			"normal case: name has 50 multibyte characters",
+			Task{ID: id, UserID: userID, Name: strings.Repeat("会", 50), Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()},
+			nil,
+		},
+		{
+			"error case: name exceeds limit",
+			Task{ID: id, UserID: userID, Name: strings.Repeat("a", 51), Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()},
+			errors.New("task name exceeds 50 characters"),
+		},
+		{
+			"error case: detail exceeds limit",
+			Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: strings.Repeat("a", 301), Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()},
+			errors.New("task detail exceeds 300 characters"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,7 +143,7 @@ func TestTaskSet(t *testing.T) {
 
 	id := TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
 	userID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
-	fetchedTask := Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: createdDate, NotificationCount: 0, PostponedCount: 0}
+	fetchedTask := Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: createdDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0}
 
 	updatedTaskName := "Updated Venue Reservation"
 	updatedTaskDetail := "Updated Reserve venue for conference"
@@ -145,7 +161,7 @@ func TestTaskSet(t *testing.T) {
 			Working,
 			createdDate,
 			referenceDate,
-			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: createdDate, NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: createdDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 		},
 		{
@@ -153,7 +169,7 @@ func TestTaskSet(t *testing.T) {
 			Working,
 			postponedDate,
 			referenceDate,
-			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: postponedDate, NotificationCount: 0, PostponedCount: 1},
+			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Working, CompletionDate: nil, Deadline: postponedDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 1},
 			nil,
 		},
 		{
@@ -161,7 +177,7 @@ func TestTaskSet(t *testing.T) {
 			Completed,
 			createdDate,
 			referenceDate,
-			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Completed, CompletionDate: &completedDate, Deadline: createdDate, NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Completed, CompletionDate: &completedDate, Deadline: createdDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 		},
 		{
@@ -169,7 +185,7 @@ func TestTaskSet(t *testing.T) {
 			Completed,
 			createdDate,
 			behindDate,
-			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Completed, CompletionDate: &behindCompletedDate, Deadline: createdDate, NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Completed, CompletionDate: &behindCompletedDate, Deadline: createdDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 		},
 		{
@@ -177,7 +193,7 @@ func TestTaskSet(t *testing.T) {
 			Working,
 			createdDate,
 			behindDate,
-			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Behind, CompletionDate: nil, Deadline: createdDate, NotificationCount: 0, PostponedCount: 0},
+			&Task{ID: id, UserID: userID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: Behind, CompletionDate: nil, Deadline: createdDate, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 		},
 	}
@@ -188,7 +204,7 @@ func TestTaskSet(t *testing.T) {
 			t.Parallel()
 
 			getNow = func() time.Time { return tt.date }
-			output, err := TaskSet(fetchedTask, updatedTaskName, updatedTaskDetail, tt.status, tt.deadline)
+			output, err := TaskSet(fetchedTask, updatedTaskName, updatedTaskDetail, tt.status, DueOn(tt.deadline))
 			if err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -202,3 +218,211 @@ func TestTaskSet(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSetKeepsCompletionDate(t *testing.T) {
+	t.Parallel()
+
+	completionDate := time.Date(2022, 1, 20, 0, 0, 0, 0, time.Local)
+	fetchedTask := Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab", UserID: "477ecd7f-48fe-6b1c-499a-ec9f52b15a33", Name: "Venue Reservation", Status: Completed, CompletionDate: &completionDate, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()}
+
+	renamed, err := TaskSet(fetchedTask, "Updated Venue Reservation", "", Completed, fetchedTask.Due())
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Exactly(t, &completionDate, renamed.CompletionDate)
+
+	reopened, err := TaskSet(fetchedTask, "Venue Reservation", "", Working, fetchedTask.Due())
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Nil(t, reopened.CompletionDate)
+}
+
+func TestTaskShare(t *testing.T) {
+	t.Parallel()
+
+	id := TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	userID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	fetchedTask := Task{ID: id, UserID: userID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)}
+
+	private, err := TaskShare(fetchedTask, Private)
+	assert.Nil(t, err)
+	assert.Empty(t, private.ShareToken)
+
+	public, err := TaskShare(fetchedTask, Public)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, public.ShareToken)
+
+	republic, err := TaskShare(*public, Public)
+	assert.Nil(t, err)
+	assert.Exactly(t, public.ShareToken, republic.ShareToken)
+
+	revoked, err := TaskShare(*public, Shared)
+	assert.Nil(t, err)
+	assert.Empty(t, revoked.ShareToken)
+
+	_, err = TaskShare(fetchedTask, Visibility(9))
+	assert.Contains(t, err.Error(), "invalid visibility")
+}
+
+func TestTaskLinkCalendar(t *testing.T) {
+	t.Parallel()
+
+	fetchedTask := Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab", UserID: "477ecd7f-48fe-6b1c-499a-ec9f52b15a33", Name: "Venue Reservation", Status: Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)}
+
+	linked, err := TaskLinkCalendar(fetchedTask, "6f1a2b3c4d@google.com", "6f1a2b3c4d")
+	assert.Nil(t, err)
+	assert.Exactly(t, "6f1a2b3c4d@google.com", linked.CalendarUID)
+	assert.Exactly(t, "6f1a2b3c4d", linked.CalendarName)
+	assert.Exactly(t, fetchedTask.ID, linked.ID)
+
+	renamed, err := TaskSet(*linked, "Updated Venue Reservation", "", Working, linked.Due())
+	assert.Nil(t, err)
+	assert.Exactly(t, linked.CalendarUID, renamed.CalendarUID)
+	assert.Exactly(t, linked.CalendarName, renamed.CalendarName)
+
+	_, err = TaskLinkCalendar(fetchedTask, "", "6f1a2b3c4d")
+	assert.Contains(t, err.Error(), "calendar UID and object name")
+
+	_, err = TaskLinkCalendar(fetchedTask, "6f1a2b3c4d@google.com", strings.Repeat("a", maxCalendarIDLength+1))
+	assert.Contains(t, err.Error(), "calendar UID and object name")
+}
+
+func TestTaskVisibleTo(t *testing.T) {
+	t.Parallel()
+
+	ownerID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	memberID := UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	otherID := UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33")
+
+	tests := []struct {
+		name       string
+		visibility Visibility
+		userID     UserID
+		expected   bool
+	}{
+		{"owner of private task", Private, ownerID, true},
+		{"member of private task", Private, memberID, false},
+		{"member of shared task", Shared, memberID, true},
+		{"other user of shared task", Shared, otherID, false},
+		{"member of public task", Public, memberID, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{UserID: ownerID, Visibility: tt.visibility}
+			assert.Exactly(t, tt.expected, task.VisibleTo(tt.userID, []UserID{memberID}))
+		})
+	}
+}
+
+func TestTaskRefresh(t *testing.T) {
+	t.Parallel()
+
+	id := TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	userID := UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	completionDate := time.Date(2022, 1, 25, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name            string
+		status          Status
+		now             time.Time
+		expectedStatus  Status
+		expectedChanged bool
+	}{
+		{"working on the day of deadline", Working, time.Date(2022, 1, 26, 23, 59, 59, 0, time.Local), Working, false},
+		{"working after deadline", Working, time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local), Behind, true},
+		{"behind after deadline", Behind, time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local), Behind, false},
+		{"completed after deadline", Completed, time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local), Completed, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{ID: id, UserID: userID, Name: "Venue Reservation", Status: tt.status, Deadline: deadline, TimeZone: time.Local.String()}
+			if tt.status == Completed {
+				task.CompletionDate = &completionDate
+			}
+
+			output, changed := TaskRefresh(task, tt.now)
+			assert.Exactly(t, tt.expectedStatus, output.Status)
+			assert.Exactly(t, tt.expectedChanged, changed)
+		})
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]Priority{"": DefaultPriority, "P1": P1, "p2": P2, "P4": P4} {
+		output, err := ParsePriority(name)
+		assert.Nil(t, err)
+		assert.Exactly(t, expected, output)
+	}
+
+	for _, name := range []string{"P0", "P5", "high"} {
+		_, err := ParsePriority(name)
+		assert.NotNil(t, err, "error is expected for %s", name)
+	}
+}
+
+func TestTaskPrioritize(t *testing.T) {
+	t.Parallel()
+
+	output, err := TaskPrioritize(Task{Priority: P4}, P1)
+	assert.Nil(t, err)
+	assert.Exactly(t, P1, output.Priority)
+
+	_, err = TaskPrioritize(Task{Priority: P4}, Priority(0))
+	assert.NotNil(t, err)
+}
+
+func TestTaskVersionSatisfied(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, TaskVersionSatisfied(Task{Version: 2}, 2))
+	assert.NotNil(t, TaskVersionSatisfied(Task{Version: 2}, 1))
+}
+
+func TestTaskRefreshInTimeZone(t *testing.T) {
+	t.Parallel()
+
+	tokyo := LoadLocation("Asia/Tokyo")
+	berlin := LoadLocation("Europe/Berlin")
+
+	tests := []struct {
+		name           string
+		due            Due
+		now            time.Time
+		expectedStatus Status
+	}{
+		{"on the day in Tokyo", DueOn(time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo)), time.Date(2022, 1, 26, 14, 59, 0, 0, time.UTC), Working},
+		{"next day in Tokyo", DueOn(time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo)), time.Date(2022, 1, 26, 15, 0, 0, 0, time.UTC), Behind},
+		{"same moment on the day in Berlin", DueOn(time.Date(2022, 1, 26, 0, 0, 0, 0, berlin)), time.Date(2022, 1, 26, 15, 0, 0, 0, time.UTC), Working},
+		{"before due time", DueAt(time.Date(2022, 1, 26, 18, 0, 0, 0, berlin)), time.Date(2022, 1, 26, 16, 59, 0, 0, time.UTC), Working},
+		{"at due time", DueAt(time.Date(2022, 1, 26, 18, 0, 0, 0, berlin)), time.Date(2022, 1, 26, 17, 0, 0, 0, time.UTC), Behind},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task, err := NewTask("id", "user_id", "Venue Reservation", "", tt.due)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			output, _ := TaskRefresh(*task, tt.now)
+			assert.Exactly(t, tt.expectedStatus, output.Status)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RecordDateLayout and RecordTimeLayout are the formats of the dates and the due times of task records.
+	RecordDateLayout = "2006-01-02"
+	RecordTimeLayout = "15:04"
+
+	MaxImportRecords = 1000
+)
+
+// TaskRecord is a task as a row of an export or import file. The fields are text like in the files,
+// so that every row of an import can be checked on its own and its errors reported.
+// ID and ParentID only link subtasks to their parents within a file; imported tasks get new IDs.
+// Dates without a TimeZone are in the time zone of the importing user. Labels are the names of the labels of the user.
+type TaskRecord struct {
+	ID             string
+	ParentID       string
+	Name           string
+	Detail         string
+	Status         string
+	Deadline       string
+	DueTime        string
+	TimeZone       string
+	Recurrence     string
+	Priority       string
+	CompletionDate string
+	Archived       bool
+	Labels         []string
+}
+
+// TaskRecordOf returns the record of the task, whose dates are in the time zone of the task.
+func TaskRecordOf(t Task) TaskRecord {
+	loc := t.Location()
+	r := TaskRecord{
+		ID:         string(t.ID),
+		Name:       t.Name,
+		Detail:     t.Detail,
+		Status:     t.Status.String(),
+		Deadline:   t.Due().At.Format(RecordDateLayout),
+		TimeZone:   loc.String(),
+		Recurrence: string(t.Recurrence),
+		Priority:   t.Priority.String(),
+		Archived:   t.IsArchived(),
+	}
+
+	if t.ParentID != nil {
+		r.ParentID = string(*t.ParentID)
+	}
+
+	if t.HasDueTime {
+		r.DueTime = t.Due().At.Format(RecordTimeLayout)
+	}
+
+	if t.CompletionDate != nil {
+		r.CompletionDate = t.CompletionDate.In(loc).Format(RecordDateLayout)
+	}
+
+	return r
+}
+
+// TaskRecordsOf returns the records of the tasks with their labels. Links to parents which are not among the tasks are dropped,
+// so that the records can be imported again.
+func TaskRecordsOf(tasks []*Task, labels map[TaskID][]*Label) []TaskRecord {
+	ids := make(map[TaskID]bool, len(tasks))
+	for _, t := range tasks {
+		ids[t.ID] = true
+	}
+
+	records := make([]TaskRecord, 0, len(tasks))
+
+	for _, t := range tasks {
+		r := TaskRecordOf(*t)
+		if t.ParentID != nil && !ids[*t.ParentID] {
+			r.ParentID = ""
+		}
+
+		for _, l := range labels[t.ID] {
+			r.Labels = append(r.Labels, l.Name)
+		}
+
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// ImportRow is a row of an import, numbered from 1, with the task made of its record or the reason why it cannot be imported.
+type ImportRow struct {
+	Number int
+	Record TaskRecord
+	Task   *Task
+	Err    error
+	depth  int
+}
+
+// TaskImport is the result of checking the records of an import. It is only imported when all the rows are valid.
+type TaskImport struct {
+	Rows []*ImportRow
+}
+
+// NewTaskImport makes the tasks of the records for the user in the workspace, checking every record like a new task.
+// It fails only when the number of records is out of range; the errors of the records are kept in their rows.
+func NewTaskImport(userID UserID, workspaceID WorkspaceID, records []TaskRecord, loc *time.Location, now time.Time) (*TaskImport, error) {
+	if len(records) == 0 {
+		return nil, errors.New("import has no tasks")
+	}
+
+	if len(records) > MaxImportRecords {
+		return nil, errors.Errorf("import has too many tasks. count: %d, limit: %d", len(records), MaxImportRecords)
+	}
+
+	imp := &TaskImport{Rows: make([]*ImportRow, 0, len(records))}
+	byID := make(map[string]*ImportRow, len(records))
+
+	for i, r := range records {
+		row := &ImportRow{Number: i + 1, Record: r}
+		row.Task, row.Err = newTaskOfRecord(userID, workspaceID, r, loc, now)
+
+		if r.ID != "" {
+			if other, ok := byID[r.ID]; ok {
+				row.Task, row.Err = nil, errors.Errorf("id is the same as row %d. id: %s", other.Number, r.ID)
+			} else {
+				byID[r.ID] = row
+			}
+		}
+
+		imp.Rows = append(imp.Rows, row)
+	}
+
+	for _, row := range imp.Rows {
+		if row.Err == nil {
+			row.depth, row.Err = linkParent(row, byID)
+		}
+	}
+
+	// INFO: rows are linked before the errors spread, so that a subtask is never imported without its parent
+	for _, row := range imp.Rows {
+		if row.Err == nil {
+			row.Err = parentRowError(row, byID)
+		}
+
+		if row.Err != nil {
+			row.Task = nil
+		}
+	}
+
+	return imp, nil
+}
+
+func newTaskOfRecord(userID UserID, workspaceID WorkspaceID, r TaskRecord, loc *time.Location, now time.Time) (*Task, error) {
+	if strings.TrimSpace(r.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	for _, name := range r.Labels {
+		if err := LabelSpecSatisfied(Label{Name: strings.TrimSpace(name), Color: DefaultLabelColor}); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.TimeZone != "" {
+		if err := TimeZoneSpecSatisfied(r.TimeZone); err != nil {
+			return nil, err
+		}
+
+		loc = LoadLocation(r.TimeZone)
+	}
+
+	due, err := parseRecordDue(r.Deadline, r.DueTime, loc, now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := Working
+	if r.Status != "" {
+		if status, err = ParseStatus(strings.ToLower(r.Status)); err != nil {
+			return nil, err
+		}
+	}
+
+	recurrence, err := ParseRecurrence(r.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := ParsePriority(r.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewTask(TaskID(CreateUUID()), userID, r.Name, r.Detail, due)
+	if err != nil {
+		return nil, err
+	}
+
+	if t, err = TaskPlace(*t, workspaceID); err != nil {
+		return nil, err
+	}
+
+	if t, err = TaskRepeat(*t, recurrence); err != nil {
+		return nil, err
+	}
+
+	if t, err = TaskPrioritize(*t, priority); err != nil {
+		return nil, err
+	}
+
+	// INFO: behind is not taken from the record, since whether a task is behind depends on its deadline
+	if status == Completed {
+		t.Status = Completed
+
+		if r.CompletionDate != "" {
+			d, err := time.ParseInLocation(RecordDateLayout, r.CompletionDate, loc)
+			if err != nil {
+				return nil, errors.Errorf("completion date must be formatted as %s. completion date: %s", RecordDateLayout, r.CompletionDate)
+			}
+
+			t.CompletionDate = &d
+		}
+	} else if r.CompletionDate != "" {
+		return nil, errors.Errorf("only completed task can have completion date. status: %s", status)
+	}
+
+	t = calculateAt(*t, now)
+
+	if r.Archived {
+		if t, err = TaskArchive(*t, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// parseRecordDue parses the deadline of a record, which is today when it is empty like in the form of a new task.
+func parseRecordDue(date, dueTime string, loc *time.Location, now time.Time) (Due, error) {
+	if date == "" {
+		if dueTime != "" {
+			return Due{}, errors.Errorf("due time needs deadline. due time: %s", dueTime)
+		}
+
+		return DueOn(now.In(loc)), nil
+	}
+
+	if dueTime == "" {
+		d, err := time.ParseInLocation(RecordDateLayout, date, loc)
+		if err != nil {
+			return Due{}, errors.Errorf("deadline must be formatted as %s. deadline: %s", RecordDateLayout, date)
+		}
+
+		return DueOn(d), nil
+	}
+
+	at, err := time.ParseInLocation(RecordDateLayout+" "+RecordTimeLayout, date+" "+dueTime, loc)
+	if err != nil {
+		return Due{}, errors.Errorf("deadline must be formatted as %s and due time as %s. deadline: %s, due time: %s", RecordDateLayout, RecordTimeLayout, date, dueTime)
+	}
+
+	return DueAt(at), nil
+}
+
+// linkParent makes the task of the row a subtask of the task of its parent row, and returns the depth of the row.
+func linkParent(row *ImportRow, byID map[string]*ImportRow) (int, error) {
+	depth := 0
+	seen := map[*ImportRow]bool{row: true}
+
+	for r := row; r.Record.ParentID != ""; depth++ {
+		parent, ok := byID[r.Record.ParentID]
+		if !ok {
+			if r == row {
+				return 0, errors.Errorf("parent is not in the import. parentID: %s", r.Record.ParentID)
+			}
+
+			break
+		}
+
+		if seen[parent] {
+			return 0, errors.Errorf("parents of task form a cycle. parentID: %s", row.Record.ParentID)
+		}
+
+		seen[parent] = true
+		r = parent
+	}
+
+	parent, ok := byID[row.Record.ParentID]
+	if !ok {
+		return depth, nil
+	}
+
+	if parent.Task != nil {
+		parentID := parent.Task.ID
+		row.Task.ParentID = &parentID
+
+		if err := SubtaskSpecSatisfied(*row.Task, parent.Task, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	return depth, nil
+}
+
+// parentRowError returns the error of the row when one of its ancestors cannot be imported.
+func parentRowError(row *ImportRow, byID map[string]*ImportRow) error {
+	for r, n := row, 0; r.Record.ParentID != "" && n < len(byID); n++ {
+		parent, ok := byID[r.Record.ParentID]
+		if !ok {
+			return nil
+		}
+
+		if parent.Err != nil {
+			return errors.Errorf("parent cannot be imported. row: %d", parent.Number)
+		}
+
+		r = parent
+	}
+
+	return nil
+}
+
+// Valid reports whether all the rows can be imported.
+func (imp TaskImport) Valid() bool {
+	return imp.ErrorCount() == 0
+}
+
+func (imp TaskImport) ErrorCount() int {
+	n := 0
+
+	for _, row := range imp.Rows {
+		if row.Err != nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Tasks returns the tasks of the valid rows, parents before their subtasks.
+func (imp TaskImport) Tasks() []*Task {
+	var (
+		tasks []*Task
+		rows  []*ImportRow
+	)
+
+	for _, row := range imp.Rows {
+		if row.Task != nil {
+			rows = append(rows, row)
+		}
+	}
+
+	for depth := 0; len(tasks) < len(rows); depth++ {
+		for _, row := range rows {
+			if row.depth == depth {
+				tasks = append(tasks, row.Task)
+			}
+		}
+	}
+
+	return tasks
+}
+
+// LabelNames returns the names of the labels of the valid rows, without the duplicates which differ only in case.
+func (imp TaskImport) LabelNames() []string {
+	seen := make(map[string]bool)
+
+	var names []string
+
+	for _, row := range imp.Rows {
+		if row.Task == nil {
+			continue
+		}
+
+		for _, name := range row.Record.Labels {
+			name = strings.TrimSpace(name)
+			if key := strings.ToLower(name); !seen[key] {
+				seen[key] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,238 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRecordsOf(t *testing.T) {
+	t.Parallel()
+
+	tokyo := LoadLocation("Asia/Tokyo")
+	completionDate := time.Date(2022, 1, 25, 0, 0, 0, 0, tokyo)
+	archivedAt := time.Date(2022, 1, 26, 1, 0, 0, 0, time.UTC)
+	parentID := TaskID("72c24944-f532-4c5d-a695-70fa3e72f3aa")
+	trashedParentID := TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+
+	tasks := []*Task{
+		{ID: parentID, Name: "Conference", Status: Completed, CompletionDate: &completionDate, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo", Priority: P1, ArchivedAt: &archivedAt},
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ac", ParentID: &parentID, Name: "Venue Reservation", Detail: "Reserve venue", Status: Behind, Deadline: time.Date(2022, 1, 19, 23, 30, 0, 0, tokyo).UTC(), HasDueTime: true, TimeZone: "Asia/Tokyo", Recurrence: "FREQ=WEEKLY", Priority: P2},
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ad", ParentID: &trashedParentID, Name: "Catering", Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.UTC), TimeZone: "UTC", Priority: P4},
+	}
+
+	expected := []TaskRecord{
+		{ID: string(parentID), Name: "Conference", Status: "completed", Deadline: "2022-01-26", TimeZone: "Asia/Tokyo", Priority: "P1", CompletionDate: "2022-01-25", Archived: true},
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ac", ParentID: string(parentID), Name: "Venue Reservation", Detail: "Reserve venue", Status: "behind", Deadline: "2022-01-19", DueTime: "23:30", TimeZone: "Asia/Tokyo", Recurrence: "FREQ=WEEKLY", Priority: "P2", Labels: []string{"Work", "Calls"}},
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ad", Name: "Catering", Status: "working", Deadline: "2022-01-26", TimeZone: "UTC", Priority: "P4"},
+	}
+
+	labels := map[TaskID][]*Label{
+		"72c24944-f532-4c5d-a695-70fa3e72f3ac": {{ID: "work", Name: "Work"}, {ID: "calls", Name: "Calls"}},
+	}
+
+	assert.Exactly(t, expected, TaskRecordsOf(tasks, labels))
+}
+
+func TestNewTaskImport(t *testing.T) {
+	t.Parallel()
+
+	userID := UserID("bcdf4a46-4bcd-4f2f-95b7-a3ed9a2c4c56")
+	workspaceID := WorkspaceID("3e0a2c1b-7fd2-4e4b-a8f6-52a4b2c3d9e1")
+	tokyo := LoadLocation("Asia/Tokyo")
+	now := time.Date(2022, 1, 20, 9, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name           string
+		input          []TaskRecord
+		expectedErr    error
+		expectedNames  []string
+		expectedLabels []string
+		expectedRows   map[int]string
+	}{
+		{
+			"normal case: subtasks are imported after their parents",
+			[]TaskRecord{
+				{ID: "2", ParentID: "1", Name: "Venue Reservation", Deadline: "2022-01-26", DueTime: "18:30", Priority: "p2", Labels: []string{"Work", "Calls"}},
+				{ID: "1", Name: "Conference", Deadline: "2022-01-28", TimeZone: "Europe/Berlin", Recurrence: "freq=weekly", Labels: []string{" work "}},
+				{Name: "Invitations", Status: "Completed", Deadline: "2022-01-18", CompletionDate: "2022-01-17", Archived: true},
+				{Name: "Catering", Status: "behind"},
+			},
+			nil,
+			[]string{"Conference", "Invitations", "Catering", "Venue Reservation"},
+			[]string{"Work", "Calls"},
+			map[int]string{},
+		},
+		{
+			"error case: invalid rows",
+			[]TaskRecord{
+				{Name: " "},
+				{Name: "Venue Reservation", Deadline: "2022/01/26"},
+				{Name: "Venue Reservation", DueTime: "18:30"},
+				{Name: "Venue Reservation", TimeZone: "Mars/Olympus"},
+				{Name: "Venue Reservation", Priority: "P9"},
+				{Name: "Venue Reservation", Status: "done"},
+				{Name: "Venue Reservation", Recurrence: "FREQ=YEARLY"},
+				{Name: "Venue Reservation", Archived: true},
+				{Name: "Venue Reservation", CompletionDate: "2022-01-17"},
+				{Name: "Venue Reservation", Labels: []string{""}},
+				{Name: strings.Repeat("a", 51)},
+				{Name: "Venue Reservation", Detail: strings.Repeat("a", 301)},
+				{Name: "Venue Reservation", Status: "completed", Labels: []string{"Work"}},
+			},
+			nil,
+			[]string{"Venue Reservation"},
+			[]string{"Work"},
+			map[int]string{
+				1:  "name is required",
+				2:  "deadline must be formatted as 2006-01-02",
+				3:  "due time needs deadline",
+				4:  "unknown time zone",
+				5:  "unknown priority",
+				6:  "unknown status",
+				7:  "unsupported recurrence frequency",
+				8:  "only completed task can be archived",
+				9:  "only completed task can have completion date",
+				10: "label name is required",
+				11: "task name exceeds 50 characters",
+				12: "task detail exceeds 300 characters",
+			},
+		},
+		{
+			"error case: invalid links between rows",
+			[]TaskRecord{
+				{ID: "1", Name: "Conference", Status: "completed"},
+				{ID: "2", ParentID: "1", Name: "Venue Reservation"},
+				{ID: "3", ParentID: "4", Name: "Catering"},
+				{ID: "4", ParentID: "3", Name: "Drinks"},
+				{ID: "5", ParentID: "9", Name: "Invitations"},
+				{ID: "6", ParentID: "5", Name: "Guest list"},
+				{ID: "1", Name: "Speakers"},
+				{ID: "7", Name: "Bad priority", Priority: "P0"},
+				{ID: "8", ParentID: "7", Name: "Slides"},
+			},
+			nil,
+			[]string{"Conference"},
+			nil,
+			map[int]string{
+				2: "parent task is completed",
+				3: "parents of task form a cycle",
+				4: "parents of task form a cycle",
+				5: "parent is not in the import",
+				6: "parent cannot be imported. row: 5",
+				7: "id is the same as row 1",
+				8: "unknown priority",
+				9: "parent cannot be imported. row: 8",
+			},
+		},
+		{
+			"error case: no records",
+			[]TaskRecord{},
+			errors.New("import has no tasks"),
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"error case: too many records",
+			make([]TaskRecord, MaxImportRecords+1),
+			errors.New("import has too many tasks"),
+			nil,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := NewTaskImport(userID, workspaceID, tt.input, tokyo, now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+
+				return
+			}
+
+			assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			assert.Len(t, output.Rows, len(tt.input))
+			assert.Exactly(t, len(tt.expectedRows), output.ErrorCount())
+			assert.Exactly(t, len(tt.expectedRows) == 0, output.Valid())
+
+			for _, row := range output.Rows {
+				if expected, ok := tt.expectedRows[row.Number]; ok {
+					if assert.Error(t, row.Err, "row %d", row.Number) {
+						assert.Contains(t, row.Err.Error(), expected, "row %d", row.Number)
+					}
+
+					assert.Nil(t, row.Task)
+				} else {
+					assert.NoError(t, row.Err, "row %d", row.Number)
+					assert.Exactly(t, userID, row.Task.UserID)
+					assert.Exactly(t, workspaceID, row.Task.WorkspaceID)
+				}
+			}
+
+			tasks := output.Tasks()
+			names := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				names = append(names, task.Name)
+			}
+
+			assert.Exactly(t, tt.expectedNames, names)
+			assert.Exactly(t, tt.expectedLabels, output.LabelNames())
+		})
+	}
+}
+
+func TestNewTaskImportFields(t *testing.T) {
+	t.Parallel()
+
+	tokyo := LoadLocation("Asia/Tokyo")
+	berlin := LoadLocation("Europe/Berlin")
+	now := time.Date(2022, 1, 20, 9, 0, 0, 0, tokyo)
+	records := []TaskRecord{
+		{ID: "2", ParentID: "1", Name: "Venue Reservation", Detail: "Reserve venue", Deadline: "2022-01-26", DueTime: "18:30", Priority: "p2"},
+		{ID: "1", Name: "Conference", Deadline: "2022-01-28", TimeZone: "Europe/Berlin", Recurrence: "freq=weekly"},
+		{Name: "Invitations", Status: "completed", Deadline: "2022-01-18", CompletionDate: "2022-01-17", Archived: true},
+		{Name: "Catering", Status: "working", Deadline: "2022-01-19"},
+		{Name: "Drinks"},
+	}
+
+	output, err := NewTaskImport("bcdf4a46-4bcd-4f2f-95b7-a3ed9a2c4c56", "3e0a2c1b-7fd2-4e4b-a8f6-52a4b2c3d9e1", records, tokyo, now)
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	subtask, parent, completed, behind, today := output.Rows[0].Task, output.Rows[1].Task, output.Rows[2].Task, output.Rows[3].Task, output.Rows[4].Task
+
+	assert.NotEqual(t, TaskID("2"), subtask.ID)
+	assert.Exactly(t, &parent.ID, subtask.ParentID)
+	assert.Exactly(t, "Reserve venue", subtask.Detail)
+	assert.Exactly(t, DueAt(time.Date(2022, 1, 26, 18, 30, 0, 0, tokyo)).String(), subtask.Due().String())
+	assert.Exactly(t, P2, subtask.Priority)
+	assert.Exactly(t, 1, subtask.Version)
+
+	assert.Nil(t, parent.ParentID)
+	assert.Exactly(t, DueOn(time.Date(2022, 1, 28, 0, 0, 0, 0, berlin)).String(), parent.Due().String())
+	assert.Exactly(t, "Europe/Berlin", parent.TimeZone)
+	assert.Exactly(t, Recurrence("FREQ=WEEKLY"), parent.Recurrence)
+	assert.Exactly(t, DefaultPriority, parent.Priority)
+
+	assert.Exactly(t, Completed, completed.Status)
+	assert.True(t, time.Date(2022, 1, 17, 0, 0, 0, 0, tokyo).Equal(*completed.CompletionDate))
+	assert.True(t, completed.IsArchived())
+
+	assert.Exactly(t, Behind, behind.Status)
+
+	assert.Exactly(t, Working, today.Status)
+	assert.Exactly(t, "2022-01-20", today.Due().String())
+}
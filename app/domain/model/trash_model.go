@@ -0,0 +1,120 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (t Task) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+func (t Task) IsTrashed() bool {
+	return t.TrashedAt != nil
+}
+
+// TaskArchive hides the completed task from the default task list.
+func TaskArchive(fetchedTask Task, now time.Time) (*Task, error) {
+	if fetchedTask.IsTrashed() {
+		return nil, errors.New("trashed task cannot be archived")
+	}
+
+	if fetchedTask.Status != Completed {
+		return nil, errors.Errorf("only completed task can be archived. status: %s", fetchedTask.Status)
+	}
+
+	t := fetchedTask
+	if t.ArchivedAt == nil {
+		t.ArchivedAt = &now
+	}
+
+	return &t, nil
+}
+
+func TaskUnarchive(fetchedTask Task) (*Task, error) {
+	if !fetchedTask.IsArchived() {
+		return nil, errors.New("task is not archived")
+	}
+
+	t := fetchedTask
+	t.ArchivedAt = nil
+
+	return &t, nil
+}
+
+// TaskTrash moves the task into the trash. It can be restored until it is purged.
+func TaskTrash(fetchedTask Task, now time.Time) (*Task, error) {
+	if fetchedTask.IsTrashed() {
+		return nil, errors.New("task is already trashed")
+	}
+
+	t := fetchedTask
+	t.TrashedAt = &now
+
+	return &t, nil
+}
+
+func TaskRestore(fetchedTask Task) (*Task, error) {
+	if !fetchedTask.IsTrashed() {
+		return nil, errors.New("task is not trashed")
+	}
+
+	t := fetchedTask
+	t.TrashedAt = nil
+
+	return &t, nil
+}
+
+// TaskTreeTrash moves the root task of the tree into the trash together with all its subtasks, so that no subtask is left
+// without its parent. The subtasks in the tree are the ones which are not trashed. It returns the tasks which have been changed.
+func TaskTreeTrash(tree TaskTree, now time.Time) ([]*Task, error) {
+	t, err := TaskTrash(*tree.Task, now)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := []*Task{t}
+
+	for _, d := range tree.Descendants() {
+		trashed, err := TaskTrash(*d, now)
+		if err != nil {
+			return nil, err
+		}
+
+		changed = append(changed, trashed)
+	}
+
+	return changed, nil
+}
+
+// TaskTreeRestore restores the root task of the tree together with the subtasks which were trashed with it.
+// The subtasks in the tree are the trashed ones, and the ones trashed on their own before stay in the trash with their subtasks.
+// It returns the tasks which have been changed.
+func TaskTreeRestore(tree TaskTree) ([]*Task, error) {
+	t, err := TaskRestore(*tree.Task)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*Task{t}, trashedWith(tree.Children, *tree.Task.TrashedAt)...), nil
+}
+
+// trashedWith restores the subtasks which were trashed at the time, and their subtasks trashed with them.
+func trashedWith(children []*TaskTree, trashedAt time.Time) []*Task {
+	var changed []*Task
+
+	for _, c := range children {
+		if !c.Task.IsTrashed() || !c.Task.TrashedAt.Equal(trashedAt) {
+			continue
+		}
+
+		t := *c.Task
+		t.TrashedAt = nil
+
+		changed = append(changed, &t)
+		changed = append(changed, trashedWith(c.Children, trashedAt)...)
+	}
+
+	return changed
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskArchive(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		input       Task
+		expectedErr error
+	}{
+		{
+			"normal case",
+			Task{Status: Completed},
+			nil,
+		},
+		{
+			"working task case",
+			Task{Status: Working},
+			errors.New("only completed task can be archived"),
+		},
+		{
+			"trashed task case",
+			Task{Status: Completed, TrashedAt: &now},
+			errors.New("trashed task cannot be archived"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := TaskArchive(tt.input, now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, &now, output.ArchivedAt)
+				assert.True(t, output.IsArchived())
+			}
+		})
+	}
+}
+
+func TestTaskTrashAndRestore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	task := Task{Status: Working}
+
+	trashed, err := TaskTrash(task, now)
+	assert.Nil(t, err)
+	assert.True(t, trashed.IsTrashed())
+
+	_, err = TaskTrash(*trashed, now)
+	assert.Contains(t, err.Error(), "task is already trashed")
+
+	_, err = TaskSet(*trashed, "name", "detail", Working, DueOn(now))
+	assert.Contains(t, err.Error(), "trashed task cannot be updated")
+
+	restored, err := TaskRestore(*trashed)
+	assert.Nil(t, err)
+	assert.False(t, restored.IsTrashed())
+
+	_, err = TaskRestore(*restored)
+	assert.Contains(t, err.Error(), "task is not trashed")
+}
+
+func TestTaskSetUnarchivesReopenedTask(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	archived := Task{Status: Completed, CompletionDate: &deadline, Deadline: deadline, ArchivedAt: &now}
+
+	output, err := TaskSet(archived, "name", "detail", Completed, DueOn(deadline))
+	assert.Nil(t, err)
+	assert.True(t, output.IsArchived())
+
+	output, err = TaskSet(archived, "name", "detail", Working, DueOn(deadline))
+	assert.Nil(t, err)
+	assert.False(t, output.IsArchived())
+}
+
+func TestTaskTreeTrashAndRestore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	earlier := now.AddDate(0, 0, -1)
+
+	root := &Task{ID: "root", Status: Working}
+	child := &Task{ID: "child", Status: Working}
+	grandchild := &Task{ID: "grandchild", Status: Working}
+
+	trashed, err := TaskTreeTrash(TaskTree{Task: root, Children: []*TaskTree{{Task: child, Children: []*TaskTree{{Task: grandchild}}}}}, now)
+	assert.Nil(t, err)
+	assert.Len(t, trashed, 3)
+
+	for _, tr := range trashed {
+		assert.Exactly(t, &now, tr.TrashedAt)
+	}
+
+	_, err = TaskTreeTrash(TaskTree{Task: trashed[0]}, now)
+	assert.Contains(t, err.Error(), "task is already trashed")
+
+	trashedAlone := &Task{ID: "alone", Status: Working, TrashedAt: &earlier}
+	tree := TaskTree{Task: trashed[0], Children: []*TaskTree{
+		{Task: trashed[1], Children: []*TaskTree{{Task: trashed[2]}}},
+		{Task: trashedAlone, Children: []*TaskTree{{Task: &Task{ID: "under alone", Status: Working, TrashedAt: &now}}}},
+	}}
+
+	restored, err := TaskTreeRestore(tree)
+	assert.Nil(t, err)
+
+	ids := make([]TaskID, 0, len(restored))
+	for _, r := range restored {
+		assert.False(t, r.IsTrashed())
+		ids = append(ids, r.ID)
+	}
+
+	assert.Exactly(t, []TaskID{"root", "child", "grandchild"}, ids)
+	assert.True(t, trashed[1].IsTrashed(), "the subtasks in the tree must not be changed")
+
+	_, err = TaskTreeRestore(TaskTree{Task: root})
+	assert.Contains(t, err.Error(), "task is not trashed")
+}
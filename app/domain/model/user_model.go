@@ -2,15 +2,20 @@ package model
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// User is an account. TimeZone is the IANA time zone in which the deadlines the user sets are interpreted.
+// CalendarToken is the secret of the iCalendar feed of the user, which is empty while the feed is disabled.
 type User struct {
-	ID       UserID
-	Email    Email
-	Password string
+	ID            UserID
+	Email         Email
+	Password      string
+	TimeZone      string
+	CalendarToken string
 }
 
 type (
@@ -40,6 +45,7 @@ func NewUser(id UserID, email Email, pw string) (*User, error) {
 		ID:       id,
 		Email:    email,
 		Password: string(hash),
+		TimeZone: DefaultTimeZone,
 	}
 
 	if err := UserSpecSatisfied(*u); err != nil {
@@ -69,6 +75,40 @@ func UserSpecSatisfied(u User) error {
 	return nil
 }
 
+// UserSetTimeZone changes the time zone of the user. The deadlines set before keep their time zone.
+func UserSetTimeZone(fetchedUser User, timeZone string) (*User, error) {
+	if err := TimeZoneSpecSatisfied(timeZone); err != nil {
+		return nil, errors.Wrap(err, "failed to set time zone")
+	}
+
+	u := fetchedUser
+	u.TimeZone = timeZone
+
+	return &u, nil
+}
+
+// UserIssueCalendarToken enables the iCalendar feed of the user with a new token.
+// The token issued before is replaced so that the URL leaked can be invalidated.
+func UserIssueCalendarToken(fetchedUser User) *User {
+	u := fetchedUser
+	u.CalendarToken = string(CreateUUID())
+
+	return &u
+}
+
+// UserRevokeCalendarToken disables the iCalendar feed of the user.
+func UserRevokeCalendarToken(fetchedUser User) *User {
+	u := fetchedUser
+	u.CalendarToken = ""
+
+	return &u
+}
+
+// Location returns the time zone of the user.
+func (u User) Location() *time.Location {
+	return LoadLocation(u.TimeZone)
+}
+
 func (u *User) ValidatePassword(password string) error {
 	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
 		return errors.Wrapf(err, "fail to authenticate password")
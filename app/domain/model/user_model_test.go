@@ -73,9 +73,42 @@ func TestNewUser(t *testing.T) {
 				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
 				assert.Exactly(t, tt.expectedOutput.ID, output.ID)
 				assert.Exactly(t, tt.expectedOutput.Email, output.Email)
+				assert.Exactly(t, DefaultTimeZone, output.TimeZone)
 				assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(output.Password), []byte(tt.password)))
 				assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(tt.expectedOutput.Password), []byte(tt.password)))
 			}
 		})
 	}
 }
+
+func TestUserSetTimeZone(t *testing.T) {
+	t.Parallel()
+
+	user := User{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab", TimeZone: DefaultTimeZone}
+
+	output, err := UserSetTimeZone(user, "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Exactly(t, "Europe/Berlin", output.TimeZone)
+	assert.Exactly(t, "Europe/Berlin", output.Location().String())
+
+	_, err = UserSetTimeZone(user, "Europe/Atlantis")
+	assert.Contains(t, err.Error(), "unknown time zone")
+}
+
+func TestUserCalendarToken(t *testing.T) {
+	t.Parallel()
+
+	user := User{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab"}
+
+	issued := UserIssueCalendarToken(user)
+	assert.Len(t, issued.CalendarToken, 36)
+	assert.Empty(t, user.CalendarToken)
+
+	reissued := UserIssueCalendarToken(*issued)
+	assert.NotEqual(t, issued.CalendarToken, reissued.CalendarToken)
+
+	assert.Empty(t, UserRevokeCalendarToken(*reissued).CalendarToken)
+}
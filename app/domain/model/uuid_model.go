@@ -1,9 +1,21 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
 
 type UUID string
 
 func CreateUUID() UUID {
 	return UUID(uuid.Must(uuid.NewRandom()).String())
 }
+
+// UUIDSpecSatisfied checks that the ID is a UUID in the hyphenated form of 36 characters, like the ones of CreateUUID.
+func UUIDSpecSatisfied(id string) error {
+	if _, err := uuid.Parse(id); err != nil || len(id) != 36 {
+		return errors.Errorf("id must be a UUID. id: %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+type WebhookID string
+
+type WebhookDeliveryID string
+
+// Webhook delivers the events of the tasks of a workspace to the URL. Events are the names of the subscribed events
+// separated by commas, and Secret is the key with which the deliveries are signed.
+type Webhook struct {
+	ID          WebhookID
+	WorkspaceID WorkspaceID
+	UserID      UserID
+	URL         string
+	Secret      string
+	Events      string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+const (
+	MaxWebhookURLLength = 2048
+	// MaxWebhookAttempts is how many times a delivery is sent before it is given up.
+	MaxWebhookAttempts = 6
+	// WebhookFirstRetryDelay is the delay before the second attempt, which doubles with every further attempt.
+	WebhookFirstRetryDelay = time.Minute
+
+	webhookEventSeparator  = ","
+	maxDeliveryErrorLength = 255
+)
+
+// NewWebhook creates an active webhook of the workspace with a new secret.
+func NewWebhook(id WebhookID, workspaceID WorkspaceID, userID UserID, rawURL string, events []string, now time.Time) (*Webhook, error) {
+	w := &Webhook{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Secret:      NewWebhookSecret(),
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	return WebhookSet(*w, rawURL, events, true, now)
+}
+
+// WebhookSet changes the URL and the events of the webhook, and turns it on or off.
+func WebhookSet(fetchedWebhook Webhook, rawURL string, events []string, active bool, now time.Time) (*Webhook, error) {
+	w := fetchedWebhook
+	w.URL = strings.TrimSpace(rawURL)
+	w.Active = active
+	w.UpdatedAt = now
+
+	subscribed, err := parseWebhookEvents(events)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Events = subscribed
+
+	if err := WebhookSpecSatisfied(w); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// WebhookRotateSecret replaces the secret of the webhook, so that the deliveries cannot be signed with the old one anymore.
+func WebhookRotateSecret(fetchedWebhook Webhook, now time.Time) *Webhook {
+	w := fetchedWebhook
+	w.Secret = NewWebhookSecret()
+	w.UpdatedAt = now
+
+	return &w
+}
+
+func WebhookSpecSatisfied(w Webhook) error {
+	if utf8.RuneCountInString(w.URL) > MaxWebhookURLLength {
+		return errors.Errorf("webhook url must be at most %d characters", MaxWebhookURLLength)
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("webhook url must be an absolute http or https url. url: %s", w.URL)
+	}
+
+	if u.User != nil {
+		return errors.New("webhook url must not have credentials")
+	}
+
+	// INFO: the names are checked again by the sender for every address they resolve to, since they can change later
+	if host := u.Hostname(); strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errors.Errorf("webhook url must not be a local address. url: %s", w.URL)
+	} else if ip := net.ParseIP(host); ip != nil && !PublicIP(ip) {
+		return errors.Errorf("webhook url must not be a local address. url: %s", w.URL)
+	}
+
+	if len(w.EventTypes()) == 0 {
+		return errors.New("webhook needs at least one event")
+	}
+
+	return nil
+}
+
+// sharedAddressSpace is the range of carrier-grade NAT of RFC 6598, which is not public but not private by net.IP either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// PublicIP reports whether webhooks may be delivered to the address, which must not be a loopback, private, link-local,
+// multicast or unspecified one, so that webhooks cannot reach the server itself or the network behind it.
+func PublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// parseWebhookEvents joins the names of the events in the order of EventTypes, without duplicates.
+func parseWebhookEvents(names []string) (string, error) {
+	subscribed := make(map[EventType]bool, len(names))
+
+	for _, name := range names {
+		t, err := ParseEventType(strings.TrimSpace(name))
+		if err != nil {
+			return "", err
+		}
+
+		subscribed[t] = true
+	}
+
+	events := make([]string, 0, len(subscribed))
+	for _, t := range EventTypes {
+		if subscribed[t] {
+			events = append(events, string(t))
+		}
+	}
+
+	return strings.Join(events, webhookEventSeparator), nil
+}
+
+func (w Webhook) EventTypes() []EventType {
+	if w.Events == "" {
+		return nil
+	}
+
+	names := strings.Split(w.Events, webhookEventSeparator)
+	types := make([]EventType, 0, len(names))
+
+	for _, name := range names {
+		types = append(types, EventType(name))
+	}
+
+	return types
+}
+
+// Subscribes reports whether the webhook delivers the events of the type.
+func (w Webhook) Subscribes(t EventType) bool {
+	for _, s := range w.EventTypes() {
+		if s == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewWebhookSecret returns a random key of 256 bits in hex.
+// INFO: unlike the tokens of links, the secret is a key of HMAC, so that it is as long as the output of SHA-256
+func NewWebhookSecret() string {
+	b := make([]byte, sha256.Size)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Wrap(err, "failed to read random bytes"))
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// SignWebhook returns the signature of a delivery, the HMAC-SHA256 of the timestamp in Unix seconds, a dot and the payload.
+// The timestamp is signed so that receivers can reject old deliveries which are sent again by someone else.
+func SignWebhook(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event sent to a webhook, and the log of the attempts to send it.
+// The payload is made when the event occurs, so that every attempt sends the task as it was then.
+type WebhookDelivery struct {
+	ID             WebhookDeliveryID
+	WebhookID      WebhookID
+	EventType      EventType
+	TaskID         TaskID
+	Payload        string
+	Status         DeliveryStatus
+	Attempts       int
+	ResponseStatus int
+	Error          string
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	CreatedAt      time.Time
+}
+
+// webhookPayload is the JSON body of a delivery.
+type webhookPayload struct {
+	ID          WebhookDeliveryID `json:"id"`
+	Event       EventType         `json:"event"`
+	OccurredAt  string            `json:"occurred_at"`
+	WorkspaceID WorkspaceID       `json:"workspace_id"`
+	Task        webhookTask       `json:"task"`
+}
+
+type webhookTask struct {
+	ID             string `json:"id"`
+	ParentID       string `json:"parent_id,omitempty"`
+	UserID         UserID `json:"user_id"`
+	AssigneeID     UserID `json:"assignee_id,omitempty"`
+	Name           string `json:"name"`
+	Detail         string `json:"detail"`
+	Status         string `json:"status"`
+	Deadline       string `json:"deadline"`
+	DueTime        string `json:"due_time,omitempty"`
+	TimeZone       string `json:"time_zone"`
+	Recurrence     string `json:"recurrence,omitempty"`
+	Priority       string `json:"priority"`
+	CompletionDate string `json:"completion_date,omitempty"`
+	PostponedCount int    `json:"postponed_count"`
+	Archived       bool   `json:"archived"`
+	Trashed        bool   `json:"trashed"`
+	Version        int    `json:"version"`
+}
+
+// NewWebhookDelivery creates a delivery of the event of the task to the webhook, which is sent first at once.
+func NewWebhookDelivery(id WebhookDeliveryID, w Webhook, e TaskEvent, t Task, now time.Time) (*WebhookDelivery, error) {
+	r := TaskRecordOf(t)
+
+	payload, err := json.Marshal(&webhookPayload{
+		ID:          id,
+		Event:       e.Type,
+		OccurredAt:  e.OccurredAt.UTC().Format(time.RFC3339),
+		WorkspaceID: t.WorkspaceID,
+		Task: webhookTask{
+			ID:             r.ID,
+			ParentID:       r.ParentID,
+			UserID:         t.UserID,
+			AssigneeID:     t.AssigneeID,
+			Name:           r.Name,
+			Detail:         r.Detail,
+			Status:         r.Status,
+			Deadline:       r.Deadline,
+			DueTime:        r.DueTime,
+			TimeZone:       r.TimeZone,
+			Recurrence:     r.Recurrence,
+			Priority:       r.Priority,
+			CompletionDate: r.CompletionDate,
+			PostponedCount: t.PostponedCount,
+			Archived:       r.Archived,
+			Trashed:        t.IsTrashed(),
+			Version:        t.Version,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make webhook payload")
+	}
+
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     w.ID,
+		EventType:     e.Type,
+		TaskID:        t.ID,
+		Payload:       string(payload),
+		Status:        DeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// WebhookDeliveryAttempted records an attempt to send the delivery, which succeeds with a 2xx response.
+// A failed delivery is retried with a doubling delay until it has been sent MaxWebhookAttempts times.
+func WebhookDeliveryAttempted(d WebhookDelivery, statusCode int, sendErr error, now time.Time) *WebhookDelivery {
+	d.Attempts++
+	d.ResponseStatus = statusCode
+	d.LastAttemptAt = &now
+	d.Error = ""
+
+	switch {
+	case sendErr != nil:
+		d.Error = truncateRunes(sendErr.Error(), maxDeliveryErrorLength)
+	case statusCode < 200 || statusCode > 299:
+		d.Error = "unexpected response status: " + strconv.Itoa(statusCode)
+	default:
+		d.Status = DeliverySucceeded
+
+		return &d
+	}
+
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = DeliveryFailed
+	} else {
+		d.NextAttemptAt = now.Add(WebhookRetryDelay(d.Attempts))
+	}
+
+	return &d
+}
+
+// WebhookDeliveryCanceled gives up the delivery without sending it, e.g. when its webhook is turned off.
+func WebhookDeliveryCanceled(d WebhookDelivery, reason string) *WebhookDelivery {
+	d.Status = DeliveryFailed
+	d.Error = truncateRunes(reason, maxDeliveryErrorLength)
+
+	return &d
+}
+
+// WebhookRetryDelay returns the delay after the attempts, which is WebhookFirstRetryDelay after the first one and doubles with every further one.
+func WebhookRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	return WebhookFirstRetryDelay << (attempts - 1)
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	return string([]rune(s)[:max])
+}
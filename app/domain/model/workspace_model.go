@@ -0,0 +1,185 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Workspace owns tasks and groups the users who work on them. Each member has a role in the workspace.
+// Every user has a personal workspace, created on sign up, whose ID is the ID of the user.
+type Workspace struct {
+	ID        WorkspaceID
+	Name      string
+	CreatedAt time.Time
+}
+
+type WorkspaceID string
+
+// WorkspaceDetail is a workspace as seen by a member with the role, together with its members and their users.
+// The pending invitations are only given to the members who can invite.
+type WorkspaceDetail struct {
+	Workspace   *Workspace
+	Role        Role
+	Members     []*Member
+	Users       map[UserID]*User
+	Invitations []*Invitation
+}
+
+// Member is a user who belongs to a workspace with a role.
+type Member struct {
+	WorkspaceID WorkspaceID
+	UserID      UserID
+	Role        Role
+	CreatedAt   time.Time
+}
+
+// Role is what a member can do in a workspace. Roles are ordered so that a role can do all that the lower ones can.
+// Viewers can view the tasks of the workspace, members can also create tasks and work on their own and assigned ones,
+// admins can also manage every task and invite users, and owners can also make admins.
+type Role int
+
+const (
+	RoleViewer Role = iota
+	RoleMember
+	RoleAdmin
+	RoleOwner
+)
+
+var roleNames = map[Role]string{
+	RoleViewer: "viewer",
+	RoleMember: "member",
+	RoleAdmin:  "admin",
+	RoleOwner:  "owner",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func ParseRole(name string) (Role, error) {
+	for r, n := range roleNames {
+		if n == name {
+			return r, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown role. name: %s", name)
+}
+
+// CanGrant reports whether a member of the role can give the other role to users.
+// Admins and owners can grant the roles below their own, so the ownership cannot be granted.
+func (r Role) CanGrant(role Role) bool {
+	return r >= RoleAdmin && role < r
+}
+
+const (
+	maxWorkspaceNameLength = 100
+	personalWorkspaceName  = "Personal"
+)
+
+// PersonalWorkspaceID returns the ID of the personal workspace of the user.
+func PersonalWorkspaceID(userID UserID) WorkspaceID {
+	return WorkspaceID(userID)
+}
+
+func NewWorkspace(id WorkspaceID, name string, now time.Time) (*Workspace, error) {
+	w := &Workspace{
+		ID:        id,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: now,
+	}
+
+	if err := WorkspaceSpecSatisfied(*w); err != nil {
+		return nil, errors.Wrapf(err, "failed to satisfy Workspace spec. w: %+v", w)
+	}
+
+	return w, nil
+}
+
+// NewPersonalWorkspace creates the personal workspace of the user, who is its owner.
+func NewPersonalWorkspace(userID UserID, now time.Time) (*Workspace, *Member, error) {
+	w, err := NewWorkspace(PersonalWorkspaceID(userID), personalWorkspaceName, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return w, NewMember(w.ID, userID, RoleOwner, now), nil
+}
+
+func WorkspaceSpecSatisfied(w Workspace) error {
+	if w.Name == "" {
+		return errors.New("workspace name is required")
+	}
+
+	if utf8.RuneCountInString(w.Name) > maxWorkspaceNameLength {
+		return errors.Errorf("workspace name exceeds %d characters", maxWorkspaceNameLength)
+	}
+
+	return nil
+}
+
+func NewMember(workspaceID WorkspaceID, userID UserID, role Role, now time.Time) *Member {
+	return &Member{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        role,
+		CreatedAt:   now,
+	}
+}
+
+// MemberChangeRole changes the role of the target by the actor, who has to outrank the target and be able to grant the role.
+func MemberChangeRole(actor, target Member, role Role) (*Member, error) {
+	if _, ok := roleNames[role]; !ok {
+		return nil, errors.Errorf("invalid role. role: %d", role)
+	}
+
+	if actor.Role <= target.Role {
+		return nil, errors.Errorf("%s cannot change role of %s", actor.Role, target.Role)
+	}
+
+	if !actor.Role.CanGrant(role) {
+		return nil, errors.Errorf("%s cannot grant %s", actor.Role, role)
+	}
+
+	m := target
+	m.Role = role
+
+	return &m, nil
+}
+
+// MemberRemovable checks that the actor can remove the target from the workspace.
+// Members can leave by themselves except owners, and admins and owners can remove the members they outrank.
+func MemberRemovable(actor, target Member) error {
+	if actor.UserID == target.UserID {
+		if target.Role == RoleOwner {
+			return errors.New("owner cannot leave workspace")
+		}
+
+		return nil
+	}
+
+	if actor.Role < RoleAdmin || actor.Role <= target.Role {
+		return errors.Errorf("%s cannot remove %s", actor.Role, target.Role)
+	}
+
+	return nil
+}
+
+// TaskPlace puts the new task in the workspace.
+func TaskPlace(fetchedTask Task, workspaceID WorkspaceID) (*Task, error) {
+	if workspaceID == "" {
+		return nil, errors.New("workspace is required")
+	}
+
+	t := fetchedTask
+	t.WorkspaceID = workspaceID
+
+	return &t, nil
+}
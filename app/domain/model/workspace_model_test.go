@@ -0,0 +1,153 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberChangeRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		actor       Role
+		target      Role
+		role        Role
+		expectedErr error
+	}{
+		{
+			"owner makes admin case",
+			RoleOwner,
+			RoleMember,
+			RoleAdmin,
+			nil,
+		},
+		{
+			"owner makes owner case",
+			RoleOwner,
+			RoleAdmin,
+			RoleOwner,
+			errors.New("owner cannot grant owner"),
+		},
+		{
+			"admin makes viewer case",
+			RoleAdmin,
+			RoleMember,
+			RoleViewer,
+			nil,
+		},
+		{
+			"admin changes admin case",
+			RoleAdmin,
+			RoleAdmin,
+			RoleMember,
+			errors.New("admin cannot change role of admin"),
+		},
+		{
+			"member changes viewer case",
+			RoleMember,
+			RoleViewer,
+			RoleMember,
+			errors.New("member cannot grant member"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actor := Member{WorkspaceID: "team", UserID: "actor", Role: tt.actor}
+			target := Member{WorkspaceID: "team", UserID: "target", Role: tt.target}
+
+			output, err := MemberChangeRole(actor, target, tt.role)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.role, output.Role)
+				assert.Exactly(t, target.UserID, output.UserID)
+			}
+		})
+	}
+}
+
+func TestTaskAccessOf(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	userID := UserID("user")
+	task := Task{UserID: "creator", AssigneeID: "assignee", WorkspaceID: "team", Visibility: Private}
+
+	tests := []struct {
+		name     string
+		task     Task
+		userID   UserID
+		role     *Role
+		shared   []UserID
+		expected TaskAccess
+	}{
+		{"admin case", task, userID, rolePtr(RoleAdmin), nil, ManageAccess},
+		{"creator case", task, "creator", rolePtr(RoleMember), nil, ManageAccess},
+		{"assignee case", task, "assignee", rolePtr(RoleMember), nil, WorkAccess},
+		{"other member case", task, userID, rolePtr(RoleMember), nil, ViewAccess},
+		{"viewer creator case", task, "creator", rolePtr(RoleViewer), nil, ViewAccess},
+		{"outsider case", task, userID, nil, []UserID{userID}, NoAccess},
+		{"shared outsider case", Task{UserID: "creator", WorkspaceID: "team", Visibility: Shared}, userID, nil, []UserID{userID}, ViewAccess},
+		{"former creator case", task, "creator", nil, nil, ViewAccess},
+		{"trashed task case", Task{UserID: "creator", WorkspaceID: "team", TrashedAt: &now}, "creator", rolePtr(RoleViewer), nil, NoAccess},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var m *Member
+			if tt.role != nil {
+				m = NewMember(tt.task.WorkspaceID, tt.userID, *tt.role, now)
+			}
+
+			assert.Exactly(t, tt.expected, TaskAccessOf(tt.task, tt.userID, m, tt.shared))
+		})
+	}
+}
+
+func TestInvitationAccept(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+	inviter := Member{WorkspaceID: "team", UserID: "inviter", Role: RoleAdmin}
+
+	invitation, err := NewInvitation("id", inviter, RoleMember, now)
+	assert.Nil(t, err)
+	assert.Exactly(t, now.Add(InvitationTTL), invitation.ExpiresAt)
+
+	_, err = NewInvitation("id", inviter, RoleAdmin, now)
+	assert.Contains(t, err.Error(), "admin cannot invite admin")
+
+	_, err = NewInvitation("id", Member{WorkspaceID: "inviter", UserID: "inviter", Role: RoleOwner}, RoleMember, now)
+	assert.Contains(t, err.Error(), "nobody can be invited to personal workspace")
+
+	accepted, member, err := InvitationAccept(*invitation, "user", now)
+	assert.Nil(t, err)
+	assert.True(t, accepted.IsAccepted())
+	assert.Exactly(t, &Member{WorkspaceID: "team", UserID: "user", Role: RoleMember, CreatedAt: now}, member)
+
+	_, _, err = InvitationAccept(*accepted, "other", now)
+	assert.Contains(t, err.Error(), "invitation is already accepted")
+
+	_, _, err = InvitationAccept(*invitation, "user", invitation.ExpiresAt)
+	assert.Contains(t, err.Error(), "invitation is expired")
+}
+
+func rolePtr(r Role) *Role {
+	return &r
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source=attachment_repository.go -destination=../../mock/mock_attachment_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type AttachmentRepository interface {
+	Create(*model.Attachment) error
+	FindByID(model.AttachmentID) (*model.Attachment, error)
+	// FindByTaskID returns the attachments of the task, the oldest first.
+	FindByTaskID(model.TaskID) ([]*model.Attachment, error)
+	Delete(model.AttachmentID) error
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source=comment_repository.go -destination=../../mock/mock_comment_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type CommentRepository interface {
+	Create(*model.Comment) error
+	FindByID(model.CommentID) (*model.Comment, error)
+	// FindByTaskID returns the comments on the task including the deleted ones, the oldest first.
+	FindByTaskID(model.TaskID) ([]*model.Comment, error)
+	Update(*model.Comment) error
+}
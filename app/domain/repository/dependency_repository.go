@@ -0,0 +1,16 @@
+//go:generate mockgen -source=dependency_repository.go -destination=../../mock/mock_dependency_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type DependencyRepository interface {
+	Create(*model.Dependency) error
+	// Delete deletes the dependency of the task on the blocker. Deleting a missing dependency is not an error.
+	Delete(taskID, blockerID model.TaskID) error
+	// FindByWorkspaceID returns all the dependencies between the tasks of the workspace.
+	FindByWorkspaceID(model.WorkspaceID) ([]*model.Dependency, error)
+	// FindBlockers returns the tasks which block each of the tasks, including completed and trashed ones.
+	FindBlockers([]model.TaskID) (map[model.TaskID][]*model.Task, error)
+	// FindBlocking returns the tasks which are blocked by the task.
+	FindBlocking(model.TaskID) ([]*model.Task, error)
+}
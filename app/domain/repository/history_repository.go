@@ -0,0 +1,13 @@
+//go:generate mockgen -source=history_repository.go -destination=../../mock/mock_history_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+// HistoryRepository finds the history of tasks. Histories are only appended together with the changes which they record
+// by the repositories storing the changes, and never updated nor deleted.
+type HistoryRepository interface {
+	// FindByTaskID returns the history of the task, the oldest first.
+	FindByTaskID(model.TaskID) ([]*model.TaskHistory, error)
+	// FindByUserID returns at most limit of the latest changes made by the user, the newest first.
+	FindByUserID(model.UserID, int) ([]*model.TaskHistory, error)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=invitation_repository.go -destination=../../mock/mock_invitation_repository.go -package=mock
+package repository
+
+import (
+	"time"
+	"todo-app/domain/model"
+)
+
+type InvitationRepository interface {
+	Create(*model.Invitation) error
+	FindByToken(string) (*model.Invitation, error)
+	// FindPendingByWorkspaceID returns the invitations of the workspace which are neither accepted nor expired at the time,
+	// the newest first.
+	FindPendingByWorkspaceID(model.WorkspaceID, time.Time) ([]*model.Invitation, error)
+	// Accept stores the accepted invitation together with the new member,
+	// unless the invitation has been accepted in the meantime, which is reported as ErrVersionConflict.
+	Accept(*model.Invitation, *model.Member) error
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=label_repository.go -destination=../../mock/mock_label_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type LabelRepository interface {
+	Create(*model.Label) error
+	FindByID(model.LabelID) (*model.Label, error)
+	FindByUserID(model.UserID) ([]*model.Label, error)
+	Update(*model.Label) error
+	Delete(model.LabelID) error
+	// FindByTaskIDs returns the labels of the user attached to each of the tasks.
+	FindByTaskIDs(model.UserID, []model.TaskID) (map[model.TaskID][]*model.Label, error)
+	// UpdateTaskLabels replaces the labels of the user attached to the task.
+	UpdateTaskLabels(model.TaskID, model.UserID, []model.LabelID) error
+}
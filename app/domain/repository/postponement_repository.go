@@ -0,0 +1,17 @@
+//go:generate mockgen -source=postponement_repository.go -destination=../../mock/mock_postponement_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type PostponementRepository interface {
+	Create(*model.Postponement) error
+	FindByID(model.PostponementID) (*model.Postponement, error)
+	// FindByTaskID returns the postponements of the task, the newest first.
+	FindByTaskID(model.TaskID) ([]*model.Postponement, error)
+	// FindPendingByApproverID returns the postponements waiting for the decision of the user, the oldest first.
+	FindPendingByApproverID(model.UserID) ([]*model.Postponement, error)
+	// Decide stores the decision of the postponement together with the postponed task, which is nil unless it is approved,
+	// and the history of the task in a transaction. It fails with ErrVersionConflict when the postponement is no longer pending
+	// or the task has been updated.
+	Decide(*model.Postponement, *model.Task, *model.TaskHistory) error
+}
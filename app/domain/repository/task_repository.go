@@ -1,11 +1,52 @@
 //go:generate mockgen -source=task_repository.go -destination=../../mock/mock_task_repository.go -package=mock
 package repository
 
-import "todo-app/domain/model"
+import (
+	"time"
+	"todo-app/domain/model"
 
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidCursor is returned by Find when the cursor of the query cannot be decoded
+// or was issued for another sort.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrVersionConflict is returned by Update and UpdateAll when a task has been updated
+// since it was fetched, i.e. its version in the store differs from the version of the given task.
+// It is also returned by InvitationRepository.Accept when the invitation has been accepted since it was fetched.
+var ErrVersionConflict = errors.New("version conflict")
+
+// The methods which change tasks take the histories of the changes, and store them in the same transaction as the changes.
+// A nil history records nothing.
 type TaskRepository interface {
-	Create(*model.Task) error
+	Create(*model.Task, *model.TaskHistory) error
+	// Import creates the tasks, the new labels and the labels attached to the tasks in a transaction.
+	// The tasks are created in their order, so parents have to precede their subtasks.
+	Import([]*model.Task, []*model.Label, map[model.TaskID][]model.LabelID, []*model.TaskHistory) error
 	FindByID(model.TaskID) (*model.Task, error)
-	FindAll() ([]*model.Task, error)
-	Update(*model.Task) error
+	// Find returns a page of the tasks selected by the query.
+	Find(model.TaskQuery) (*model.TaskPage, error)
+	FindByParentID(model.TaskID) ([]*model.Task, error)
+	FindTrashedByParentID(model.TaskID) ([]*model.Task, error)
+	FindArchivedByUserID(model.UserID) ([]*model.Task, error)
+	FindTrashedByUserID(model.UserID) ([]*model.Task, error)
+	FindByShareToken(string) (*model.Task, error)
+	// FindByCalendarName finds the untrashed task assigned to the user whose todo a calendar client has named so.
+	FindByCalendarName(model.UserID, string) (*model.Task, error)
+	FindByStatus(model.Status) ([]*model.Task, error)
+	// Update stores the task only if its version is unchanged in the store, and increments the version of the task.
+	Update(*model.Task, *model.TaskHistory) error
+	// UpdateAll updates the tasks in a transaction like Update. None is stored if any of them conflicts.
+	UpdateAll([]*model.Task, []*model.TaskHistory) error
+	// Complete updates the tasks in a transaction like UpdateAll, and creates the next occurrences of the recurring ones,
+	// which are keyed by the ID of the task they follow and shared with the same users as it.
+	Complete([]*model.Task, map[model.TaskID]*model.Task, []*model.TaskHistory) error
+	// Delete deletes the task and the rows which refer to it, and returns its attachments, whose files are left to be deleted.
+	Delete(model.TaskID) ([]*model.Attachment, error)
+	// DeleteTrashedBefore deletes the tasks trashed before the time like Delete, and returns how many they are and their attachments.
+	DeleteTrashedBefore(time.Time) (int, []*model.Attachment, error)
+	FindSharedUserIDs(model.TaskID) ([]model.UserID, error)
+	// Share updates the task like Update and replaces the users it is shared with in a transaction.
+	Share(*model.Task, []model.UserID, *model.TaskHistory) error
 }
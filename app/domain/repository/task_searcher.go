@@ -0,0 +1,9 @@
+//go:generate mockgen -source=task_searcher.go -destination=../../mock/mock_task_searcher.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type TaskSearcher interface {
+	// Search returns the tasks matching all the terms of the search, the best match first.
+	Search(model.TaskSearch) ([]*model.TaskHit, error)
+}
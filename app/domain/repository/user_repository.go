@@ -4,6 +4,10 @@ package repository
 import "todo-app/domain/model"
 
 type UserRepository interface {
-	Create(*model.User) error
+	// Create creates the user together with the personal workspace and the membership of the user in a transaction.
+	Create(*model.User, *model.Workspace, *model.Member) error
+	FindByID(model.UserID) (*model.User, error)
 	FindByEmail(model.Email) (*model.User, error)
+	FindByCalendarToken(token string) (*model.User, error)
+	Update(*model.User) error
 }
@@ -0,0 +1,23 @@
+//go:generate mockgen -source=webhook_repository.go -destination=../../mock/mock_webhook_repository.go -package=mock
+package repository
+
+import (
+	"time"
+	"todo-app/domain/model"
+)
+
+type WebhookRepository interface {
+	Create(*model.Webhook) error
+	Update(*model.Webhook) error
+	// Delete deletes the webhook with its deliveries.
+	Delete(model.WebhookID) error
+	FindByID(model.WebhookID) (*model.Webhook, error)
+	// FindByWorkspaceID returns the webhooks of the workspace, the oldest first.
+	FindByWorkspaceID(model.WorkspaceID) ([]*model.Webhook, error)
+	CreateDeliveries([]*model.WebhookDelivery) error
+	UpdateDelivery(*model.WebhookDelivery) error
+	// FindDeliveries returns the latest deliveries of the webhook, up to limit.
+	FindDeliveries(id model.WebhookID, limit int) ([]*model.WebhookDelivery, error)
+	// FindDueDeliveries returns the pending deliveries whose next attempt is at or before now, the earliest first, up to limit.
+	FindDueDeliveries(now time.Time, limit int) ([]*model.WebhookDelivery, error)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=workspace_repository.go -destination=../../mock/mock_workspace_repository.go -package=mock
+package repository
+
+import "todo-app/domain/model"
+
+type WorkspaceRepository interface {
+	// Create stores the workspace together with its first member.
+	Create(*model.Workspace, *model.Member) error
+	FindByID(model.WorkspaceID) (*model.Workspace, error)
+	// FindByUserID returns the workspaces which the user is a member of, by name.
+	FindByUserID(model.UserID) ([]*model.Workspace, error)
+	// FindMember returns the membership of the user in the workspace, or nil unless the user is a member.
+	FindMember(model.WorkspaceID, model.UserID) (*model.Member, error)
+	// FindMembers returns the members of the workspace, the oldest first.
+	FindMembers(model.WorkspaceID) ([]*model.Member, error)
+	UpdateMember(*model.Member) error
+	DeleteMember(model.WorkspaceID, model.UserID) error
+}
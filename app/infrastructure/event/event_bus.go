@@ -0,0 +1,52 @@
+package event
+
+import (
+	"log"
+	"sync"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+)
+
+type Subscriber func(model.TaskEvent)
+
+// EventBus delivers published events to the subscribers in process.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+var _ usecase.EventPublisher = (*EventBus)(nil)
+
+func (b *EventBus) Subscribe(s Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, s)
+}
+
+func (b *EventBus) Publish(e model.TaskEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, s := range b.subscribers {
+		s(e)
+	}
+}
+
+func LogSubscriber(e model.TaskEvent) {
+	log.Printf("event: %s, taskID: %s, userID: %s, occurredAt: %s", e.Type, e.TaskID, e.UserID, e.OccurredAt.Format("2006-01-02 15:04:05"))
+}
+
+// WebhookSubscriber queues the deliveries of the events to the webhooks, which fails only with the database,
+// so that the error is logged rather than failing the change of the task which has already been stored.
+func WebhookSubscriber(u usecase.WebhookUsecase) Subscriber {
+	return func(e model.TaskEvent) {
+		if err := u.Dispatch(e); err != nil {
+			log.Printf("failed to dispatch event: %s, taskID: %s, err: %v", e.Type, e.TaskID, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"log"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+)
+
+// LogSender writes notifications to the log instead of delivering them.
+// It is used when no SMTP server is configured.
+type LogSender struct{}
+
+func NewLogSender() usecase.NotificationSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(to model.Email, subject, body string) error {
+	log.Printf("notification to: %s, subject: %s\n%s", to, subject, body)
+
+	return nil
+}
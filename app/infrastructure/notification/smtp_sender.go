@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPSender returns a sender which delivers mails via the SMTP server at host:port.
+// Authentication is skipped when username is empty.
+func NewSMTPSender(host, port, username, password, from string) usecase.NotificationSender {
+	s := &SMTPSender{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s
+}
+
+func (s *SMTPSender) Send(to model.Email, subject, body string) error {
+	msg := s.message(to, subject, body)
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{string(to)}, msg); err != nil {
+		return errors.Wrapf(err, "failed to send mail. to: %s", to)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) message(to model.Email, subject, body string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(normalizeNewlines(body))
+
+	return b.Bytes()
+}
+
+func normalizeNewlines(s string) string {
+	var b bytes.Buffer
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+
+			b.WriteString("\r\n")
+		case '\n':
+			b.WriteString("\r\n")
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveFakeSMTP accepts a single SMTP session on a local port and sends the received mail to the returned channel.
+func serveFakeSMTP(t *testing.T) (string, string, <-chan receivedMail) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	t.Cleanup(func() { l.Close() })
+
+	mails := make(chan receivedMail, 1)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		mail := receivedMail{}
+
+		_ = tp.PrintfLine("220 localhost fake SMTP")
+
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			cmd := strings.ToUpper(line)
+
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				_ = tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				mail.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				_ = tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+
+				mail.data = string(data)
+				mails <- mail
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+
+				return
+			default:
+				_ = tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+
+	return host, port, mails
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	t.Parallel()
+
+	host, port, mails := serveFakeSMTP(t)
+	sender := NewSMTPSender(host, port, "", "", "todo@example.com")
+
+	if err := sender.Send("abc@example.com", "[todo] Venue Reservation is due today", "Venue Reservation\nDeadline: 2022-01-26\n"); err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	mail := <-mails
+
+	assert.Exactly(t, "todo@example.com", mail.from)
+	assert.Exactly(t, []string{"abc@example.com"}, mail.to)
+
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(mail.data)))
+	header, err := r.ReadMIMEHeader()
+	assert.Nil(t, err)
+	assert.Exactly(t, "[todo] Venue Reservation is due today", header.Get("Subject"))
+	assert.Exactly(t, "abc@example.com", header.Get("To"))
+	assert.Contains(t, mail.data, "Deadline: 2022-01-26\n")
+}
+
+func TestSMTPSenderSendError(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+
+	sender := NewSMTPSender(host, port, "", "", "todo@example.com")
+
+	err = sender.Send("abc@example.com", "subject", "body")
+	assert.Contains(t, err.Error(), "failed to send mail")
+}
@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type AttachmentPersistence struct {
+	conn *gorm.DB
+}
+
+func NewAttachmentPersistence(conn *gorm.DB) repository.AttachmentRepository {
+	return &AttachmentPersistence{
+		conn,
+	}
+}
+
+func (ap *AttachmentPersistence) Create(a *model.Attachment) error {
+	if err := ap.conn.Create(&a).Error; err != nil {
+		return errors.Wrapf(err, "failed to create attachment. attachment: %+v", a)
+	}
+
+	return nil
+}
+
+func (ap *AttachmentPersistence) FindByID(id model.AttachmentID) (*model.Attachment, error) {
+	a := &model.Attachment{ID: id}
+
+	if err := ap.conn.First(&a).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find attachment. id: %+v", id)
+	}
+
+	return a, nil
+}
+
+func (ap *AttachmentPersistence) FindByTaskID(id model.TaskID) ([]*model.Attachment, error) {
+	var attachments []*model.Attachment
+	if err := ap.conn.Where("task_id = ?", id).Order("created_at, id").Find(&attachments).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find attachments. task id: %+v", id)
+	}
+
+	return attachments, nil
+}
+
+func (ap *AttachmentPersistence) Delete(id model.AttachmentID) error {
+	if err := ap.conn.Where("id = ?", id).Delete(&model.Attachment{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete attachment. id: %+v", id)
+	}
+
+	return nil
+}
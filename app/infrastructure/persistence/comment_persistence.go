@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type CommentPersistence struct {
+	conn *gorm.DB
+}
+
+func NewCommentPersistence(conn *gorm.DB) repository.CommentRepository {
+	return &CommentPersistence{
+		conn,
+	}
+}
+
+func (cp *CommentPersistence) Create(c *model.Comment) error {
+	if err := cp.conn.Create(&c).Error; err != nil {
+		return errors.Wrapf(err, "failed to create comment. comment: %+v", c)
+	}
+
+	return nil
+}
+
+func (cp *CommentPersistence) FindByID(id model.CommentID) (*model.Comment, error) {
+	c := &model.Comment{ID: id}
+
+	if err := cp.conn.First(&c).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find comment. id: %+v", id)
+	}
+
+	return c, nil
+}
+
+func (cp *CommentPersistence) FindByTaskID(id model.TaskID) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	if err := cp.conn.Where("task_id = ?", id).Order("created_at, id").Find(&comments).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find comments. task id: %+v", id)
+	}
+
+	return comments, nil
+}
+
+func (cp *CommentPersistence) Update(c *model.Comment) error {
+	return cp.conn.Save(&c).Error
+}
@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type DependencyPersistence struct {
+	conn *gorm.DB
+}
+
+func NewDependencyPersistence(conn *gorm.DB) repository.DependencyRepository {
+	return &DependencyPersistence{
+		conn,
+	}
+}
+
+func (dp *DependencyPersistence) Create(d *model.Dependency) error {
+	if err := dp.conn.Create(&d).Error; err != nil {
+		return errors.Wrapf(err, "failed to create dependency. dependency: %+v", d)
+	}
+
+	return nil
+}
+
+func (dp *DependencyPersistence) Delete(taskID, blockerID model.TaskID) error {
+	if err := dp.conn.Where("task_id = ? AND blocker_id = ?", taskID, blockerID).Delete(&model.Dependency{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete dependency. task id: %+v, blocker id: %+v", taskID, blockerID)
+	}
+
+	return nil
+}
+
+func (dp *DependencyPersistence) FindByWorkspaceID(id model.WorkspaceID) ([]*model.Dependency, error) {
+	var dependencies []*model.Dependency
+
+	err := dp.conn.Table("dependencies").
+		Select("dependencies.*").
+		Joins("JOIN tasks ON tasks.id = dependencies.task_id").
+		Where("tasks.workspace_id = ?", id).
+		Order("dependencies.created_at").
+		Scan(&dependencies).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find dependencies. workspace id: %+v", id)
+	}
+
+	return dependencies, nil
+}
+
+func (dp *DependencyPersistence) FindBlockers(taskIDs []model.TaskID) (map[model.TaskID][]*model.Task, error) {
+	blockers := make(map[model.TaskID][]*model.Task)
+	if len(taskIDs) == 0 {
+		return blockers, nil
+	}
+
+	var rows []struct {
+		DependentID model.TaskID
+		model.Task
+	}
+
+	err := dp.conn.Table("dependencies").
+		Select("dependencies.task_id AS dependent_id, tasks.*").
+		Joins("JOIN tasks ON tasks.id = dependencies.blocker_id").
+		Where("dependencies.task_id IN ?", taskIDs).
+		Order("tasks.deadline, tasks.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find blockers. task ids: %+v", taskIDs)
+	}
+
+	for _, r := range rows {
+		t := r.Task
+		blockers[r.DependentID] = append(blockers[r.DependentID], &t)
+	}
+
+	return blockers, nil
+}
+
+func (dp *DependencyPersistence) FindBlocking(id model.TaskID) ([]*model.Task, error) {
+	var tasks []*model.Task
+
+	err := dp.conn.
+		Joins("JOIN dependencies ON dependencies.task_id = tasks.id").
+		Where("dependencies.blocker_id = ? AND tasks.trashed_at IS NULL", id).
+		Order("tasks.deadline, tasks.id").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find blocked tasks. blocker id: %+v", id)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// HistoryPersistence stores task histories in task_histories.
+// The changes are kept as a JSON array, since they are always read together with the history.
+type HistoryPersistence struct {
+	conn *gorm.DB
+}
+
+type taskHistory struct {
+	ID        model.HistoryID
+	TaskID    model.TaskID
+	UserID    model.UserID
+	Action    model.HistoryAction
+	Changes   string
+	CreatedAt time.Time
+}
+
+type fieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func NewHistoryPersistence(conn *gorm.DB) repository.HistoryRepository {
+	return &HistoryPersistence{
+		conn,
+	}
+}
+
+// createHistories stores the histories on the connection, so that they can be stored in the transaction of the change
+// which they record. Nil histories, i.e. changes with nothing to record, are skipped.
+func createHistories(db *gorm.DB, histories ...*model.TaskHistory) error {
+	for _, h := range histories {
+		if h == nil {
+			continue
+		}
+
+		changes := make([]fieldChange, 0, len(h.Changes))
+		for _, c := range h.Changes {
+			changes = append(changes, fieldChange{Field: c.Field, Before: c.Before, After: c.After})
+		}
+
+		b, err := json.Marshal(changes)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode changes. history: %+v", h)
+		}
+
+		row := &taskHistory{
+			ID:        h.ID,
+			TaskID:    h.TaskID,
+			UserID:    h.UserID,
+			Action:    h.Action,
+			Changes:   string(b),
+			CreatedAt: h.CreatedAt,
+		}
+
+		if err := db.Create(row).Error; err != nil {
+			return errors.Wrapf(err, "failed to create history. history: %+v", h)
+		}
+	}
+
+	return nil
+}
+
+func (hp *HistoryPersistence) FindByTaskID(id model.TaskID) ([]*model.TaskHistory, error) {
+	var rows []*taskHistory
+	if err := hp.conn.Where("task_id = ?", id).Order("created_at, id").Find(&rows).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find histories. task id: %+v", id)
+	}
+
+	return toHistories(rows)
+}
+
+func (hp *HistoryPersistence) FindByUserID(id model.UserID, limit int) ([]*model.TaskHistory, error) {
+	var rows []*taskHistory
+	if err := hp.conn.Where("user_id = ?", id).Order("created_at DESC, id DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find histories. user id: %+v", id)
+	}
+
+	return toHistories(rows)
+}
+
+func toHistories(rows []*taskHistory) ([]*model.TaskHistory, error) {
+	histories := make([]*model.TaskHistory, 0, len(rows))
+
+	for _, r := range rows {
+		var changes []fieldChange
+		if err := json.Unmarshal([]byte(r.Changes), &changes); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode changes. history id: %s", r.ID)
+		}
+
+		h := &model.TaskHistory{
+			ID:        r.ID,
+			TaskID:    r.TaskID,
+			UserID:    r.UserID,
+			Action:    r.Action,
+			Changes:   make([]model.FieldChange, 0, len(changes)),
+			CreatedAt: r.CreatedAt,
+		}
+
+		for _, c := range changes {
+			h.Changes = append(h.Changes, model.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+		}
+
+		histories = append(histories, h)
+	}
+
+	return histories, nil
+}
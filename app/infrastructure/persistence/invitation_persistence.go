@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type InvitationPersistence struct {
+	conn *gorm.DB
+}
+
+func NewInvitationPersistence(conn *gorm.DB) repository.InvitationRepository {
+	return &InvitationPersistence{
+		conn,
+	}
+}
+
+func (ip *InvitationPersistence) Create(i *model.Invitation) error {
+	if err := ip.conn.Create(&i).Error; err != nil {
+		return errors.Wrapf(err, "failed to create invitation. workspace id: %+v", i.WorkspaceID)
+	}
+
+	return nil
+}
+
+func (ip *InvitationPersistence) FindByToken(token string) (*model.Invitation, error) {
+	i := &model.Invitation{}
+
+	if err := ip.conn.Where("token = ?", token).First(&i).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find invitation by token")
+	}
+
+	return i, nil
+}
+
+func (ip *InvitationPersistence) FindPendingByWorkspaceID(id model.WorkspaceID, now time.Time) ([]*model.Invitation, error) {
+	var invitations []*model.Invitation
+	if err := ip.conn.Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", id, now).Order("created_at DESC, id DESC").Find(&invitations).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find pending invitations. workspace id: %+v", id)
+	}
+
+	return invitations, nil
+}
+
+func (ip *InvitationPersistence) Accept(i *model.Invitation, m *model.Member) error {
+	err := ip.conn.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Invitation{}).Where("id = ? AND accepted_at IS NULL", i.ID).
+			Updates(map[string]interface{}{"accepted_by": i.AcceptedBy, "accepted_at": i.AcceptedAt})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return errors.Wrapf(repository.ErrVersionConflict, "invitation has been accepted. id: %s", i.ID)
+		}
+
+		return tx.Create(&m).Error
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return err
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to accept invitation. id: %+v", i.ID)
+	}
+
+	return nil
+}
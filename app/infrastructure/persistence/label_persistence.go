@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LabelPersistence struct {
+	conn *gorm.DB
+}
+
+type taskLabel struct {
+	TaskID  model.TaskID
+	LabelID model.LabelID
+}
+
+func NewLabelPersistence(conn *gorm.DB) repository.LabelRepository {
+	return &LabelPersistence{
+		conn,
+	}
+}
+
+func (lp *LabelPersistence) Create(label *model.Label) error {
+	if err := lp.conn.Create(&label).Error; err != nil {
+		return errors.Wrapf(err, "failed to create label. label: %+v", label)
+	}
+
+	return nil
+}
+
+func (lp *LabelPersistence) FindByID(id model.LabelID) (*model.Label, error) {
+	l := &model.Label{ID: id}
+
+	if err := lp.conn.First(&l).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find label. id: %+v", id)
+	}
+
+	return l, nil
+}
+
+func (lp *LabelPersistence) FindByUserID(id model.UserID) ([]*model.Label, error) {
+	var labels []*model.Label
+	if err := lp.conn.Where("user_id = ?", id).Order("name").Find(&labels).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find labels. user id: %+v", id)
+	}
+
+	return labels, nil
+}
+
+func (lp *LabelPersistence) Update(l *model.Label) error {
+	return lp.conn.Save(&l).Error
+}
+
+func (lp *LabelPersistence) Delete(id model.LabelID) error {
+	err := lp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", id).Delete(&taskLabel{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&model.Label{}).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete label. id: %+v", id)
+	}
+
+	return nil
+}
+
+func (lp *LabelPersistence) FindByTaskIDs(userID model.UserID, taskIDs []model.TaskID) (map[model.TaskID][]*model.Label, error) {
+	labels := make(map[model.TaskID][]*model.Label)
+	if len(taskIDs) == 0 {
+		return labels, nil
+	}
+
+	var rows []struct {
+		TaskID model.TaskID
+		model.Label
+	}
+
+	err := lp.conn.Table("task_labels").
+		Select("task_labels.task_id, labels.*").
+		Joins("JOIN labels ON labels.id = task_labels.label_id").
+		Where("task_labels.task_id IN ? AND labels.user_id = ?", taskIDs, userID).
+		Order("labels.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find labels of tasks. user id: %+v", userID)
+	}
+
+	for _, r := range rows {
+		l := r.Label
+		labels[r.TaskID] = append(labels[r.TaskID], &l)
+	}
+
+	return labels, nil
+}
+
+func (lp *LabelPersistence) UpdateTaskLabels(taskID model.TaskID, userID model.UserID, labelIDs []model.LabelID) error {
+	err := lp.conn.Transaction(func(tx *gorm.DB) error {
+		owned := tx.Model(&model.Label{}).Select("id").Where("user_id = ?", userID)
+
+		if err := tx.Where("task_id = ? AND label_id IN (?)", taskID, owned).Delete(&taskLabel{}).Error; err != nil {
+			return err
+		}
+
+		if len(labelIDs) == 0 {
+			return nil
+		}
+
+		taskLabels := make([]*taskLabel, 0, len(labelIDs))
+		for _, labelID := range labelIDs {
+			taskLabels = append(taskLabels, &taskLabel{TaskID: taskID, LabelID: labelID})
+		}
+
+		return tx.Create(&taskLabels).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update labels of task. task id: %+v", taskID)
+	}
+
+	return nil
+}
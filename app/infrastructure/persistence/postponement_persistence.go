@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type PostponementPersistence struct {
+	conn *gorm.DB
+}
+
+func NewPostponementPersistence(conn *gorm.DB) repository.PostponementRepository {
+	return &PostponementPersistence{
+		conn,
+	}
+}
+
+func (pp *PostponementPersistence) Create(p *model.Postponement) error {
+	if err := pp.conn.Create(&p).Error; err != nil {
+		return errors.Wrapf(err, "failed to create postponement. postponement: %+v", p)
+	}
+
+	return nil
+}
+
+func (pp *PostponementPersistence) FindByID(id model.PostponementID) (*model.Postponement, error) {
+	p := &model.Postponement{ID: id}
+
+	if err := pp.conn.First(&p).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find postponement. id: %+v", id)
+	}
+
+	return p, nil
+}
+
+func (pp *PostponementPersistence) FindByTaskID(id model.TaskID) ([]*model.Postponement, error) {
+	var postponements []*model.Postponement
+	if err := pp.conn.Where("task_id = ?", id).Order("created_at DESC, id DESC").Find(&postponements).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find postponements. task id: %+v", id)
+	}
+
+	return postponements, nil
+}
+
+func (pp *PostponementPersistence) FindPendingByApproverID(id model.UserID) ([]*model.Postponement, error) {
+	var postponements []*model.Postponement
+	if err := pp.conn.Where("approver_id = ? AND status = ?", id, model.Pending).Order("created_at, id").Find(&postponements).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find pending postponements. approver id: %+v", id)
+	}
+
+	return postponements, nil
+}
+
+func (pp *PostponementPersistence) Decide(p *model.Postponement, t *model.Task, h *model.TaskHistory) error {
+	err := pp.conn.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(p).Where("status = ?", model.Pending).Select("*").Updates(p)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return errors.Wrapf(repository.ErrVersionConflict, "postponement has been decided. id: %s", p.ID)
+		}
+
+		if t == nil {
+			return nil
+		}
+
+		if err := updateVersioned(tx, t); err != nil {
+			return err
+		}
+
+		return createHistories(tx, h)
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return err
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to decide postponement. id: %+v", p.ID)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"time"
 	"todo-app/domain/model"
 	"todo-app/domain/repository"
 
@@ -12,39 +13,399 @@ type TaskPersistence struct {
 	conn *gorm.DB
 }
 
+type taskShare struct {
+	TaskID model.TaskID
+	UserID model.UserID
+}
+
 func NewTaskPersistence(conn *gorm.DB) repository.TaskRepository {
 	return &TaskPersistence{
 		conn,
 	}
 }
 
-func (tp *TaskPersistence) Create(task *model.Task) error {
-	if err := tp.conn.Create(&task).Error; err != nil {
+func (tp *TaskPersistence) Create(task *model.Task, h *model.TaskHistory) error {
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+
+		return createHistories(tx, h)
+	})
+	if err != nil {
 		return errors.Wrapf(err, "failed to create task. task: %+v", &task)
 	}
 
 	return nil
 }
 
+func (tp *TaskPersistence) Import(tasks []*model.Task, labels []*model.Label, taskLabels map[model.TaskID][]model.LabelID, histories []*model.TaskHistory) error {
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		for _, t := range tasks {
+			if err := tx.Create(t).Error; err != nil {
+				return errors.Wrapf(err, "failed to create task. taskID: %s", t.ID)
+			}
+		}
+
+		if err := createHistories(tx, histories...); err != nil {
+			return err
+		}
+
+		for _, l := range labels {
+			if err := tx.Create(l).Error; err != nil {
+				return errors.Wrapf(err, "failed to create label. name: %s", l.Name)
+			}
+		}
+
+		var rows []*taskLabel
+
+		for _, t := range tasks {
+			for _, labelID := range taskLabels[t.ID] {
+				rows = append(rows, &taskLabel{TaskID: t.ID, LabelID: labelID})
+			}
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to import tasks. count: %d", len(tasks))
+	}
+
+	return nil
+}
+
 func (tp *TaskPersistence) FindByID(id model.TaskID) (*model.Task, error) {
 	t := &model.Task{ID: id}
 
-	if err := tp.conn.First(&t).Error; err != nil {
+	if err := tp.conn.First(&t).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
 		return nil, errors.Wrapf(err, "failed to find task. id: %+v", id)
 	}
 
 	return t, nil
 }
 
-func (tp *TaskPersistence) FindAll() ([]*model.Task, error) {
+func (tp *TaskPersistence) FindByParentID(id model.TaskID) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if err := tp.conn.Where("parent_id = ? AND trashed_at IS NULL", id).Order("deadline").Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find subtasks. parent id: %+v", id)
+	}
+
+	return tasks, nil
+}
+
+func (tp *TaskPersistence) FindTrashedByParentID(id model.TaskID) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if err := tp.conn.Where("parent_id = ? AND trashed_at IS NOT NULL", id).Order("deadline").Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find trashed subtasks. parent id: %+v", id)
+	}
+
+	return tasks, nil
+}
+
+func (tp *TaskPersistence) FindArchivedByUserID(id model.UserID) ([]*model.Task, error) {
 	var tasks []*model.Task
-	if err := tp.conn.Find(&tasks).Error; err != nil {
-		return nil, errors.Wrapf(err, "failed to find all tasks")
+	if err := tp.conn.Where("user_id = ? AND archived_at IS NOT NULL AND trashed_at IS NULL", id).Order("archived_at DESC").Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find archived tasks. user id: %+v", id)
 	}
 
 	return tasks, nil
 }
 
-func (tp *TaskPersistence) Update(t *model.Task) error {
-	return tp.conn.Save(&t).Error
+func (tp *TaskPersistence) FindTrashedByUserID(id model.UserID) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if err := tp.conn.Where("user_id = ? AND trashed_at IS NOT NULL", id).Order("trashed_at DESC").Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find trashed tasks. user id: %+v", id)
+	}
+
+	return tasks, nil
+}
+
+func (tp *TaskPersistence) FindByShareToken(token string) (*model.Task, error) {
+	t := &model.Task{}
+
+	if err := tp.conn.Where("share_token = ? AND trashed_at IS NULL", token).First(&t).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find task by share token")
+	}
+
+	return t, nil
+}
+
+func (tp *TaskPersistence) FindByCalendarName(userID model.UserID, name string) (*model.Task, error) {
+	t := &model.Task{}
+
+	if err := tp.conn.Where("assignee_id = ? AND calendar_name = ? AND trashed_at IS NULL", userID, name).First(&t).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find task by calendar name. user id: %+v, name: %s", userID, name)
+	}
+
+	return t, nil
+}
+
+func (tp *TaskPersistence) FindByStatus(status model.Status) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if err := tp.conn.Where("status = ? AND trashed_at IS NULL", status).Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find tasks. status: %+v", status)
+	}
+
+	return tasks, nil
+}
+
+func (tp *TaskPersistence) Update(t *model.Task, h *model.TaskHistory) error {
+	if h == nil {
+		return updateVersioned(tp.conn, t)
+	}
+
+	return tp.UpdateAll([]*model.Task{t}, []*model.TaskHistory{h})
+}
+
+func (tp *TaskPersistence) UpdateAll(tasks []*model.Task, histories []*model.TaskHistory) error {
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		for _, t := range tasks {
+			if err := updateVersioned(tx, t); err != nil {
+				return err
+			}
+		}
+
+		return createHistories(tx, histories...)
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return err
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to update tasks")
+	}
+
+	return nil
+}
+
+func (tp *TaskPersistence) Complete(tasks []*model.Task, nexts map[model.TaskID]*model.Task, histories []*model.TaskHistory) error {
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		for _, t := range tasks {
+			if err := updateVersioned(tx, t); err != nil {
+				return err
+			}
+
+			next, ok := nexts[t.ID]
+			if !ok {
+				continue
+			}
+
+			if err := tx.Create(next).Error; err != nil {
+				return errors.Wrapf(err, "failed to create next occurrence. taskID: %s", t.ID)
+			}
+
+			if next.Visibility == model.Private {
+				continue
+			}
+
+			var shares []*taskShare
+			if err := tx.Where("task_id = ?", t.ID).Find(&shares).Error; err != nil {
+				return errors.Wrapf(err, "failed to find shared users. taskID: %s", t.ID)
+			} else if len(shares) == 0 {
+				continue
+			}
+
+			for _, share := range shares {
+				share.TaskID = next.ID
+			}
+
+			if err := tx.Create(&shares).Error; err != nil {
+				return errors.Wrapf(err, "failed to share next occurrence. taskID: %s", next.ID)
+			}
+		}
+
+		return createHistories(tx, histories...)
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return err
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to complete tasks")
+	}
+
+	return nil
+}
+
+// updateVersioned stores all the fields of the task with the next version, unless the task has been updated
+// since it was fetched with its current version.
+func updateVersioned(db *gorm.DB, t *model.Task) error {
+	next := *t
+	next.Version++
+
+	result := db.Model(&next).Where("version = ?", t.Version).Select("*").Updates(&next)
+	if result.Error != nil {
+		return errors.Wrapf(result.Error, "failed to update task. taskID: %s", t.ID)
+	} else if result.RowsAffected == 0 {
+		return errors.Wrapf(repository.ErrVersionConflict, "task has been updated or deleted. taskID: %s, version: %d", t.ID, t.Version)
+	}
+
+	t.Version = next.Version
+
+	return nil
+}
+
+func (tp *TaskPersistence) Delete(id model.TaskID) ([]*model.Attachment, error) {
+	var attachments []*model.Attachment
+
+	if err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		var err error
+		_, attachments, err = deleteTasks(tx, []model.TaskID{id})
+
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete task. id: %+v", id)
+	}
+
+	return attachments, nil
+}
+
+func (tp *TaskPersistence) DeleteTrashedBefore(before time.Time) (int, []*model.Attachment, error) {
+	var (
+		ids         []model.TaskID
+		count       int
+		attachments []*model.Attachment
+	)
+
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Task{}).Where("trashed_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		var err error
+		count, attachments, err = deleteTasks(tx, ids)
+
+		return err
+	})
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to delete trashed tasks. before: %+v", before)
+	}
+
+	return count, attachments, nil
+}
+
+// deleteTasks deletes the tasks with all their subtasks and the rows which refer to them, and returns the number of the deleted
+// tasks and their attachments.
+// INFO: the subtasks are deleted rather than left to the foreign key, which would make them top-level tasks
+func deleteTasks(tx *gorm.DB, ids []model.TaskID) (int, []*model.Attachment, error) {
+	ids, err := withDescendants(tx, ids)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var attachments []*model.Attachment
+	if err := tx.Where("task_id IN ?", ids).Find(&attachments).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ?", ids).Delete(&taskShare{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ?", ids).Delete(&taskLabel{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ?", ids).Delete(&model.Postponement{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ?", ids).Delete(&model.Comment{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ?", ids).Delete(&model.Attachment{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("task_id IN ? OR blocker_id IN ?", ids, ids).Delete(&model.Dependency{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	if err := tx.Where("id IN ?", ids).Delete(&model.Task{}).Error; err != nil {
+		return 0, nil, err
+	}
+
+	return len(ids), attachments, nil
+}
+
+// withDescendants adds the subtasks at any depth to the tasks, which are found level by level since MySQL 5.7 has no recursive queries.
+func withDescendants(tx *gorm.DB, ids []model.TaskID) ([]model.TaskID, error) {
+	seen := make(map[model.TaskID]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+
+	all := append([]model.TaskID(nil), ids...)
+
+	for level := ids; len(level) > 0; {
+		var children []model.TaskID
+		if err := tx.Model(&model.Task{}).Where("parent_id IN ?", level).Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+
+		level = nil
+
+		for _, id := range children {
+			if !seen[id] {
+				seen[id] = true
+				level = append(level, id)
+			}
+		}
+
+		all = append(all, level...)
+	}
+
+	return all, nil
+}
+
+func (tp *TaskPersistence) FindSharedUserIDs(id model.TaskID) ([]model.UserID, error) {
+	var userIDs []model.UserID
+	if err := tp.conn.Model(&taskShare{}).Where("task_id = ?", id).Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find shared users. task id: %+v", id)
+	}
+
+	return userIDs, nil
+}
+
+func (tp *TaskPersistence) Share(t *model.Task, userIDs []model.UserID, h *model.TaskHistory) error {
+	err := tp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, t); err != nil {
+			return err
+		}
+
+		if err := tx.Where("task_id = ?", t.ID).Delete(&taskShare{}).Error; err != nil {
+			return err
+		}
+
+		if len(userIDs) > 0 {
+			shares := make([]*taskShare, 0, len(userIDs))
+			for _, userID := range userIDs {
+				shares = append(shares, &taskShare{TaskID: t.ID, UserID: userID})
+			}
+
+			if err := tx.Create(&shares).Error; err != nil {
+				return err
+			}
+		}
+
+		return createHistories(tx, h)
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return err
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to share task. task id: %+v", t.ID)
+	}
+
+	return nil
 }
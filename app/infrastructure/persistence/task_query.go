@@ -0,0 +1,247 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var sortColumns = map[model.SortKey]string{
+	model.SortByDeadline:  "deadline",
+	model.SortByPriority:  "priority",
+	model.SortByStatus:    fmt.Sprintf("CASE status WHEN %d THEN 0 WHEN %d THEN 1 ELSE 2 END", model.Behind, model.Working),
+	model.SortByCreatedAt: "created_at",
+	model.SortByName:      "name",
+}
+
+// taskCursor is the position after the last task of a page.
+// It holds the values of the ORDER BY columns of the task, so that the next page starts right after it
+// even when tasks are added or removed in the meantime.
+type taskCursor struct {
+	Sort     string    `json:"s"`
+	Value    string    `json:"v"`
+	Deadline time.Time `json:"d"`
+	ID       string    `json:"i"`
+}
+
+func (tp *TaskPersistence) Find(q model.TaskQuery) (*model.TaskPage, error) {
+	db, err := tp.query(q)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := q.PageSize()
+
+	var tasks []*model.Task
+
+	// INFO: fetch one more task to know whether there is a next page
+	if err := db.Order(orderBy(q.Sort)).Limit(limit + 1).Find(&tasks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find tasks. query: %+v", q)
+	}
+
+	page := &model.TaskPage{Tasks: tasks}
+
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+
+		page.NextCursor, err = encodeCursor(q.Sort, page.Tasks[limit-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// visibleTasks selects the tasks in the workspaces of the viewer and the ones owned by, assigned to or shared with
+// the viewer, excluding archived and trashed ones.
+func visibleTasks(conn *gorm.DB, viewerID model.UserID) *gorm.DB {
+	workspaces := conn.Model(&model.Member{}).Select("workspace_id").Where("user_id = ?", viewerID)
+	shared := conn.Model(&taskShare{}).Select("task_id").Where("user_id = ?", viewerID)
+
+	return conn.Where("archived_at IS NULL AND trashed_at IS NULL").
+		Where(conn.Where("workspace_id IN (?)", workspaces).
+			Or("user_id = ? OR assignee_id = ?", viewerID, viewerID).
+			Or("visibility <> ? AND id IN (?)", model.Private, shared))
+}
+
+func (tp *TaskPersistence) query(q model.TaskQuery) (*gorm.DB, error) {
+	db := visibleTasks(tp.conn, q.ViewerID)
+
+	if q.OwnerID != "" {
+		db = db.Where("user_id = ?", q.OwnerID)
+	}
+
+	if q.AssigneeID != "" {
+		db = db.Where("assignee_id = ?", q.AssigneeID)
+	}
+
+	if q.WorkspaceID != "" {
+		db = db.Where("workspace_id = ?", q.WorkspaceID)
+	}
+
+	if len(q.Statuses) > 0 {
+		db = db.Where("status IN ?", q.Statuses)
+	}
+
+	if q.DeadlineFrom != nil {
+		db = db.Where("deadline >= ?", *q.DeadlineFrom)
+	}
+
+	if q.DeadlineTo != nil {
+		db = db.Where("deadline <= ?", *q.DeadlineTo)
+	}
+
+	if q.Text != "" {
+		pattern := "%" + escapeLike(q.Text) + "%"
+		db = db.Where("(name LIKE ? OR detail LIKE ?)", pattern, pattern)
+	}
+
+	if len(q.LabelIDs) > 0 {
+		labeled := tp.conn.Model(&taskLabel{}).Select("task_id").Where("label_id IN ?", q.LabelIDs).Group("task_id")
+		if q.LabelMatch == model.MatchAll {
+			labeled = labeled.Having("COUNT(DISTINCT label_id) = ?", len(q.LabelIDs))
+		}
+
+		db = db.Where("id IN (?)", labeled)
+	}
+
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Sort, q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := cursorValue(q.Sort.Key, c.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		db = db.Where(after(q.Sort), map[string]interface{}{"v": value, "d": c.Deadline, "i": c.ID})
+	}
+
+	return db, nil
+}
+
+// orderBy returns the ORDER BY clause of the sort.
+// Ties are broken by deadline and id, so that the order is total and pages neither overlap nor skip tasks.
+func orderBy(sort model.TaskSort) string {
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, deadline ASC, id ASC", sortColumn(sort.Key), direction)
+}
+
+// after returns the condition which selects the tasks ordered after the cursor,
+// whose sort value, deadline and id are bound to @v, @d and @i.
+func after(sort model.TaskSort) string {
+	op := ">"
+	if sort.Desc {
+		op = "<"
+	}
+
+	column := sortColumn(sort.Key)
+
+	return fmt.Sprintf("(%[1]s %[2]s @v OR (%[1]s = @v AND (deadline > @d OR (deadline = @d AND id > @i))))", column, op)
+}
+
+func sortColumn(key model.SortKey) string {
+	if column, ok := sortColumns[key]; ok {
+		return column
+	}
+
+	return sortColumns[model.SortByDeadline]
+}
+
+func encodeCursor(sort model.TaskSort, t *model.Task) (string, error) {
+	c := taskCursor{
+		Sort:     sort.String(),
+		Deadline: t.Deadline,
+		ID:       string(t.ID),
+	}
+
+	switch sort.Key {
+	case model.SortByPriority:
+		c.Value = strconv.Itoa(int(t.Priority))
+	case model.SortByStatus:
+		c.Value = strconv.Itoa(statusRank(t.Status))
+	case model.SortByCreatedAt:
+		c.Value = t.CreatedAt.Format(time.RFC3339Nano)
+	case model.SortByName:
+		c.Value = t.Name
+	default:
+		c.Value = t.Deadline.Format(time.RFC3339Nano)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode cursor")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(sort model.TaskSort, cursor string) (*taskCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrapf(repository.ErrInvalidCursor, "cursor is not base64: %s", cursor)
+	}
+
+	var c taskCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.Wrapf(repository.ErrInvalidCursor, "cursor is not json: %s", cursor)
+	}
+
+	if c.Sort != sort.String() {
+		return nil, errors.Wrapf(repository.ErrInvalidCursor, "cursor is issued for sort %s, not %s", c.Sort, sort)
+	}
+
+	return &c, nil
+}
+
+func cursorValue(key model.SortKey, value string) (interface{}, error) {
+	switch key {
+	case model.SortByPriority, model.SortByStatus:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Wrapf(repository.ErrInvalidCursor, "cursor value is not a number: %s", value)
+		}
+
+		return n, nil
+	case model.SortByName:
+		return value, nil
+	default:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Wrapf(repository.ErrInvalidCursor, "cursor value is not a time: %s", value)
+		}
+
+		return t, nil
+	}
+}
+
+// statusRank mirrors the CASE expression of SortByStatus.
+func statusRank(s model.Status) int {
+	switch s {
+	case model.Behind:
+		return 0
+	case model.Working:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
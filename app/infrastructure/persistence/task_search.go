@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"strings"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// TaskSearchPersistence searches tasks with the FULLTEXT index on their names and details.
+// The index uses the ngram parser, so that terms match parts of words and texts without spaces.
+type TaskSearchPersistence struct {
+	conn *gorm.DB
+}
+
+type taskSearchRow struct {
+	model.Task `gorm:"embedded"`
+	Score      float64
+}
+
+func NewTaskSearchPersistence(conn *gorm.DB) repository.TaskSearcher {
+	return &TaskSearchPersistence{
+		conn,
+	}
+}
+
+func (sp *TaskSearchPersistence) Search(s model.TaskSearch) ([]*model.TaskHit, error) {
+	against := booleanQuery(s.Terms())
+	if against == "" {
+		return nil, nil
+	}
+
+	match := "MATCH (name, detail) AGAINST (? IN BOOLEAN MODE)"
+
+	var rows []*taskSearchRow
+
+	if err := visibleTasks(sp.conn, s.ViewerID).Table("tasks").
+		Select("tasks.*, "+match+" AS score", against).
+		Where(match, against).
+		Order("score DESC, deadline ASC, id ASC").
+		Limit(s.PageSize()).
+		Find(&rows).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to search tasks. search: %+v", s)
+	}
+
+	hits := make([]*model.TaskHit, 0, len(rows))
+	for _, r := range rows {
+		t := r.Task
+		hits = append(hits, &model.TaskHit{Task: &t, Score: r.Score})
+	}
+
+	return hits, nil
+}
+
+// booleanQuery requires every term as a phrase, dropping the operators of the boolean mode from the terms.
+func booleanQuery(terms []string) string {
+	var b strings.Builder
+
+	for _, term := range terms {
+		term = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`+-<>()~*"@`, r) {
+				return -1
+			}
+
+			return r
+		}, term)
+
+		if term == "" {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+
+		b.WriteString(`+"` + term + `"`)
+	}
+
+	return b.String()
+}
@@ -18,14 +18,37 @@ func NewUserPersistence(conn *gorm.DB) repository.UserRepository {
 	}
 }
 
-func (up *UserPersistence) Create(user *model.User) error {
-	if err := up.conn.Create(&user).Error; err != nil {
+func (up *UserPersistence) Create(user *model.User, w *model.Workspace, m *model.Member) error {
+	err := up.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&w).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&m).Error
+	})
+	if err != nil {
 		return errors.Wrapf(err, "failed to create user. user email: %+v", &user.Email)
 	}
 
 	return nil
 }
 
+func (up *UserPersistence) FindByID(id model.UserID) (*model.User, error) {
+	u := &model.User{ID: id}
+
+	if err := up.conn.Where(&u).First(&u).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find user. user id: %+v", id)
+	}
+
+	return u, nil
+}
+
 func (up *UserPersistence) FindByEmail(email model.Email) (*model.User, error) {
 	t := &model.User{Email: email}
 
@@ -37,3 +60,28 @@ func (up *UserPersistence) FindByEmail(email model.Email) (*model.User, error) {
 
 	return t, nil
 }
+
+func (up *UserPersistence) FindByCalendarToken(token string) (*model.User, error) {
+	u := &model.User{}
+
+	if err := up.conn.Where("calendar_token = ?", token).First(&u).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to find user by calendar token")
+	}
+
+	return u, nil
+}
+
+func (up *UserPersistence) Update(user *model.User) error {
+	columns := map[string]interface{}{
+		"time_zone":      user.TimeZone,
+		"calendar_token": user.CalendarToken,
+	}
+
+	if err := up.conn.Model(&model.User{}).Where("id = ?", user.ID).Updates(columns).Error; err != nil {
+		return errors.Wrapf(err, "failed to update user. user id: %+v", user.ID)
+	}
+
+	return nil
+}
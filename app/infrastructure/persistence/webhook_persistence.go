@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type WebhookPersistence struct {
+	conn *gorm.DB
+}
+
+func NewWebhookPersistence(conn *gorm.DB) repository.WebhookRepository {
+	return &WebhookPersistence{
+		conn,
+	}
+}
+
+func (wp *WebhookPersistence) Create(w *model.Webhook) error {
+	if err := wp.conn.Create(&w).Error; err != nil {
+		return errors.Wrapf(err, "failed to create webhook. webhook id: %+v", w.ID)
+	}
+
+	return nil
+}
+
+func (wp *WebhookPersistence) Update(w *model.Webhook) error {
+	if err := wp.conn.Save(&w).Error; err != nil {
+		return errors.Wrapf(err, "failed to update webhook. webhook id: %+v", w.ID)
+	}
+
+	return nil
+}
+
+func (wp *WebhookPersistence) Delete(id model.WebhookID) error {
+	err := wp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&model.Webhook{}).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete webhook. id: %+v", id)
+	}
+
+	return nil
+}
+
+func (wp *WebhookPersistence) FindByID(id model.WebhookID) (*model.Webhook, error) {
+	w := &model.Webhook{ID: id}
+
+	if err := wp.conn.First(&w).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find webhook. id: %+v", id)
+	}
+
+	return w, nil
+}
+
+func (wp *WebhookPersistence) FindByWorkspaceID(id model.WorkspaceID) ([]*model.Webhook, error) {
+	var webhooks []*model.Webhook
+	if err := wp.conn.Where("workspace_id = ?", id).Order("created_at, id").Find(&webhooks).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find webhooks. workspace id: %+v", id)
+	}
+
+	return webhooks, nil
+}
+
+func (wp *WebhookPersistence) CreateDeliveries(deliveries []*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := wp.conn.Create(&deliveries).Error; err != nil {
+		return errors.Wrapf(err, "failed to create webhook deliveries. count: %d", len(deliveries))
+	}
+
+	return nil
+}
+
+func (wp *WebhookPersistence) UpdateDelivery(d *model.WebhookDelivery) error {
+	if err := wp.conn.Save(&d).Error; err != nil {
+		return errors.Wrapf(err, "failed to update webhook delivery. id: %+v", d.ID)
+	}
+
+	return nil
+}
+
+func (wp *WebhookPersistence) FindDeliveries(id model.WebhookID, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	if err := wp.conn.Where("webhook_id = ?", id).Order("created_at DESC, id").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find webhook deliveries. webhook id: %+v", id)
+	}
+
+	return deliveries, nil
+}
+
+func (wp *WebhookPersistence) FindDueDeliveries(now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+
+	err := wp.conn.
+		Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+		Order("next_attempt_at, created_at, id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find due webhook deliveries. now: %+v", now)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type WorkspacePersistence struct {
+	conn *gorm.DB
+}
+
+func NewWorkspacePersistence(conn *gorm.DB) repository.WorkspaceRepository {
+	return &WorkspacePersistence{
+		conn,
+	}
+}
+
+func (wp *WorkspacePersistence) Create(w *model.Workspace, m *model.Member) error {
+	err := wp.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&w).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&m).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create workspace. workspace: %+v", w)
+	}
+
+	return nil
+}
+
+func (wp *WorkspacePersistence) FindByID(id model.WorkspaceID) (*model.Workspace, error) {
+	w := &model.Workspace{ID: id}
+
+	if err := wp.conn.First(&w).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find workspace. id: %+v", id)
+	}
+
+	return w, nil
+}
+
+func (wp *WorkspacePersistence) FindByUserID(id model.UserID) ([]*model.Workspace, error) {
+	members := wp.conn.Model(&model.Member{}).Select("workspace_id").Where("user_id = ?", id)
+
+	var workspaces []*model.Workspace
+	if err := wp.conn.Where("id IN (?)", members).Order("name, id").Find(&workspaces).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find workspaces. user id: %+v", id)
+	}
+
+	return workspaces, nil
+}
+
+func (wp *WorkspacePersistence) FindMember(workspaceID model.WorkspaceID, userID model.UserID) (*model.Member, error) {
+	m := &model.Member{}
+
+	if err := wp.conn.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&m).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member. workspace id: %+v, user id: %+v", workspaceID, userID)
+	}
+
+	return m, nil
+}
+
+func (wp *WorkspacePersistence) FindMembers(id model.WorkspaceID) ([]*model.Member, error) {
+	var members []*model.Member
+	if err := wp.conn.Where("workspace_id = ?", id).Order("created_at, user_id").Find(&members).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find members. workspace id: %+v", id)
+	}
+
+	return members, nil
+}
+
+func (wp *WorkspacePersistence) UpdateMember(m *model.Member) error {
+	if err := wp.conn.Model(&model.Member{}).Where("workspace_id = ? AND user_id = ?", m.WorkspaceID, m.UserID).Update("role", m.Role).Error; err != nil {
+		return errors.Wrapf(err, "failed to update member. member: %+v", m)
+	}
+
+	return nil
+}
+
+func (wp *WorkspacePersistence) DeleteMember(workspaceID model.WorkspaceID, userID model.UserID) error {
+	if err := wp.conn.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&model.Member{}).Error; err != nil {
+		return errors.Wrapf(err, "failed to delete member. workspace id: %+v, user id: %+v", workspaceID, userID)
+	}
+
+	return nil
+}
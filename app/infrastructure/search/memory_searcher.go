@@ -0,0 +1,123 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+)
+
+// MemorySearcher searches the tasks added to it without a database.
+// Like the FULLTEXT index, terms match parts of words case-insensitively, and matches in the name count double.
+type MemorySearcher struct {
+	mu      sync.RWMutex
+	tasks   map[model.TaskID]*model.Task
+	shares  map[model.TaskID]map[model.UserID]bool
+	members map[model.WorkspaceID]map[model.UserID]bool
+}
+
+func NewMemorySearcher() *MemorySearcher {
+	return &MemorySearcher{
+		tasks:   map[model.TaskID]*model.Task{},
+		shares:  map[model.TaskID]map[model.UserID]bool{},
+		members: map[model.WorkspaceID]map[model.UserID]bool{},
+	}
+}
+
+var _ repository.TaskSearcher = (*MemorySearcher)(nil)
+
+// Add adds or replaces the task, which is also visible to the given users unless it is private.
+func (ms *MemorySearcher) Add(t *model.Task, sharedWith ...model.UserID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	task := *t
+	ms.tasks[t.ID] = &task
+
+	ms.shares[t.ID] = map[model.UserID]bool{}
+	for _, id := range sharedWith {
+		ms.shares[t.ID][id] = true
+	}
+}
+
+// Join makes the user a member of the workspace, who can view all the tasks in it.
+func (ms *MemorySearcher) Join(workspaceID model.WorkspaceID, userID model.UserID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.members[workspaceID] == nil {
+		ms.members[workspaceID] = map[model.UserID]bool{}
+	}
+
+	ms.members[workspaceID][userID] = true
+}
+
+func (ms *MemorySearcher) Search(s model.TaskSearch) ([]*model.TaskHit, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	terms := s.Terms()
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var hits []*model.TaskHit
+
+	for _, t := range ms.tasks {
+		if !ms.visible(t, s.ViewerID) {
+			continue
+		}
+
+		if score := score(t, terms); score > 0 {
+			task := *t
+			hits = append(hits, &model.TaskHit{Task: &task, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+
+		if !a.Task.Deadline.Equal(b.Task.Deadline) {
+			return a.Task.Deadline.Before(b.Task.Deadline)
+		}
+
+		return a.Task.ID < b.Task.ID
+	})
+
+	if limit := s.PageSize(); len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+func (ms *MemorySearcher) visible(t *model.Task, viewerID model.UserID) bool {
+	if t.ArchivedAt != nil || t.TrashedAt != nil {
+		return false
+	}
+
+	return ms.members[t.WorkspaceID][viewerID] || t.UserID == viewerID || t.AssigneeID == viewerID || (t.Visibility != model.Private && ms.shares[t.ID][viewerID])
+}
+
+// score counts the occurrences of the terms, or returns 0 unless all the terms occur.
+func score(t *model.Task, terms []string) float64 {
+	name, detail := strings.ToLower(t.Name), strings.ToLower(t.Detail)
+
+	var total float64
+
+	for _, term := range terms {
+		n := 2*strings.Count(name, term) + strings.Count(detail, term)
+		if n == 0 {
+			return 0
+		}
+
+		total += float64(n)
+	}
+
+	return total
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySearcher(t *testing.T) {
+	t.Parallel()
+
+	viewer := model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	deadline := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+
+	ms := NewMemorySearcher()
+	ms.Add(&model.Task{ID: "b", UserID: viewer, Name: "Report", Deadline: deadline})
+	ms.Add(&model.Task{ID: "a", UserID: viewer, Name: "Report", Deadline: deadline})
+	ms.Add(&model.Task{ID: "early", UserID: viewer, Name: "Report", Deadline: deadline.AddDate(0, 0, -1)})
+	ms.Add(&model.Task{ID: "renamed", UserID: viewer, Name: "Report", Deadline: deadline})
+	ms.Add(&model.Task{ID: "renamed", UserID: viewer, Name: "Memo", Deadline: deadline})
+	ms.Add(&model.Task{ID: "team", UserID: "creator", WorkspaceID: "team", Name: "Report", Deadline: deadline})
+	ms.Join("team", "member")
+
+	tests := []struct {
+		name           string
+		input          model.TaskSearch
+		expectedOutput []model.TaskID
+	}{
+		{"normal case: ties are ordered by deadline and id", model.TaskSearch{ViewerID: viewer, Text: "report"}, []model.TaskID{"early", "a", "b"}},
+		{"normal case: limited", model.TaskSearch{ViewerID: viewer, Text: "report", Limit: 1}, []model.TaskID{"early"}},
+		{"normal case: replaced task", model.TaskSearch{ViewerID: viewer, Text: "memo"}, []model.TaskID{"renamed"}},
+		{"normal case: other viewer", model.TaskSearch{ViewerID: "other", Text: "report"}, []model.TaskID{}},
+		{"normal case: workspace member", model.TaskSearch{ViewerID: "member", Text: "report"}, []model.TaskID{"team"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hits, err := ms.Search(tt.input)
+			assert.Nil(t, err)
+
+			ids := []model.TaskID{}
+			for _, h := range hits {
+				ids = append(ids, h.Task.ID)
+			}
+
+			assert.Exactly(t, tt.expectedOutput, ids)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage returns a storage which keeps files under the root directory, at the paths given by their keys.
+func NewLocalStorage(root string) usecase.FileStorage {
+	return &LocalStorage{root: root}
+}
+
+func (s *LocalStorage) Put(key string, r io.Reader, size int64, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return errors.Wrapf(err, "failed to create directory. key: %s", key)
+	}
+
+	// INFO: write to a temporary file first, so that a failed upload never leaves a partial file under the key
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file. key: %s", key)
+	}
+	defer os.Remove(f.Name())
+
+	n, err := io.Copy(f, io.LimitReader(r, size+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to write file. key: %s", key)
+	} else if n != size {
+		return errors.Errorf("file size does not match. key: %s, expected: %d, actual: %d", key, size, n)
+	}
+
+	if err := os.Rename(f.Name(), p); err != nil {
+		return errors.Wrapf(err, "failed to store file. key: %s", key)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open file. key: %s", key)
+	}
+
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "failed to delete file. key: %s", key)
+	}
+
+	return nil
+}
+
+// path returns the path of the file of the key, which has to stay under the root directory.
+func (s *LocalStorage) path(key string) (string, error) {
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", errors.Errorf("invalid key. key: %s", key)
+		}
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	fs := NewLocalStorage(root)
+
+	key := "tasks/task/attachment"
+	content := []byte("%PDF-1.4 content")
+
+	assert.Nil(t, fs.Put(key, bytes.NewReader(content), int64(len(content)), "application/pdf"))
+
+	stored, err := os.ReadFile(filepath.Join(root, "tasks", "task", "attachment"))
+	assert.Nil(t, err)
+	assert.Exactly(t, content, stored)
+
+	r, err := fs.Get(key)
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	got, _ := io.ReadAll(r)
+	r.Close()
+	assert.Exactly(t, content, got)
+
+	err = fs.Put("tasks/task/short", bytes.NewReader(content), int64(len(content))+1, "application/pdf")
+	assert.Contains(t, err.Error(), "file size does not match")
+
+	_, err = os.Stat(filepath.Join(root, "tasks", "task", "short"))
+	assert.True(t, os.IsNotExist(err), "partial file is not expected but found")
+
+	assert.Nil(t, fs.Delete(key))
+	assert.Nil(t, fs.Delete(key))
+
+	_, err = fs.Get(key)
+	assert.NotNil(t, err)
+
+	for _, key := range []string{"../escape", "/absolute", "tasks//task", "tasks/./task"} {
+		assert.Contains(t, fs.Put(key, bytes.NewReader(content), int64(len(content)), "").Error(), "invalid key")
+	}
+}
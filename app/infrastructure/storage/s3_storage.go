@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// emptyPayloadHash is the SHA-256 hash of an empty body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// unsignedPayload lets a body be streamed without hashing it beforehand.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	s3Service       = "s3"
+)
+
+type S3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Storage returns a storage which keeps files as objects in the bucket of an S3-compatible server at the endpoint,
+// e.g. https://s3.ap-northeast-1.amazonaws.com or http://localhost:9000 for MinIO.
+// The objects are addressed in path style, which every S3-compatible server supports.
+func NewS3Storage(endpoint, bucket, region, accessKey, secretKey string) (usecase.FileStorage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid S3 endpoint. endpoint: %s", endpoint)
+	} else if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("S3 endpoint needs scheme and host. endpoint: %s", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, errors.New("S3 bucket is required")
+	}
+
+	return &S3Storage{
+		endpoint:  u,
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Put(key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.request(http.MethodPut, key, io.LimitReader(r, size))
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return errors.Wrapf(err, "failed to put object. key: %s", key)
+	}
+
+	return res.Body.Close()
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+	req, err := s.request(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object. key: %s", key)
+	}
+
+	return res.Body, nil
+}
+
+func (s *S3Storage) Delete(key string) error {
+	req, err := s.request(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	// INFO: S3 answers 204 to the deletion of a missing object as well
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete object. key: %s", key)
+	}
+
+	return res.Body.Close()
+}
+
+func (s *S3Storage) request(method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, errors.Errorf("invalid key. key: %s", key)
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = escapePath(u.Path)
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request. key: %s", key)
+	}
+
+	return req, nil
+}
+
+// do signs and sends the request, and returns the response only when it succeeded.
+func (s *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signV4(req, payloadHash, s.accessKey, s.secretKey, s.region, s.now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode/100 != 2 {
+		defer res.Body.Close()
+
+		return nil, newS3Error(res)
+	}
+
+	return res, nil
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func newS3Error(res *http.Response) error {
+	var e s3Error
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	if err := xml.Unmarshal(body, &e); err != nil || e.Code == "" {
+		return errors.Errorf("S3 responded %s", res.Status)
+	}
+
+	return errors.Errorf("S3 responded %s: %s: %s", res.Status, e.Code, e.Message)
+}
+
+// signV4 signs the request with AWS Signature Version 4 for S3 by adding the Authorization header.
+// The host, Content-Type, Range and x-amz-* headers are signed.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signV4(req *http.Request, payloadHash, accessKey, secretKey, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s", accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// escapePath escapes the path as S3 expects, where every byte but the unreserved characters and slashes is percent-encoded.
+func escapePath(p string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader has the signature of the body by model.SignWebhook, and TimestampHeader the Unix time at which it is signed.
+	SignatureHeader = "X-Todo-Signature"
+	TimestampHeader = "X-Todo-Timestamp"
+	EventHeader     = "X-Todo-Event"
+	// DeliveryHeader has the ID of the delivery, which is the same in all the attempts so that receivers can ignore duplicates.
+	DeliveryHeader = "X-Todo-Delivery"
+
+	userAgent = "todo-app-webhook"
+	// maxResponseSize is how much of a response is read, so that the connection can be reused without reading a large body.
+	maxResponseSize = 64 << 10
+)
+
+// errBlockedAddress is the error of dialing an address which is not public.
+var errBlockedAddress = errors.New("address is not allowed")
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender returns a sender which posts the deliveries within the timeout.
+// Redirects are not followed, since a redirected POST may lose its body, so that they are failures like other non-2xx responses.
+func NewHTTPSender(timeout time.Duration) usecase.WebhookSender {
+	return newHTTPSender(timeout, publicOnly)
+}
+
+// newHTTPSender returns a sender whose connections are checked by control before they are made.
+// INFO: the addresses are checked at dial time rather than when the URL is registered, so that a name which resolves
+// to another address later cannot reach the server itself or the network behind it, and no proxy is used since it would dial instead
+func newHTTPSender(timeout time.Duration, control func(network, address string, c syscall.RawConn) error) usecase.WebhookSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: control,
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// publicOnly refuses to connect to addresses which are not public.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errBlockedAddress
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !model.PublicIP(ip) {
+		return errBlockedAddress
+	}
+
+	return nil
+}
+
+func (s *HTTPSender) Send(w model.Webhook, d model.WebhookDelivery) (int, error) {
+	payload := []byte(d.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to make request. delivery id: %s", d.ID)
+	}
+
+	now := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, string(d.EventType))
+	req.Header.Set(DeliveryHeader, string(d.ID))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, model.SignWebhook(w.Secret, now, payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		// INFO: the log of the deliveries is shown to the admins of the workspace, so that it has only a generic error,
+		// which does not tell how the address or the port failed
+		log.Printf("failed to post delivery. delivery id: %s, err: %v", d.ID, err)
+
+		if errors.Is(err, errBlockedAddress) {
+			return 0, errors.New("receiver address is not allowed")
+		}
+
+		return 0, errors.New("failed to reach the receiver")
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseSize))
+
+	return res.StatusCode, nil
+}
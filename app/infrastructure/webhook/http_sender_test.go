@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPSenderSend(t *testing.T) {
+	t.Parallel()
+
+	webhook := model.Webhook{ID: "wh1", Secret: "secret"}
+	delivery := model.WebhookDelivery{ID: "d1", EventType: model.TaskCompleted, Payload: `{"event":"task.completed"}`}
+
+	tests := []struct {
+		name           string
+		status         int
+		expectedStatus int
+	}{
+		{"normal case", http.StatusNoContent, http.StatusNoContent},
+		{"error status is returned", http.StatusInternalServerError, http.StatusInternalServerError},
+		// INFO: a redirect is not followed, so that it fails as its status
+		{"redirect is not followed", http.StatusFound, http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			received := make(chan *http.Request, 1)
+			bodies := make(chan string, 1)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received <- r
+				bodies <- string(body)
+
+				if tt.status == http.StatusFound {
+					http.Redirect(w, r, "/elsewhere", http.StatusFound)
+
+					return
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			w := webhook
+			w.URL = server.URL + "/hook"
+
+			// INFO: the receiver is on the loopback, which NewHTTPSender refuses
+			status, err := newHTTPSender(time.Second, nil).Send(w, delivery)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			assert.Exactly(t, tt.expectedStatus, status)
+
+			r := <-received
+			body := <-bodies
+
+			assert.Exactly(t, http.MethodPost, r.Method)
+			assert.Exactly(t, "/hook", r.URL.Path)
+			assert.Exactly(t, delivery.Payload, body)
+			assert.Exactly(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Exactly(t, "task.completed", r.Header.Get(EventHeader))
+			assert.Exactly(t, "d1", r.Header.Get(DeliveryHeader))
+
+			unix, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+			if err != nil {
+				t.Fatalf("timestamp is invalid: %v", err)
+			}
+
+			assert.Exactly(t, model.SignWebhook("secret", time.Unix(unix, 0), []byte(body)), r.Header.Get(SignatureHeader))
+		})
+	}
+}
+
+func TestHTTPSenderSendUnreachable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := newHTTPSender(time.Second, nil).Send(model.Webhook{URL: server.URL}, model.WebhookDelivery{ID: "d1"})
+	if assert.Error(t, err) {
+		assert.Exactly(t, "failed to reach the receiver", err.Error())
+	}
+}
+
+func TestHTTPSenderSendLocalAddress(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan struct{}, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPSender(time.Second).Send(model.Webhook{URL: server.URL}, model.WebhookDelivery{ID: "d1"})
+	if assert.Error(t, err) {
+		assert.Exactly(t, "receiver address is not allowed", err.Error())
+	}
+
+	assert.Len(t, received, 0)
+}
+
+func TestPublicOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address  string
+		expected error
+	}{
+		{"93.184.216.34:443", nil},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", nil},
+		{"127.0.0.1:8080", errBlockedAddress},
+		{"[::1]:80", errBlockedAddress},
+		{"10.0.0.1:80", errBlockedAddress},
+		{"172.16.0.1:80", errBlockedAddress},
+		{"192.168.1.1:80", errBlockedAddress},
+		{"169.254.169.254:80", errBlockedAddress},
+		{"100.64.0.1:80", errBlockedAddress},
+		{"0.0.0.0:80", errBlockedAddress},
+		{"[fd00::1]:80", errBlockedAddress},
+		{"[fe80::1]:80", errBlockedAddress},
+		{"[::ffff:127.0.0.1]:80", errBlockedAddress},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Exactly(t, tt.expected, publicOnly("tcp", tt.address, nil))
+		})
+	}
+}
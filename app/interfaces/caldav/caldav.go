@@ -0,0 +1,209 @@
+// Package caldav serves the tasks assigned to a user as a CalDAV calendar (RFC 4791) of VTODOs,
+// so that calendar and reminder apps can sync them both ways.
+// Changes from the apps are applied through TaskUsecase, so they are checked by the same rules as the other clients.
+package caldav
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Prefix is the path under which the handler is mounted. It is both the principal and the calendar home of the user.
+	Prefix = "/caldav/"
+	// CollectionPath is the calendar collection of the tasks. The objects of the tasks created by calendar clients
+	// are named as the clients named them, and the objects of the other tasks are named after the task IDs.
+	CollectionPath = Prefix + "tasks/"
+
+	objectExt     = ".ics"
+	maxObjectSize = 1 << 20
+)
+
+// Methods are the HTTP methods which the handler serves.
+var Methods = []string{http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, "PROPFIND", "REPORT"}
+
+var getNow = time.Now
+
+type handler struct {
+	taskUsecase usecase.TaskUsecase
+	userUsecase usecase.UserUsecase
+}
+
+func NewHandler(tu usecase.TaskUsecase, uu usecase.UserUsecase) http.Handler {
+	return &handler{
+		taskUsecase: tu,
+		userUsecase: uu,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		w.Header().Set("DAV", "1, calendar-access")
+		w.Header().Set("Allow", strings.Join(Methods, ", "))
+
+		return
+	}
+
+	s, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	path := r.URL.Path
+	if !strings.HasSuffix(path, objectExt) && !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	switch {
+	case path == Prefix:
+		h.serveHome(w, r, *s)
+	case path == CollectionPath:
+		h.serveCollection(w, r, *s)
+	case strings.HasPrefix(path, CollectionPath) && !strings.Contains(path[len(CollectionPath):], "/"):
+		h.serveObject(w, r, *s, objectNameOf(path))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// authorize authenticates the user by HTTP Basic authentication with the email and password,
+// since calendar apps cannot log in with a form.
+func (h *handler) authorize(w http.ResponseWriter, r *http.Request) (*usecase.Session, bool) {
+	if email, password, ok := r.BasicAuth(); ok {
+		id, err := h.userUsecase.Authenticate(email, password)
+		if err == nil {
+			return &usecase.Session{UserID: id}, true
+		} else if !errors.Is(err, usecase.ErrUnauthenticated) {
+			errorResponse(w, err)
+
+			return nil, false
+		}
+	}
+
+	w.Header().Set("WWW-Authenticate", `Basic realm="todo-app", charset="UTF-8"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+	return nil, false
+}
+
+func (h *handler) serveHome(w http.ResponseWriter, r *http.Request, s usecase.Session) {
+	if r.Method != "PROPFIND" {
+		methodNotAllowed(w, "OPTIONS, PROPFIND")
+
+		return
+	}
+
+	h.propfind(w, r, func(depth int) ([]*resource, error) {
+		user, err := h.userUsecase.Find(s)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := []*resource{homeResource(user)}
+
+		if depth > 0 {
+			tasks, err := h.taskUsecase.FindAssigned(s)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, collectionResource(tasks))
+		}
+
+		return resources, nil
+	})
+}
+
+func (h *handler) serveCollection(w http.ResponseWriter, r *http.Request, s usecase.Session) {
+	switch r.Method {
+	case "PROPFIND":
+		h.propfind(w, r, func(depth int) ([]*resource, error) {
+			tasks, err := h.taskUsecase.FindAssigned(s)
+			if err != nil {
+				return nil, err
+			}
+
+			resources := []*resource{collectionResource(tasks)}
+
+			if depth > 0 {
+				for _, t := range tasks {
+					resources = append(resources, objectResource(t))
+				}
+			}
+
+			return resources, nil
+		})
+	case "REPORT":
+		h.report(w, r, s)
+	default:
+		methodNotAllowed(w, "OPTIONS, PROPFIND, REPORT")
+	}
+}
+
+func (h *handler) serveObject(w http.ResponseWriter, r *http.Request, s usecase.Session, name string) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.getObject(w, r, s, name)
+	case http.MethodPut:
+		h.putObject(w, r, s, name)
+	case http.MethodDelete:
+		h.deleteObject(w, r, s, name)
+	case "PROPFIND":
+		h.propfind(w, r, func(int) ([]*resource, error) {
+			t, err := h.findObject(s, name)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource{objectResource(t)}, nil
+		})
+	default:
+		methodNotAllowed(w, "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND")
+	}
+}
+
+func objectPath(t *model.Task) string {
+	return CollectionPath + objectName(t) + objectExt
+}
+
+func objectName(t *model.Task) string {
+	if t.CalendarName != "" {
+		return t.CalendarName
+	}
+
+	return string(t.ID)
+}
+
+func objectNameOf(path string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(path, CollectionPath), objectExt)
+}
+
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+// errorResponse maps the usecase errors to statuses. Changes which break the rules of tasks are forbidden,
+// and concurrent changes fail the precondition of the ETag.
+func errorResponse(w http.ResponseWriter, err error) {
+	log.Println(err)
+
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, usecase.ErrConflict):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, usecase.ErrForbidden), errors.Is(err, usecase.ErrInvalidArgument), errors.Is(err, usecase.ErrApprovalRequired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, usecase.ErrUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,461 @@
+package caldav
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/service"
+	"todo-app/mock"
+	"todo-app/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type repositories struct {
+	task *mock.MockTaskRepository
+	user *mock.MockUserRepository
+}
+
+func TestHandler(t *testing.T) {
+	user, err := model.NewUser("477ecd7f-48fe-6b1c-499a-ec9f52b15a33", "abc@example.com", "password123")
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	user.TimeZone = "Asia/Tokyo"
+	tokyo := user.Location()
+	d := time.Now().In(tokyo).AddDate(0, 0, 2)
+	deadline := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, tokyo)
+	completionDate := time.Date(d.Year(), d.Month(), d.Day()-3, 0, 0, 0, 0, tokyo)
+
+	id1 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	id2 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac")
+	googleUID := "6f1a2b3c4d5e@google.com"
+	googleName := "6f1a2b3c4d5e"
+	workingTask := func() *model.Task {
+		return &model.Task{ID: id1, UserID: user.ID, AssigneeID: user.ID, WorkspaceID: model.PersonalWorkspaceID(user.ID), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, Deadline: deadline, TimeZone: "Asia/Tokyo", Priority: model.P2, Version: 3}
+	}
+	completedTask := func() *model.Task {
+		return &model.Task{ID: id2, UserID: user.ID, AssigneeID: user.ID, WorkspaceID: model.PersonalWorkspaceID(user.ID), Name: "Catering", Status: model.Completed, CompletionDate: &completionDate, Deadline: deadline, TimeZone: "Asia/Tokyo", Priority: model.P4, Version: 5}
+	}
+	othersTask := func() *model.Task {
+		t := workingTask()
+		t.AssigneeID = "other"
+
+		return t
+	}
+	namedTask := func() *model.Task {
+		t := workingTask()
+		t.CalendarUID = googleUID
+		t.CalendarName = googleName
+
+		return t
+	}
+	postponedTask := func() *model.Task {
+		t := workingTask()
+		t.PostponedCount = model.POSTPONED_COUNT_LIMIT
+
+		return t
+	}
+	page := func(_ *testing.T, r repositories) {
+		r.task.EXPECT().Find(gomock.Any()).Return(&model.TaskPage{Tasks: []*model.Task{workingTask(), completedTask()}}, nil).Times(1)
+	}
+
+	clientUID := "0c9e0d6e-5b3a-4f4e-9b1d-2f6a8c7e4d21"
+	todo := func(summary, status, due string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\nBEGIN:VTODO\r\nUID:" + clientUID + "\r\nDTSTAMP:20220101T000000Z\r\n" +
+			"SUMMARY:" + summary + "\r\nDESCRIPTION:Reserve venue for conference\r\nSTATUS:" + status + "\r\n" + due + "END:VTODO\r\nEND:VCALENDAR\r\n"
+	}
+	sameDue := "DUE;VALUE=DATE:" + deadline.Format("20060102") + "\r\n"
+	laterDue := "DUE;VALUE=DATE:" + deadline.AddDate(0, 0, 1).Format("20060102") + "\r\n"
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		password         string
+		header           map[string]string
+		body             string
+		expect           func(t *testing.T, r repositories)
+		expectedStatus   int
+		expectedContains []string
+	}{
+		{
+			"error case: wrong password",
+			"PROPFIND",
+			Prefix,
+			"password124",
+			nil,
+			"",
+			func(*testing.T, repositories) {},
+			http.StatusUnauthorized,
+			nil,
+		},
+		{
+			"normal case: PROPFIND of the home",
+			"PROPFIND",
+			Prefix,
+			"password123",
+			map[string]string{"Depth": "0"},
+			`<?xml version="1.0"?><propfind xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><prop><current-user-principal/><C:calendar-home-set/><displayname/><getetag/></prop></propfind>`,
+			func(*testing.T, repositories) {},
+			http.StatusMultiStatus,
+			[]string{`<calendar-home-set xmlns="urn:ietf:params:xml:ns:caldav"><href xmlns="DAV:">/caldav/</href></calendar-home-set>`, `<displayname xmlns="DAV:">abc@example.com</displayname>`, `<getetag xmlns="DAV:"></getetag></prop><status>HTTP/1.1 404 Not Found</status>`},
+		},
+		{
+			"normal case: PROPFIND of the collection",
+			"PROPFIND",
+			CollectionPath,
+			"password123",
+			map[string]string{"Depth": "1"},
+			`<?xml version="1.0"?><propfind xmlns="DAV:"><prop><resourcetype/><getetag/></prop></propfind>`,
+			page,
+			http.StatusMultiStatus,
+			[]string{`<calendar xmlns="urn:ietf:params:xml:ns:caldav"/>`, "<href>/caldav/tasks/" + string(id1) + ".ics</href>", `<getetag xmlns="DAV:">&#34;3&#34;</getetag>`, `<getetag xmlns="DAV:">&#34;5&#34;</getetag>`},
+		},
+		{
+			"normal case: calendar-multiget",
+			"REPORT",
+			CollectionPath,
+			"password123",
+			nil,
+			`<?xml version="1.0"?><C:calendar-multiget xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><prop><getetag/><C:calendar-data/></prop><href>/caldav/tasks/` + string(id2) + `.ics</href><href>/caldav/tasks/unknown.ics</href></C:calendar-multiget>`,
+			page,
+			http.StatusMultiStatus,
+			[]string{"SUMMARY:Catering&#xD;&#xA;", "STATUS:COMPLETED", "COMPLETED:" + completionDate.UTC().Format("20060102T150405Z"), "<href>/caldav/tasks/unknown.ics</href><status>HTTP/1.1 404 Not Found</status>"},
+		},
+		{
+			"normal case: calendar-query of events",
+			"REPORT",
+			CollectionPath,
+			"password123",
+			nil,
+			`<?xml version="1.0"?><C:calendar-query xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav"><prop><getetag/></prop><C:filter><C:comp-filter name="VCALENDAR"><C:comp-filter name="VEVENT"/></C:comp-filter></C:filter></C:calendar-query>`,
+			page,
+			http.StatusMultiStatus,
+			[]string{`<multistatus xmlns="DAV:"></multistatus>`},
+		},
+		{
+			"normal case: GET",
+			http.MethodGet,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			nil,
+			"",
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(workingTask(), nil).Times(1)
+			},
+			http.StatusOK,
+			[]string{"UID:" + string(id1), "SUMMARY:Venue Reservation", "STATUS:NEEDS-ACTION", "PRIORITY:5"},
+		},
+		{
+			"normal case: GET of a todo named by a client",
+			http.MethodGet,
+			CollectionPath + googleName + ".ics",
+			"password123",
+			nil,
+			"",
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByCalendarName(user.ID, googleName).Return(namedTask(), nil).Times(1)
+			},
+			http.StatusOK,
+			[]string{"UID:" + googleUID, "SUMMARY:Venue Reservation"},
+		},
+		{
+			"error case: GET of a todo named by a client by its task ID",
+			http.MethodGet,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			nil,
+			"",
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(namedTask(), nil).Times(1)
+			},
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"error case: GET of a task assigned to another user",
+			http.MethodGet,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			nil,
+			"",
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(othersTask(), nil).Times(1)
+			},
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"error case: PUT with a stale ETag",
+			http.MethodPut,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			map[string]string{"If-Match": `"2"`},
+			todo("Venue Reservation", "COMPLETED", sameDue),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(workingTask(), nil).Times(1)
+				r.task.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			http.StatusPreconditionFailed,
+			nil,
+		},
+		{
+			"normal case: PUT completing and renaming a task",
+			http.MethodPut,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			map[string]string{"If-Match": `"3"`},
+			todo("Venue Reservation in Tokyo", "COMPLETED", sameDue),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(workingTask(), nil).Times(2)
+				r.task.EXPECT().FindByParentID(id1).Return(nil, nil).Times(1)
+				r.task.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ map[model.TaskID]*model.Task, _ []*model.TaskHistory) error {
+					if !assert.Len(t, tasks, 1) {
+						return nil
+					}
+
+					task := tasks[0]
+					assert.Exactly(t, "Venue Reservation in Tokyo", task.Name)
+					assert.Exactly(t, model.Completed, task.Status)
+					assert.NotNil(t, task.CompletionDate)
+					assert.Exactly(t, model.P2, task.Priority)
+					assert.Exactly(t, 0, task.PostponedCount)
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusNoContent,
+			nil,
+		},
+		{
+			"error case: PUT postponing a task too often",
+			http.MethodPut,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			nil,
+			todo("Venue Reservation", "NEEDS-ACTION", laterDue),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(postponedTask(), nil).Times(2)
+				r.task.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			http.StatusForbidden,
+			[]string{"postponed counts reach limit"},
+		},
+		{
+			"normal case: PUT of a new todo",
+			http.MethodPut,
+			CollectionPath + clientUID + ".ics",
+			"password123",
+			map[string]string{"If-None-Match": "*"},
+			todo("Catering", "NEEDS-ACTION", laterDue),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(model.TaskID(clientUID)).Return(nil, nil).Times(2)
+				r.task.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(task *model.Task, _ *model.TaskHistory) error {
+					assert.NotEqual(t, model.TaskID(clientUID), task.ID)
+					assert.Exactly(t, clientUID, task.CalendarUID)
+					assert.Exactly(t, clientUID, task.CalendarName)
+					assert.Exactly(t, "Catering", task.Name)
+					assert.Exactly(t, deadline.AddDate(0, 0, 1).Format("2006-01-02"), task.Due().String())
+					assert.Exactly(t, "Asia/Tokyo", task.TimeZone)
+					assert.Exactly(t, model.DefaultPriority, task.Priority)
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusCreated,
+			nil,
+		},
+		{
+			"normal case: PUT of a new completed todo",
+			http.MethodPut,
+			CollectionPath + clientUID + ".ics",
+			"password123",
+			map[string]string{"If-None-Match": "*"},
+			todo("Catering", "COMPLETED", laterDue+"COMPLETED:"+completionDate.UTC().Add(10*time.Hour).Format("20060102T150405Z")+"\r\n"),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(model.TaskID(clientUID)).Return(nil, nil).Times(2)
+				r.task.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(task *model.Task, _ *model.TaskHistory) error {
+					assert.Exactly(t, model.Completed, task.Status)
+					assert.True(t, completionDate.Equal(*task.CompletionDate), "expected %v but received: %v", completionDate, task.CompletionDate)
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusCreated,
+			nil,
+		},
+		{
+			"error case: PUT of a new todo whose summary is too long",
+			http.MethodPut,
+			CollectionPath + clientUID + ".ics",
+			"password123",
+			map[string]string{"If-None-Match": "*"},
+			todo(strings.Repeat("a", 51), "NEEDS-ACTION", laterDue),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(model.TaskID(clientUID)).Return(nil, nil).Times(2)
+				r.task.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			http.StatusForbidden,
+			[]string{"task name exceeds 50 characters"},
+		},
+		{
+			"error case: PUT of a todo whose description is too long",
+			http.MethodPut,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			map[string]string{"If-Match": `"3"`},
+			strings.Replace(todo("Venue Reservation", "NEEDS-ACTION", sameDue), "Reserve venue for conference", strings.Repeat("a", 301), 1),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(workingTask(), nil).Times(2)
+				r.task.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			http.StatusForbidden,
+			[]string{"task detail exceeds 300 characters"},
+		},
+		{
+			"normal case: PUT of a new todo whose UID is not a UUID",
+			http.MethodPut,
+			CollectionPath + googleName + ".ics",
+			"password123",
+			map[string]string{"If-None-Match": "*"},
+			strings.Replace(todo("Catering", "NEEDS-ACTION", laterDue), clientUID, googleUID, 1),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(model.TaskID(googleName)).Return(nil, nil).Times(2)
+				r.task.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(task *model.Task, _ *model.TaskHistory) error {
+					assert.Nil(t, model.UUIDSpecSatisfied(string(task.ID)))
+					assert.Exactly(t, googleUID, task.CalendarUID)
+					assert.Exactly(t, googleName, task.CalendarName)
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusCreated,
+			nil,
+		},
+		{
+			"normal case: PUT updating a todo named by a client",
+			http.MethodPut,
+			CollectionPath + googleName + ".ics",
+			"password123",
+			map[string]string{"If-Match": `"3"`},
+			strings.Replace(todo("Venue Reservation in Tokyo", "NEEDS-ACTION", sameDue), clientUID, googleUID, 1),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByCalendarName(user.ID, googleName).Return(namedTask(), nil).Times(1)
+				r.task.EXPECT().FindByID(id1).Return(namedTask(), nil).Times(1)
+				r.task.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(task *model.Task, _ *model.TaskHistory) error {
+					assert.Exactly(t, id1, task.ID)
+					assert.Exactly(t, "Venue Reservation in Tokyo", task.Name)
+					assert.Exactly(t, googleUID, task.CalendarUID)
+					assert.Exactly(t, googleName, task.CalendarName)
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusNoContent,
+			nil,
+		},
+		{
+			"error case: PUT of a new todo named like another todo",
+			http.MethodPut,
+			CollectionPath + googleName + ".ics",
+			"password123",
+			map[string]string{"If-None-Match": "*"},
+			strings.Replace(todo("Catering", "NEEDS-ACTION", laterDue), clientUID, googleUID, 1),
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByCalendarName(user.ID, googleName).Return(namedTask(), nil).Times(1)
+				r.task.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			http.StatusPreconditionFailed,
+			nil,
+		},
+		{
+			"error case: PUT of an event",
+			http.MethodPut,
+			CollectionPath + "client-uid.ics",
+			"password123",
+			nil,
+			"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:client-uid\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			func(*testing.T, repositories) {},
+			http.StatusForbidden,
+			nil,
+		},
+		{
+			"normal case: DELETE",
+			http.MethodDelete,
+			CollectionPath + string(id1) + ".ics",
+			"password123",
+			map[string]string{"If-Match": `"3"`},
+			"",
+			func(t *testing.T, r repositories) {
+				r.task.EXPECT().FindByID(id1).Return(workingTask(), nil).Times(2)
+				r.task.EXPECT().FindByParentID(id1).Return(nil, nil).Times(1)
+				r.task.EXPECT().UpdateAll(gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ []*model.TaskHistory) error {
+					assert.Len(t, tasks, 1)
+					assert.True(t, tasks[0].IsTrashed())
+
+					return nil
+				}).Times(1)
+			},
+			http.StatusNoContent,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			taskUsecase := usecase.NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+			userUsecase := usecase.NewUserUsecase(userRepository, service.NewUService(userRepository))
+			h := NewHandler(taskUsecase, userUsecase)
+
+			userRepository.EXPECT().FindByEmail(user.Email).Return(user, nil).AnyTimes()
+			userRepository.EXPECT().FindByID(user.ID).Return(user, nil).AnyTimes()
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), user.ID).Return(&model.Member{WorkspaceID: model.PersonalWorkspaceID(user.ID), UserID: user.ID, Role: model.RoleOwner}, nil).AnyTimes()
+			dependencyRepository.EXPECT().FindBlockers(gomock.Any()).Return(nil, nil).AnyTimes()
+			tt.expect(t, repositories{task: taskRepository, user: userRepository})
+			// INFO: the objects of the tasks are named after their IDs unless the cases expect otherwise
+			taskRepository.EXPECT().FindByCalendarName(user.ID, gomock.Any()).Return(nil, nil).AnyTimes()
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			r.SetBasicAuth(string(user.Email), tt.password)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Exactly(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			for _, s := range tt.expectedContains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+
+			if tt.expectedStatus == http.StatusCreated {
+				assert.Empty(t, w.Header().Get("Location"), "the object must be stored at the requested path")
+			}
+
+			if tt.method == http.MethodGet && tt.expectedStatus == http.StatusOK {
+				assert.Exactly(t, `"3"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
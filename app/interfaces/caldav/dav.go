@@ -0,0 +1,414 @@
+package caldav
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"todo-app/domain/model"
+	"todo-app/interfaces/ical"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	davNS    = "DAV:"
+	caldavNS = "urn:ietf:params:xml:ns:caldav"
+	// csNS is the namespace of the extensions of Apple Calendar Server, of which getctag is widely used.
+	csNS = "http://calendarserver.org/ns/"
+
+	maxRequestBodySize = 1 << 20
+)
+
+var (
+	calendarDataName = xml.Name{Space: caldavNS, Local: "calendar-data"}
+	multigetName     = xml.Name{Space: caldavNS, Local: "calendar-multiget"}
+	queryName        = xml.Name{Space: caldavNS, Local: "calendar-query"}
+)
+
+// resource is a WebDAV resource with its properties, whose values are inner XML.
+type resource struct {
+	href  string
+	props []*propValue
+	// task is set on calendar object resources, whose calendar-data is made when it is requested.
+	task *model.Task
+}
+
+func (res *resource) add(space, local, inner string) {
+	res.props = append(res.props, &propValue{XMLName: xml.Name{Space: space, Local: local}, Inner: inner})
+}
+
+func (res *resource) find(name xml.Name) (*propValue, bool) {
+	if res.task != nil && name == calendarDataName {
+		var b bytes.Buffer
+		if err := ical.Encode(&b, ical.NewObject(res.task, getNow())); err != nil {
+			log.Println(err)
+
+			return nil, false
+		}
+
+		return &propValue{XMLName: name, Inner: escape(b.String())}, true
+	}
+
+	for _, p := range res.props {
+		if p.XMLName == name {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// response returns the requested properties of the resource. Properties which the resource does not have
+// are reported as not found, and all the properties but calendar-data are returned when names are nil.
+func (res *resource) response(names []xml.Name) *response {
+	found := &propstat{Status: statusLine(http.StatusOK)}
+	missing := &propstat{Status: statusLine(http.StatusNotFound)}
+
+	if names == nil {
+		found.Prop.Values = res.props
+	}
+
+	for _, name := range names {
+		if p, ok := res.find(name); ok {
+			found.Prop.Values = append(found.Prop.Values, p)
+		} else {
+			missing.Prop.Values = append(missing.Prop.Values, &propValue{XMLName: name})
+		}
+	}
+
+	resp := &response{Href: res.href}
+
+	for _, ps := range []*propstat{found, missing} {
+		if len(ps.Prop.Values) > 0 {
+			resp.Propstats = append(resp.Propstats, ps)
+		}
+	}
+
+	return resp
+}
+
+func homeResource(user *model.User) *resource {
+	res := &resource{href: Prefix}
+	res.add(davNS, "resourcetype", `<collection xmlns="DAV:"/><principal xmlns="DAV:"/>`)
+	res.add(davNS, "displayname", escape(string(user.Email)))
+	res.add(davNS, "current-user-principal", href(Prefix))
+	res.add(davNS, "principal-URL", href(Prefix))
+	res.add(davNS, "owner", href(Prefix))
+	res.add(caldavNS, "calendar-home-set", href(Prefix))
+	res.add(caldavNS, "calendar-user-address-set", href("mailto:"+string(user.Email)))
+	res.add(davNS, "current-user-privilege-set", privileges("read"))
+
+	return res
+}
+
+func collectionResource(tasks []*model.Task) *resource {
+	res := &resource{href: CollectionPath}
+	res.add(davNS, "resourcetype", `<collection xmlns="DAV:"/><calendar xmlns="urn:ietf:params:xml:ns:caldav"/>`)
+	res.add(davNS, "displayname", "Tasks")
+	res.add(davNS, "current-user-principal", href(Prefix))
+	res.add(davNS, "owner", href(Prefix))
+	res.add(davNS, "current-user-privilege-set", privileges("read", "write", "write-content", "bind", "unbind"))
+	res.add(davNS, "supported-report-set", supportedReport(multigetName)+supportedReport(queryName))
+	res.add(caldavNS, "supported-calendar-component-set", `<comp xmlns="urn:ietf:params:xml:ns:caldav" name="VTODO"/>`)
+	res.add(csNS, "getctag", ctag(tasks))
+
+	return res
+}
+
+func objectResource(t *model.Task) *resource {
+	res := &resource{href: objectPath(t), task: t}
+	res.add(davNS, "resourcetype", "")
+	res.add(davNS, "getetag", escape(etag(t)))
+	res.add(davNS, "getcontenttype", "text/calendar; charset=utf-8; component=vtodo")
+
+	return res
+}
+
+// etag is the entity tag of the calendar object of the task, which changes on every update of the task.
+func etag(t *model.Task) string {
+	return fmt.Sprintf(`"%d"`, t.Version)
+}
+
+// ctag changes whenever a task of the collection is added, changed or removed, so that clients skip unchanged calendars.
+func ctag(tasks []*model.Task) string {
+	h := sha1.New()
+	for _, t := range tasks {
+		fmt.Fprintf(h, "%s:%d;", t.ID, t.Version)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func href(u string) string {
+	return `<href xmlns="DAV:">` + escape(u) + `</href>`
+}
+
+func privileges(names ...string) string {
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(`<privilege xmlns="DAV:"><` + name + `/></privilege>`)
+	}
+
+	return b.String()
+}
+
+func supportedReport(name xml.Name) string {
+	return `<supported-report xmlns="DAV:"><report><` + name.Local + ` xmlns="` + name.Space + `"/></report></supported-report>`
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+func statusLine(status int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", status, http.StatusText(status))
+}
+
+// propfind answers the properties of the resources. Depth "infinity" is served as 1, since the tree is two levels deep.
+func (h *handler) propfind(w http.ResponseWriter, r *http.Request, find func(depth int) ([]*resource, error)) {
+	var req propfindRequest
+	if !decodeXML(w, r, &req) {
+		return
+	}
+
+	depth := 1
+	if r.Header.Get("Depth") == "0" {
+		depth = 0
+	}
+
+	resources, err := find(depth)
+	if err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	names := req.names()
+	responses := make([]*response, 0, len(resources))
+
+	for _, res := range resources {
+		responses = append(responses, res.response(names))
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// report serves calendar-multiget and calendar-query on the collection.
+// Queries are only filtered by component, and the time ranges are left to the clients.
+func (h *handler) report(w http.ResponseWriter, r *http.Request, s usecase.Session) {
+	var req reportRequest
+	if !decodeXML(w, r, &req) {
+		return
+	}
+
+	if req.XMLName != multigetName && req.XMLName != queryName {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, xml.Header+`<error xmlns="DAV:"><supported-report/></error>`)
+
+		return
+	}
+
+	tasks, err := h.taskUsecase.FindAssigned(s)
+	if err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	names := req.names()
+	var responses []*response
+
+	if req.XMLName == queryName {
+		if req.Filter.selectsTodos() {
+			for _, t := range tasks {
+				responses = append(responses, objectResource(t).response(names))
+			}
+		}
+
+		writeMultistatus(w, responses)
+
+		return
+	}
+
+	byName := make(map[string]*model.Task, len(tasks))
+	for _, t := range tasks {
+		byName[objectName(t)] = t
+	}
+
+	for _, ref := range req.Hrefs {
+		if t, ok := byName[objectNameOfHref(ref)]; ok {
+			responses = append(responses, objectResource(t).response(names))
+		} else {
+			responses = append(responses, &response{Href: ref, Status: statusLine(http.StatusNotFound)})
+		}
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// objectNameOfHref returns the object name of the href, which may be a full URL or an escaped path, or an empty name
+// when it is not a calendar object of the collection.
+func objectNameOfHref(ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil || !strings.HasPrefix(u.Path, CollectionPath) || !strings.HasSuffix(u.Path, objectExt) {
+		return ""
+	}
+
+	return objectNameOf(u.Path)
+}
+
+func decodeXML(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed to read request body").Error(), http.StatusBadRequest)
+
+		return false
+	}
+
+	// INFO: an empty PROPFIND body means allprop
+	if len(bytes.TrimSpace(body)) == 0 {
+		return true
+	}
+
+	if err := xml.Unmarshal(body, v); err != nil {
+		http.Error(w, errors.Wrap(err, "failed to decode request body").Error(), http.StatusBadRequest)
+
+		return false
+	}
+
+	return true
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []*response) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+
+	if err := xml.NewEncoder(&b).Encode(&multistatus{Responses: responses}); err != nil {
+		errorResponse(w, errors.Wrap(err, "failed to encode multistatus"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+
+	if _, err := b.WriteTo(w); err != nil {
+		log.Println(errors.Wrap(err, "failed to write multistatus"))
+	}
+}
+
+type propfindRequest struct {
+	XMLName xml.Name   `xml:"DAV: propfind"`
+	AllProp *struct{}  `xml:"DAV: allprop"`
+	Prop    *propNames `xml:"DAV: prop"`
+}
+
+// names returns the requested property names, or nil for all the properties.
+func (req *propfindRequest) names() []xml.Name {
+	if req.Prop == nil || req.AllProp != nil {
+		return nil
+	}
+
+	return req.Prop.Names
+}
+
+type reportRequest struct {
+	XMLName xml.Name
+	Prop    *propNames `xml:"DAV: prop"`
+	Hrefs   []string   `xml:"DAV: href"`
+	Filter  *filter    `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+func (req *reportRequest) names() []xml.Name {
+	if req.Prop == nil {
+		return []xml.Name{{Space: davNS, Local: "getetag"}, calendarDataName}
+	}
+
+	return req.Prop.Names
+}
+
+// propNames collects the names of the child elements of a prop element.
+type propNames struct {
+	Names []xml.Name
+}
+
+func (p *propNames) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			p.Names = append(p.Names, t.Name)
+
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type filter struct {
+	CompFilter compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	CompFilters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// selectsTodos reports whether the filter may match VTODOs. Only VTODOs are in the collection.
+func (f *filter) selectsTodos() bool {
+	if f == nil {
+		return true
+	}
+
+	for _, c := range f.CompFilter.CompFilters {
+		if !strings.EqualFold(c.Name, "VTODO") {
+			return false
+		}
+	}
+
+	return true
+}
+
+type multistatus struct {
+	XMLName   xml.Name    `xml:"DAV: multistatus"`
+	Responses []*response `xml:"response"`
+}
+
+type response struct {
+	Href      string      `xml:"href"`
+	Propstats []*propstat `xml:"propstat,omitempty"`
+	Status    string      `xml:"status,omitempty"`
+}
+
+type propstat struct {
+	Prop   props  `xml:"prop"`
+	Status string `xml:"status"`
+}
+
+type props struct {
+	Values []*propValue
+}
+
+type propValue struct {
+	XMLName xml.Name
+	Inner   string `xml:",innerxml"`
+}
@@ -0,0 +1,220 @@
+package caldav
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"todo-app/domain/model"
+	"todo-app/interfaces/ical"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+// findObject finds the task of a calendar object. Only the tasks assigned to the user are in the collection.
+func (h *handler) findObject(s usecase.Session, name string) (*model.Task, error) {
+	t, err := h.taskUsecase.FindByCalendarName(s, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inCollection(t, s.UserID) {
+		return nil, errors.Wrapf(usecase.ErrNotFound, "task is not in the calendar, taskID: %s", t.ID)
+	}
+
+	return t, nil
+}
+
+func inCollection(t *model.Task, userID model.UserID) bool {
+	return t.IsAssignedTo(userID) && !t.IsArchived() && !t.IsTrashed()
+}
+
+func (h *handler) getObject(w http.ResponseWriter, r *http.Request, s usecase.Session, name string) {
+	t, err := h.findObject(s, name)
+	if err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("ETag", etag(t))
+
+	if matchETag(r.Header.Get("If-None-Match"), etag(t)) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	var b bytes.Buffer
+	if err := ical.Encode(&b, ical.NewObject(t, getNow())); err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := b.WriteTo(w); err != nil {
+		log.Println(errors.Wrap(err, "failed to write calendar object"))
+	}
+}
+
+// putObject creates a task from a new calendar object or updates the task of an existing one.
+// New tasks keep the name of the object, so that the object stays where the client has put it.
+func (h *handler) putObject(w http.ResponseWriter, r *http.Request, s usecase.Session, name string) {
+	cal, err := ical.Decode(http.MaxBytesReader(w, r.Body, maxObjectSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	todos := cal.Children("VTODO")
+	if cal.Name != "VCALENDAR" || len(todos) != 1 || len(todos) != len(cal.Components)-len(cal.Children("VTIMEZONE")) {
+		http.Error(w, "calendar object must have a single VTODO", http.StatusForbidden)
+
+		return
+	}
+
+	t, err := h.taskUsecase.FindByCalendarName(s, name)
+
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		if r.Header.Get("If-Match") != "" {
+			http.Error(w, "calendar object is not found", http.StatusPreconditionFailed)
+
+			return
+		}
+
+		h.createObject(w, s, name, todos[0])
+	case err != nil:
+		errorResponse(w, err)
+	case !inCollection(t, s.UserID):
+		status := http.StatusForbidden
+		if r.Header.Get("If-Match") != "" {
+			status = http.StatusPreconditionFailed
+		}
+
+		http.Error(w, "task is not in the calendar", status)
+	case r.Header.Get("If-None-Match") == "*", r.Header.Get("If-Match") != "" && !matchETag(r.Header.Get("If-Match"), etag(t)):
+		http.Error(w, "calendar object has been changed", http.StatusPreconditionFailed)
+	default:
+		h.updateObject(w, s, t, todos[0])
+	}
+}
+
+// createObject creates the task of a new calendar object. The task keeps the UID of the todo and the name of the object,
+// which clients choose by themselves and are not always UUIDs.
+func (h *handler) createObject(w http.ResponseWriter, s usecase.Session, name string, c *ical.Component) {
+	user, err := h.userUsecase.Find(s)
+	if err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	todo, err := ical.ParseTodo(c, user.Location())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	// INFO: todos do not need a due date, while tasks are due today by default like in the form
+	due := model.DueOn(getNow().In(user.Location()))
+	if todo.Due != nil {
+		due = *todo.Due
+	}
+
+	priority := todo.Priority
+	if priority == 0 {
+		priority = model.DefaultPriority
+	}
+
+	if _, err := h.taskUsecase.CreateTodo(s, todo.UID, name, todo.Summary, todo.Description, todo.Status, todo.CompletionDate, due, priority); err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// updateObject applies the todo to the task. The dates without a time are in the time zone of the task,
+// so that an unchanged due date is not taken for a postponement. Missing due dates and priorities are kept.
+func (h *handler) updateObject(w http.ResponseWriter, s usecase.Session, t *model.Task, c *ical.Component) {
+	todo, err := ical.ParseTodo(c, t.Location())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	due := t.Due()
+	if todo.Due != nil {
+		due = *todo.Due
+	}
+
+	priority := t.Priority
+	if todo.Priority != 0 {
+		priority = todo.Priority
+	}
+
+	// INFO: clients only know whether todos are completed, so a behind task stays behind until it is completed
+	status := t.Status
+	switch {
+	case todo.Status == model.Completed:
+		status = model.Completed
+	case t.Status == model.Completed:
+		status = model.Working
+	}
+
+	if err := h.taskUsecase.Update(s, t.ID, todo.Summary, todo.Description, status, due, t.Recurrence, priority, false, t.Version); err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteObject moves the task to the trash, from which it can be restored.
+func (h *handler) deleteObject(w http.ResponseWriter, r *http.Request, s usecase.Session, name string) {
+	t, err := h.findObject(s, name)
+	if err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	if r.Header.Get("If-Match") != "" && !matchETag(r.Header.Get("If-Match"), etag(t)) {
+		http.Error(w, "calendar object has been changed", http.StatusPreconditionFailed)
+
+		return
+	}
+
+	if err := h.taskUsecase.Trash(s, t.ID); err != nil {
+		errorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// matchETag reports whether the If-Match or If-None-Match header, like `"3"`, `W/"3", "4"` or `*`, matches the entity tag.
+func matchETag(header, tag string) bool {
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == tag {
+			return true
+		}
+	}
+
+	return false
+}
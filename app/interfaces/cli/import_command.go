@@ -0,0 +1,159 @@
+// Package cli has the subcommands of the server binary, which are run from the command line instead of serving.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/interfaces/transfer"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+// PasswordEnv is the environment variable of the password of the importing user,
+// which is not taken as a flag so that it is left out of the shell history.
+const PasswordEnv = "TODO_PASSWORD"
+
+type Command interface {
+	Run(args []string) error
+}
+
+type importCommand struct {
+	userUsecase     usecase.UserUsecase
+	transferUsecase usecase.TransferUsecase
+	out             io.Writer
+	getenv          func(string) string
+}
+
+// NewImportCommand creates the import subcommand, which imports a file of tasks as a user like the import page, e.g.
+//
+//	TODO_PASSWORD=... server import -email user@example.com -format trello board.json
+//
+// The rows are written to out with their errors, and nothing is imported when any of them is invalid.
+func NewImportCommand(uu usecase.UserUsecase, tu usecase.TransferUsecase, out io.Writer, getenv func(string) string) Command {
+	return &importCommand{
+		userUsecase:     uu,
+		transferUsecase: tu,
+		out:             out,
+		getenv:          getenv,
+	}
+}
+
+func (c *importCommand) Run(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s=<password> server import -email <email> [options] <file>\n", PasswordEnv)
+		fs.PrintDefaults()
+	}
+
+	email := fs.String("email", "", "email of the user who imports the tasks")
+	format := fs.String("format", "", "csv, json, todotxt, taskwarrior or trello; by the file extension when empty")
+	workspace := fs.String("workspace", "", "ID of the workspace of the tasks; the personal workspace when empty")
+	dryRun := fs.Bool("dry-run", false, "check the rows without importing them")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *email == "" || fs.NArg() != 1 {
+		fs.Usage()
+
+		return errors.New("email and file are required")
+	}
+
+	path := fs.Arg(0)
+
+	var (
+		f   transfer.Format
+		err error
+	)
+
+	if *format != "" {
+		f, err = transfer.ParseFormat(*format)
+	} else {
+		f, err = transfer.ParseFileName(path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	userID, err := c.userUsecase.Authenticate(*email, c.getenv(PasswordEnv))
+	if err != nil {
+		return errors.Wrap(err, "failed to authenticate")
+	}
+
+	s := usecase.Session{UserID: userID}
+
+	user, err := c.userUsecase.Find(s)
+	if err != nil {
+		return err
+	}
+
+	records, err := readFile(path, f, user.Location())
+	if err != nil {
+		return err
+	}
+
+	var imp *model.TaskImport
+	if *dryRun {
+		imp, err = c.transferUsecase.Preview(s, model.WorkspaceID(*workspace), records)
+	} else {
+		imp, err = c.transferUsecase.Import(s, model.WorkspaceID(*workspace), records)
+	}
+
+	if imp != nil {
+		c.report(imp)
+
+		if err == nil && !imp.Valid() {
+			err = errors.Errorf("rows cannot be imported, count: %d", imp.ErrorCount())
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Fprintf(c.out, "%d tasks can be imported\n", len(imp.Tasks()))
+	} else {
+		fmt.Fprintf(c.out, "%d tasks are imported\n", len(imp.Tasks()))
+	}
+
+	return nil
+}
+
+// report writes the rows which cannot be imported with their errors.
+func (c *importCommand) report(imp *model.TaskImport) {
+	for _, row := range imp.Rows {
+		if row.Err != nil {
+			fmt.Fprintf(c.out, "row %d: %s: %v\n", row.Number, row.Record.Name, row.Err)
+		}
+	}
+
+	if !imp.Valid() {
+		fmt.Fprintf(c.out, "%d of %d rows have errors, so nothing is imported\n", imp.ErrorCount(), len(imp.Rows))
+	}
+}
+
+// readFile reads the records of a file, which is too large when it cannot be read within transfer.MaxFileSize.
+func readFile(path string, f transfer.Format, loc *time.Location) ([]model.TaskRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	} else if info.Size() > transfer.MaxFileSize {
+		return nil, errors.Errorf("file has to be at most %d MB", transfer.MaxFileSize>>20)
+	}
+
+	return transfer.Decode(file, f, loc)
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"todo-app/domain/model"
+	"todo-app/domain/service"
+	"todo-app/mock"
+	"todo-app/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestImportCommand(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &model.User{ID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33"), Email: "abc@example.com", Password: string(hash), TimeZone: "Asia/Tokyo"}
+	member := &model.Member{WorkspaceID: model.PersonalWorkspaceID(user.ID), UserID: user.ID, Role: model.RoleOwner}
+
+	tests := []struct {
+		name                string
+		args                []string
+		fileName            string
+		file                string
+		password            string
+		expectedCreateTimes int
+		expectedOutput      []string
+		expectedErr         string
+	}{
+		{
+			"normal case: todo.txt by the file extension",
+			[]string{"-email", "abc@example.com"},
+			"todo.txt",
+			"(A) Reserve the venue due:2099-01-26\nx 2022-01-25 Send invitations\n",
+			"password123",
+			1,
+			[]string{"2 tasks are imported"},
+			"",
+		},
+		{
+			"normal case: dry run",
+			[]string{"-email", "abc@example.com", "-dry-run"},
+			"todo.txt",
+			"(A) Reserve the venue due:2099-01-26\n",
+			"password123",
+			0,
+			[]string{"1 tasks can be imported"},
+			"",
+		},
+		{
+			"error case: invalid rows",
+			[]string{"-email", "abc@example.com", "-format", "csv"},
+			"tasks.txt",
+			"name,priority\nReserve the venue,P1\nSend invitations,P9\n",
+			"password123",
+			0,
+			[]string{"row 2: Send invitations: unknown priority", "1 of 2 rows have errors, so nothing is imported"},
+			"rows cannot be imported",
+		},
+		{
+			"error case: invalid rows in dry run",
+			[]string{"-email", "abc@example.com", "-dry-run", "-format", "csv"},
+			"tasks.txt",
+			"name,priority\nSend invitations,P9\n",
+			"password123",
+			0,
+			[]string{"row 1: Send invitations: unknown priority"},
+			"rows cannot be imported",
+		},
+		{
+			"error case: wrong password",
+			[]string{"-email", "abc@example.com"},
+			"todo.txt",
+			"Reserve the venue\n",
+			"password",
+			0,
+			nil,
+			"failed to authenticate",
+		},
+		{
+			"error case: unknown format",
+			[]string{"-email", "abc@example.com"},
+			"tasks.xlsx",
+			"",
+			"password123",
+			0,
+			nil,
+			"unknown format",
+		},
+		{
+			"error case: no email",
+			nil,
+			"todo.txt",
+			"Reserve the venue\n",
+			"password123",
+			0,
+			[]string{"Usage: TODO_PASSWORD=<password> server import"},
+			"email and file are required",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			userUsecase := usecase.NewUserUsecase(userRepository, service.NewUService(userRepository))
+			transferUsecase := usecase.NewTransferUsecase(taskRepository, userRepository, labelRepository, workspaceRepository, eventPublisher)
+
+			userRepository.EXPECT().FindByEmail(model.Email(user.Email)).Return(user, nil).AnyTimes()
+			userRepository.EXPECT().FindByID(user.ID).Return(user, nil).AnyTimes()
+			workspaceRepository.EXPECT().FindMember(member.WorkspaceID, user.ID).Return(member, nil).AnyTimes()
+			taskRepository.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(tt.expectedCreateTimes)
+
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			getenv := func(key string) string {
+				if key == PasswordEnv {
+					return tt.password
+				}
+
+				return ""
+			}
+
+			err := NewImportCommand(userUsecase, transferUsecase, &out, getenv).Run(append(tt.args, path))
+			if err != nil {
+				if tt.expectedErr != "" {
+					assert.Contains(t, err.Error(), tt.expectedErr)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Empty(t, tt.expectedErr, "error is expected but received nil")
+			}
+
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type attachmentResponse struct {
+	ID          string `json:"id"`
+	TaskID      string `json:"task_id"`
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type attachmentListResponse struct {
+	Attachments []*attachmentResponse `json:"attachments"`
+}
+
+func newAttachmentResponse(a *model.Attachment) *attachmentResponse {
+	return &attachmentResponse{
+		ID:          string(a.ID),
+		TaskID:      string(a.TaskID),
+		UserID:      string(a.UserID),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         fmt.Sprint("/api/v1/attachments/", a.ID),
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *handler) apiFindTaskAttachments(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachmentUsecase.FindByTaskID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := &attachmentListResponse{Attachments: make([]*attachmentResponse, 0, len(attachments))}
+	for _, a := range attachments {
+		res.Attachments = append(res.Attachments, newAttachmentResponse(a))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiUploadAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	f, header, err := uploadedFile(w, r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", err.Error())
+
+		return
+	}
+	defer f.Close()
+
+	a, err := h.attachmentUsecase.Upload(*s, model.TaskID(ps.ByName("id")), header.Filename, header.Size, f)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := newAttachmentResponse(a)
+	w.Header().Set("Location", res.URL)
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func (h *handler) apiDownloadAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	a, f, err := h.attachmentUsecase.Download(*s, model.AttachmentID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	serveAttachment(w, a, f)
+}
+
+func (h *handler) apiDeleteAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentUsecase.Delete(*s, model.AttachmentID(ps.ByName("id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
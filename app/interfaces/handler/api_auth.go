@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type credentialRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type sessionResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+func (h *handler) apiSignUp(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req credentialRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := h.userUsecase.SignUp(req.Email, req.Password); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, nil)
+}
+
+func (h *handler) apiLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req credentialRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	id, err := h.userUsecase.Authenticate(req.Email, req.Password)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	session, err := h.sessionUsecase.CreateSession(id)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &sessionResponse{
+		ID:        string(session.ID),
+		UserID:    string(session.UserID),
+		ExpiredAt: session.ExpiredAt,
+	})
+}
+
+func (h *handler) apiLogout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionUsecase.DeleteSession(s.UserID); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiAuthorize resolves the session from a bearer token, falling back to the cookie used by the HTML pages.
+// It writes an unauthorized response and returns false when there is no valid session.
+func (h *handler) apiAuthorize(w http.ResponseWriter, r *http.Request) (*usecase.Session, bool) {
+	var (
+		s   *usecase.Session
+		err error
+	)
+
+	if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); token != r.Header.Get("Authorization") {
+		s, err = h.sessionUsecase.Verify(usecase.SessionID(token))
+	} else {
+		s, err = h.session(r)
+	}
+
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return nil, false
+	} else if s == nil {
+		writeAPIError(w, http.StatusUnauthorized, "unauthenticated", "valid session is required")
+
+		return nil, false
+	}
+
+	return s, true
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type commentRequest struct {
+	ParentID string `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+type commentResponse struct {
+	ID          string             `json:"id"`
+	TaskID      string             `json:"task_id"`
+	UserID      string             `json:"user_id"`
+	AuthorEmail string             `json:"author_email,omitempty"`
+	ParentID    *string            `json:"parent_id"`
+	Body        string             `json:"body"`
+	Edited      bool               `json:"edited"`
+	Deleted     bool               `json:"deleted"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+	Replies     []*commentResponse `json:"replies,omitempty"`
+}
+
+type commentListResponse struct {
+	Comments []*commentResponse `json:"comments"`
+}
+
+func newCommentResponse(c *model.Comment, author *model.User) *commentResponse {
+	res := &commentResponse{
+		ID:        string(c.ID),
+		TaskID:    string(c.TaskID),
+		UserID:    string(c.UserID),
+		Body:      c.Body,
+		Edited:    c.IsEdited(),
+		Deleted:   c.IsDeleted(),
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if c.ParentID != nil {
+		p := string(*c.ParentID)
+		res.ParentID = &p
+	}
+
+	if author != nil {
+		res.AuthorEmail = string(author.Email)
+	}
+
+	return res
+}
+
+func newCommentThreadResponses(d *model.Discussion, threads []*model.CommentThread) []*commentResponse {
+	res := make([]*commentResponse, 0, len(threads))
+	for _, t := range threads {
+		c := newCommentResponse(t.Comment, d.Users[t.Comment.UserID])
+		c.Replies = newCommentThreadResponses(d, t.Replies)
+		res = append(res, c)
+	}
+
+	return res
+}
+
+func (h *handler) apiFindTaskComments(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	discussion, err := h.commentUsecase.FindByTaskID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &commentListResponse{Comments: newCommentThreadResponses(discussion, discussion.Threads)})
+}
+
+func (h *handler) apiCreateComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req commentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	comment, err := h.commentUsecase.Create(*s, model.TaskID(ps.ByName("id")), model.CommentID(req.ParentID), req.Body)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint("/api/v1/comments/", comment.ID))
+	writeJSON(w, http.StatusCreated, newCommentResponse(comment, nil))
+}
+
+func (h *handler) apiUpdateComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req commentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	comment, err := h.commentUsecase.Update(*s, model.CommentID(ps.ByName("id")), req.Body)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newCommentResponse(comment, nil))
+}
+
+func (h *handler) apiDeleteComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.commentUsecase.Delete(*s, model.CommentID(ps.ByName("id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type dependencyResponse struct {
+	Blocked   bool            `json:"blocked"`
+	BlockedBy []*taskResponse `json:"blocked_by"`
+	Blocking  []*taskResponse `json:"blocking"`
+}
+
+func (h *handler) apiFindTaskDependencies(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	dependencies, err := h.dependencyUsecase.FindByTaskID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &dependencyResponse{
+		Blocked:   dependencies.Blocked,
+		BlockedBy: newTaskListResponse(dependencies.BlockedBy).Tasks,
+		Blocking:  newTaskListResponse(dependencies.Blocking).Tasks,
+	})
+}
+
+func (h *handler) apiAddBlocker(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.dependencyUsecase.Add(*s, model.TaskID(ps.ByName("id")), model.TaskID(ps.ByName("blocker_id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) apiRemoveBlocker(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.dependencyUsecase.Remove(*s, model.TaskID(ps.ByName("id")), model.TaskID(ps.ByName("blocker_id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
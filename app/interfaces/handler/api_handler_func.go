@@ -0,0 +1,496 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type taskRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ParentID    string `json:"parent_id"`
+	Name        string `json:"name"`
+	Detail      string `json:"detail"`
+	Status      string `json:"status"`
+	Deadline    string `json:"deadline"`
+	// DueTime is the optional time of the day of the deadline, and TimeZone defaults to the one of the user.
+	DueTime    string `json:"due_time"`
+	TimeZone   string `json:"time_zone"`
+	Recurrence string `json:"recurrence"`
+	Priority   string `json:"priority"`
+	Cascade    bool   `json:"cascade"`
+	// Version is the version of the task which the update is based on. It is required to update a task.
+	Version *int `json:"version"`
+}
+
+type completeRequest struct {
+	Cascade bool `json:"cascade"`
+}
+
+type taskResponse struct {
+	ID                string  `json:"id"`
+	UserID            string  `json:"user_id"`
+	AssigneeID        string  `json:"assignee_id"`
+	WorkspaceID       string  `json:"workspace_id"`
+	ParentID          *string `json:"parent_id"`
+	Name              string  `json:"name"`
+	Detail            string  `json:"detail"`
+	Status            string  `json:"status"`
+	CompletionDate    *string `json:"completion_date"`
+	Deadline          string  `json:"deadline"`
+	DueTime           *string `json:"due_time"`
+	TimeZone          string  `json:"time_zone"`
+	Recurrence        string  `json:"recurrence"`
+	Priority          string  `json:"priority"`
+	NotificationCount int     `json:"notification_count"`
+	PostponedCount    int     `json:"postponed_count"`
+	Visibility        string  `json:"visibility"`
+	ShareToken        string  `json:"share_token,omitempty"`
+	Archived          bool    `json:"archived"`
+	Trashed           bool    `json:"trashed"`
+	CreatedAt         string  `json:"created_at"`
+	Version           int     `json:"version"`
+}
+
+type shareRequest struct {
+	Visibility string   `json:"visibility"`
+	Emails     []string `json:"emails"`
+}
+
+type assignRequest struct {
+	Email string `json:"email"`
+}
+
+type taskListResponse struct {
+	Tasks      []*taskResponse `json:"tasks"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type taskTreeResponse struct {
+	*taskResponse
+	Progress int                 `json:"progress"`
+	Subtasks []*taskTreeResponse `json:"subtasks"`
+}
+
+func newTaskResponse(t *model.Task) *taskResponse {
+	res := &taskResponse{
+		ID:                string(t.ID),
+		UserID:            string(t.UserID),
+		AssigneeID:        string(t.AssigneeID),
+		WorkspaceID:       string(t.WorkspaceID),
+		Name:              t.Name,
+		Detail:            t.Detail,
+		Status:            t.Status.String(),
+		Deadline:          t.Due().At.Format(timeLayout),
+		TimeZone:          t.Location().String(),
+		Recurrence:        string(t.Recurrence),
+		Priority:          t.Priority.String(),
+		NotificationCount: t.NotificationCount,
+		PostponedCount:    t.PostponedCount,
+		Visibility:        t.Visibility.String(),
+		ShareToken:        t.ShareToken,
+		Archived:          t.IsArchived(),
+		Trashed:           t.IsTrashed(),
+		CreatedAt:         t.CreatedAt.Format(time.RFC3339),
+		Version:           t.Version,
+	}
+
+	if t.ParentID != nil {
+		parentID := string(*t.ParentID)
+		res.ParentID = &parentID
+	}
+
+	if t.HasDueTime {
+		dueTime := t.Due().At.Format(dueTimeLayout)
+		res.DueTime = &dueTime
+	}
+
+	if t.CompletionDate != nil {
+		d := t.CompletionDate.Format(timeLayout)
+		res.CompletionDate = &d
+	}
+
+	return res
+}
+
+func newTaskTreeResponse(tree *model.TaskTree) *taskTreeResponse {
+	res := &taskTreeResponse{
+		taskResponse: newTaskResponse(tree.Task),
+		Progress:     tree.Progress(),
+		Subtasks:     make([]*taskTreeResponse, 0, len(tree.Children)),
+	}
+
+	for _, c := range tree.Children {
+		res.Subtasks = append(res.Subtasks, newTaskTreeResponse(c))
+	}
+
+	return res
+}
+
+func newTaskListResponse(tasks []*model.Task) *taskListResponse {
+	res := &taskListResponse{Tasks: make([]*taskResponse, 0, len(tasks))}
+	for _, t := range tasks {
+		res.Tasks = append(res.Tasks, newTaskResponse(t))
+	}
+
+	return res
+}
+
+func (h *handler) apiFindAllTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	q, err := parseTaskQuery(*s, r.URL.Query(), loc)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	page, err := h.taskUsecase.Find(*s, q)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := newTaskListResponse(page.Tasks)
+	res.NextCursor = page.NextCursor
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiCreateTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req taskRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	due, err := parseDue(req.Deadline, req.DueTime, req.TimeZone, loc)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	recurrence, err := model.ParseRecurrence(req.Recurrence)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	priority, err := model.ParsePriority(req.Priority)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	var task *model.Task
+	if req.ParentID == "" {
+		task, err = h.taskUsecase.Create(*s, model.WorkspaceID(req.WorkspaceID), req.Name, req.Detail, due, recurrence, priority)
+	} else {
+		task, err = h.taskUsecase.CreateSubtask(*s, model.TaskID(req.ParentID), req.Name, req.Detail, due, recurrence, priority)
+	}
+
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint("/api/v1/tasks/", task.ID))
+	writeJSON(w, http.StatusCreated, newTaskResponse(task))
+}
+
+func (h *handler) apiFindTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.taskUsecase.FindByID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *handler) apiUpdateTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req taskRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	status, err := model.ParseStatus(req.Status)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	due, err := parseDue(req.Deadline, req.DueTime, req.TimeZone, loc)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	recurrence, err := model.ParseRecurrence(req.Recurrence)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	priority, err := model.ParsePriority(req.Priority)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	if req.Version == nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", "version is required")
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+	version := *req.Version
+
+	if err := h.taskUsecase.Update(*s, id, req.Name, req.Detail, status, due, recurrence, priority, req.Cascade, version); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	task, err := h.taskUsecase.FindByID(*s, id)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *handler) apiShareTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req shareRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	visibility, err := model.ParseVisibility(req.Visibility)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	task, err := h.taskUsecase.Share(*s, model.TaskID(ps.ByName("id")), visibility, req.Emails)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *handler) apiAssignTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req assignRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	task, err := h.taskUsecase.Assign(*s, model.TaskID(ps.ByName("id")), req.Email)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *handler) apiFindPublicTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	task, err := h.taskUsecase.FindByShareToken(ps.ByName("token"))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *handler) apiFindArchivedTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskUsecase.FindArchived(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskListResponse(tasks))
+}
+
+func (h *handler) apiFindTrashedTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskUsecase.FindTrashed(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskListResponse(tasks))
+}
+
+func (h *handler) apiDeleteTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taskUsecase.Delete(*s, model.TaskID(ps.ByName("id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiChangeTask returns a handler which applies action to the task and responds with the changed task.
+func (h *handler) apiChangeTask(action func(usecase.Session, model.TaskID) error) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		s, ok := h.apiAuthorize(w, r)
+		if !ok {
+			return
+		}
+
+		id := model.TaskID(ps.ByName("id"))
+
+		if err := action(*s, id); err != nil {
+			apiErrorResponse(w, err)
+
+			return
+		}
+
+		task, err := h.taskUsecase.FindByID(*s, id)
+		if err != nil {
+			apiErrorResponse(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newTaskResponse(task))
+	}
+}
+
+func (h *handler) apiFindTaskTree(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	tree, err := h.taskUsecase.FindTree(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskTreeResponse(tree))
+}
+
+func (h *handler) apiCompleteTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req completeRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &req) {
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	if _, err := h.taskUsecase.Complete(*s, id, req.Cascade, nil); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	task, err := h.taskUsecase.FindByID(*s, id)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type fieldChangeResponse struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type historyResponse struct {
+	ID        string                 `json:"id"`
+	TaskID    string                 `json:"task_id"`
+	UserID    *string                `json:"user_id"`
+	UserEmail *string                `json:"user_email,omitempty"`
+	Action    string                 `json:"action"`
+	Changes   []*fieldChangeResponse `json:"changes"`
+	CreatedAt string                 `json:"created_at"`
+}
+
+type historyListResponse struct {
+	History []*historyResponse `json:"history"`
+}
+
+// newHistoryResponse converts the history. The user ID is null for changes made by the system.
+func newHistoryResponse(h *model.TaskHistory, users map[model.UserID]*model.User) *historyResponse {
+	res := &historyResponse{
+		ID:        string(h.ID),
+		TaskID:    string(h.TaskID),
+		Action:    string(h.Action),
+		Changes:   make([]*fieldChangeResponse, 0, len(h.Changes)),
+		CreatedAt: h.CreatedAt.Format(time.RFC3339),
+	}
+
+	if !h.IsBySystem() {
+		id := string(h.UserID)
+		res.UserID = &id
+	}
+
+	if u := users[h.UserID]; u != nil {
+		email := string(u.Email)
+		res.UserEmail = &email
+	}
+
+	for _, c := range h.Changes {
+		res.Changes = append(res.Changes, &fieldChangeResponse{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return res
+}
+
+func newHistoryListResponse(histories []*model.TaskHistory, users map[model.UserID]*model.User) *historyListResponse {
+	res := &historyListResponse{History: make([]*historyResponse, 0, len(histories))}
+	for _, h := range histories {
+		res.History = append(res.History, newHistoryResponse(h, users))
+	}
+
+	return res
+}
+
+func (h *handler) apiFindTaskHistory(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	timeline, err := h.taskUsecase.FindHistory(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newHistoryListResponse(timeline.Histories, timeline.Users))
+}
+
+func (h *handler) apiFindUserHistory(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	histories, err := h.taskUsecase.FindHistoryByUser(*s, limit)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newHistoryListResponse(histories, nil))
+}
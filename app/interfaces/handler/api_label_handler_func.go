@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type labelRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type taskLabelsRequest struct {
+	LabelIDs []string `json:"label_ids"`
+}
+
+type labelResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type labelListResponse struct {
+	Labels []*labelResponse `json:"labels"`
+}
+
+func newLabelResponse(l *model.Label) *labelResponse {
+	return &labelResponse{
+		ID:    string(l.ID),
+		Name:  l.Name,
+		Color: l.Color,
+	}
+}
+
+func newLabelListResponse(labels []*model.Label) *labelListResponse {
+	res := &labelListResponse{Labels: make([]*labelResponse, 0, len(labels))}
+	for _, l := range labels {
+		res.Labels = append(res.Labels, newLabelResponse(l))
+	}
+
+	return res
+}
+
+func (h *handler) apiFindAllLabel(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	labels, err := h.labelUsecase.FindByUserID(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLabelListResponse(labels))
+}
+
+func (h *handler) apiCreateLabel(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req labelRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	label, err := h.labelUsecase.Create(*s, req.Name, req.Color)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint("/api/v1/labels/", label.ID))
+	writeJSON(w, http.StatusCreated, newLabelResponse(label))
+}
+
+func (h *handler) apiUpdateLabel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req labelRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	id := model.LabelID(ps.ByName("id"))
+
+	if err := h.labelUsecase.Update(*s, id, req.Name, req.Color); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	label, err := h.labelUsecase.FindByID(*s, id)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLabelResponse(label))
+}
+
+func (h *handler) apiDeleteLabel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.labelUsecase.Delete(*s, model.LabelID(ps.ByName("id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) apiFindTaskLabels(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeTaskLabels(w, *s, model.TaskID(ps.ByName("id")))
+}
+
+func (h *handler) apiSetTaskLabels(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req taskLabelsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	if err := h.taskUsecase.SetLabels(*s, id, parseLabelIDs(req.LabelIDs)); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	h.writeTaskLabels(w, *s, id)
+}
+
+// writeTaskLabels responds with the labels of the session user attached to the task.
+func (h *handler) writeTaskLabels(w http.ResponseWriter, s usecase.Session, id model.TaskID) {
+	task, err := h.taskUsecase.FindByID(s, id)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	labels, err := h.taskUsecase.FindLabels(s, []*model.Task{task})
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newLabelListResponse(labels[id]))
+}
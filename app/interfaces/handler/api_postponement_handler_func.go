@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type postponementRequest struct {
+	Deadline string `json:"deadline"`
+	DueTime  string `json:"due_time"`
+	TimeZone string `json:"time_zone"`
+	Reason   string `json:"reason"`
+}
+
+type decisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+type postponementResponse struct {
+	ID          string  `json:"id"`
+	TaskID      string  `json:"task_id"`
+	RequesterID string  `json:"requester_id"`
+	ApproverID  string  `json:"approver_id"`
+	Deadline    string  `json:"deadline"`
+	DueTime     *string `json:"due_time"`
+	TimeZone    string  `json:"time_zone"`
+	Reason      string  `json:"reason"`
+	Status      string  `json:"status"`
+	Comment     string  `json:"comment"`
+	CreatedAt   string  `json:"created_at"`
+	DecidedAt   *string `json:"decided_at"`
+}
+
+type postponementListResponse struct {
+	Postponements []*postponementResponse `json:"postponements"`
+}
+
+func newPostponementResponse(p *model.Postponement) *postponementResponse {
+	res := &postponementResponse{
+		ID:          string(p.ID),
+		TaskID:      string(p.TaskID),
+		RequesterID: string(p.RequesterID),
+		ApproverID:  string(p.ApproverID),
+		Deadline:    p.Due().At.Format(timeLayout),
+		TimeZone:    p.Due().At.Location().String(),
+		Reason:      p.Reason,
+		Status:      p.Status.String(),
+		Comment:     p.Comment,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+	}
+
+	if p.HasDueTime {
+		dueTime := p.Due().At.Format(dueTimeLayout)
+		res.DueTime = &dueTime
+	}
+
+	if p.DecidedAt != nil {
+		d := p.DecidedAt.Format(time.RFC3339)
+		res.DecidedAt = &d
+	}
+
+	return res
+}
+
+func newPostponementListResponse(postponements []*model.Postponement) *postponementListResponse {
+	res := &postponementListResponse{Postponements: make([]*postponementResponse, 0, len(postponements))}
+	for _, p := range postponements {
+		res.Postponements = append(res.Postponements, newPostponementResponse(p))
+	}
+
+	return res
+}
+
+func (h *handler) apiRequestPostponement(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req postponementRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	due, err := parseDue(req.Deadline, req.DueTime, req.TimeZone, loc)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	p, err := h.postponementUsecase.Request(*s, model.TaskID(ps.ByName("id")), due, req.Reason)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newPostponementResponse(p))
+}
+
+func (h *handler) apiFindTaskPostponements(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	postponements, err := h.postponementUsecase.FindByTaskID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPostponementListResponse(postponements))
+}
+
+func (h *handler) apiFindPendingPostponements(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	postponements, err := h.postponementUsecase.FindPending(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPostponementListResponse(postponements))
+}
+
+func (h *handler) apiDecidePostponement(decide func(usecase.Session, model.PostponementID, string) (*model.Postponement, error)) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		s, ok := h.apiAuthorize(w, r)
+		if !ok {
+			return
+		}
+
+		var req decisionRequest
+		if r.ContentLength != 0 && !decodeJSON(w, r, &req) {
+			return
+		}
+
+		p, err := decide(*s, model.PostponementID(ps.ByName("id")), req.Comment)
+		if err != nil {
+			apiErrorResponse(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newPostponementResponse(p))
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type fragmentResponse struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match"`
+}
+
+type taskHitResponse struct {
+	Task   *taskResponse       `json:"task"`
+	Score  float64             `json:"score"`
+	Name   []*fragmentResponse `json:"name"`
+	Detail []*fragmentResponse `json:"detail"`
+}
+
+type taskHitListResponse struct {
+	Hits []*taskHitResponse `json:"hits"`
+}
+
+func newFragmentResponses(fragments []model.Fragment) []*fragmentResponse {
+	res := make([]*fragmentResponse, 0, len(fragments))
+	for _, f := range fragments {
+		res = append(res, &fragmentResponse{Text: f.Text, Match: f.Match})
+	}
+
+	return res
+}
+
+func newTaskHitListResponse(hits []*model.TaskHit) *taskHitListResponse {
+	res := &taskHitListResponse{Hits: make([]*taskHitResponse, 0, len(hits))}
+	for _, h := range hits {
+		res.Hits = append(res.Hits, &taskHitResponse{
+			Task:   newTaskResponse(h.Task),
+			Score:  h.Score,
+			Name:   newFragmentResponses(h.Name),
+			Detail: newFragmentResponses(h.Detail),
+		})
+	}
+
+	return res
+}
+
+func (h *handler) apiSearchTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	hits, err := h.searchUsecase.Search(*s, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskHitListResponse(hits))
+}
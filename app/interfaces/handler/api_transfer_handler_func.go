@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"todo-app/domain/model"
+	"todo-app/interfaces/transfer"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+type importResponse struct {
+	Imported   bool                 `json:"imported"`
+	TaskCount  int                  `json:"task_count"`
+	ErrorCount int                  `json:"error_count"`
+	Rows       []*importRowResponse `json:"rows"`
+}
+
+// importRowResponse is a row of an import with its task, or the error why it cannot be imported.
+type importRowResponse struct {
+	Row   int           `json:"row"`
+	Name  string        `json:"name"`
+	Task  *taskResponse `json:"task,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+func newImportResponse(imp *model.TaskImport, imported bool) *importResponse {
+	res := &importResponse{
+		Imported:   imported,
+		TaskCount:  len(imp.Tasks()),
+		ErrorCount: imp.ErrorCount(),
+		Rows:       make([]*importRowResponse, 0, len(imp.Rows)),
+	}
+
+	for _, row := range imp.Rows {
+		rr := &importRowResponse{Row: row.Number, Name: row.Record.Name}
+		if row.Task != nil {
+			rr.Task = newTaskResponse(row.Task)
+		}
+
+		if row.Err != nil {
+			rr.Error = row.Err.Error()
+		}
+
+		res.Rows = append(res.Rows, rr)
+	}
+
+	return res
+}
+
+// apiFormat returns the format of the query parameter, which defaults to JSON for JSON requests and to CSV otherwise.
+func apiFormat(r *http.Request) (transfer.Format, error) {
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "application/json" {
+			name = string(transfer.JSON)
+		}
+	}
+
+	return transfer.ParseFormat(name)
+}
+
+func (h *handler) apiExportTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := apiFormat(r)
+	if err == nil && !f.Exportable() {
+		err = errors.Errorf("format cannot be exported. format: %s", f)
+	}
+
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", err.Error())
+
+		return
+	}
+
+	records, err := h.transferUsecase.Export(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	b, err := encodeExport(f, records)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	serveExport(w, f, b)
+}
+
+// apiImportTask imports the file in the request body. With dry_run, the rows are only checked like in a preview.
+// Nothing is imported when any row is invalid, and the rows are returned with their errors.
+func (h *handler) apiImportTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := apiFormat(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", err.Error())
+
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "bad_request", "dry_run must be true or false")
+
+			return
+		}
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	_, records, err := readImport(r.Body, f, loc)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", errors.Wrap(err, "failed to decode request body").Error())
+
+		return
+	}
+
+	workspaceID := model.WorkspaceID(r.URL.Query().Get("workspace_id"))
+
+	if dryRun {
+		imp, err := h.transferUsecase.Preview(*s, workspaceID, records)
+		if err != nil {
+			apiErrorResponse(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newImportResponse(imp, false))
+
+		return
+	}
+
+	imp, err := h.transferUsecase.Import(*s, workspaceID, records)
+	if errors.Is(err, usecase.ErrInvalidArgument) && imp != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, newImportResponse(imp, false))
+
+		return
+	} else if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newImportResponse(imp, true))
+}
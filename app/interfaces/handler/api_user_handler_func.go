@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type userRequest struct {
+	TimeZone string `json:"time_zone"`
+}
+
+type userResponse struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	TimeZone string `json:"time_zone"`
+	// CalendarURL is the secret link of the iCalendar feed, omitted while the feed is disabled.
+	CalendarURL string `json:"calendar_url,omitempty"`
+}
+
+func newUserResponse(u *model.User) *userResponse {
+	return &userResponse{
+		ID:          string(u.ID),
+		Email:       string(u.Email),
+		TimeZone:    u.TimeZone,
+		CalendarURL: calendarURL(u),
+	}
+}
+
+func (h *handler) apiFindMe(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userUsecase.Find(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+func (h *handler) apiUpdateMe(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req userRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := h.userUsecase.SetTimeZone(*s, req.TimeZone); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	user, err := h.userUsecase.Find(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+func (h *handler) apiIssueCalendarToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.userUsecase.IssueCalendarToken(*s); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	user, err := h.userUsecase.Find(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+func (h *handler) apiRevokeCalendarToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userUsecase.RevokeCalendarToken(*s); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
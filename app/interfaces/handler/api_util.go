@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+const maxRequestBodySize = 1 << 20
+
+type apiErrorBody struct {
+	Error apiError `json:"error"`
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+var apiErrorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{usecase.ErrNotFound, http.StatusNotFound, "not_found"},
+	{usecase.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{usecase.ErrInvalidArgument, http.StatusUnprocessableEntity, "invalid_argument"},
+	{usecase.ErrConflict, http.StatusConflict, "conflict"},
+	{usecase.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{usecase.ErrApprovalRequired, http.StatusConflict, "approval_required"},
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	if v == nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(errors.Wrap(err, "failed to encode response"))
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, &apiErrorBody{Error: apiError{Code: code, Message: message}})
+}
+
+// apiErrorResponse responds with the generic message of the kind of the error, and only logs the error itself.
+func apiErrorResponse(w http.ResponseWriter, err error) {
+	log.Println(err)
+
+	for _, k := range apiErrorKinds {
+		if errors.Is(err, k.kind) {
+			writeAPIError(w, k.status, k.code, k.kind.Error())
+
+			return
+		}
+	}
+
+	writeAPIError(w, http.StatusInternalServerError, "internal", "internal server error")
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", errors.Wrap(err, "failed to decode request body").Error())
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Active turns the webhook off by an update when it is false, and on when it is omitted. A webhook is created turned on.
+	Active *bool `json:"active"`
+}
+
+type webhookResponse struct {
+	ID          string   `json:"id"`
+	WorkspaceID string   `json:"workspace_id"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	// Secret is the key of the signatures of the deliveries, which only the admins of the workspace can see.
+	Secret    string `json:"secret"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type webhookListResponse struct {
+	Webhooks []*webhookResponse `json:"webhooks"`
+}
+
+type webhookDetailResponse struct {
+	*webhookResponse
+	Deliveries []*deliveryResponse `json:"deliveries"`
+}
+
+type deliveryResponse struct {
+	ID             string `json:"id"`
+	Event          string `json:"event"`
+	TaskID         string `json:"task_id"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	Error          string `json:"error,omitempty"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  string `json:"last_attempt_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func newWebhookResponse(w *model.Webhook) *webhookResponse {
+	res := &webhookResponse{
+		ID:          string(w.ID),
+		WorkspaceID: string(w.WorkspaceID),
+		URL:         w.URL,
+		Events:      make([]string, 0, len(w.EventTypes())),
+		Secret:      w.Secret,
+		Active:      w.Active,
+		CreatedAt:   w.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   w.UpdatedAt.Format(time.RFC3339),
+	}
+
+	for _, t := range w.EventTypes() {
+		res.Events = append(res.Events, string(t))
+	}
+
+	return res
+}
+
+func newDeliveryResponse(d *model.WebhookDelivery) *deliveryResponse {
+	res := &deliveryResponse{
+		ID:             string(d.ID),
+		Event:          string(d.EventType),
+		TaskID:         string(d.TaskID),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+
+	if d.Status == model.DeliveryPending {
+		res.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+
+	if d.LastAttemptAt != nil {
+		res.LastAttemptAt = d.LastAttemptAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func (h *handler) apiFindAllWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookUsecase.FindByWorkspace(*s, model.WorkspaceID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := &webhookListResponse{Webhooks: make([]*webhookResponse, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, newWebhookResponse(webhook))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiCreateWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req webhookRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	webhook, err := h.webhookUsecase.Create(*s, id, req.URL, req.Events)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint("/api/v1/workspaces/", id, "/webhooks/", webhook.ID))
+	writeJSON(w, http.StatusCreated, newWebhookResponse(webhook))
+}
+
+func (h *handler) apiFindWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, deliveries, err := h.webhookUsecase.Find(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := &webhookDetailResponse{
+		webhookResponse: newWebhookResponse(webhook),
+		Deliveries:      make([]*deliveryResponse, 0, len(deliveries)),
+	}
+
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, newDeliveryResponse(d))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiUpdateWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req webhookRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	webhook, err := h.webhookUsecase.Update(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id")),
+		req.URL, req.Events, req.Active == nil || *req.Active)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWebhookResponse(webhook))
+}
+
+func (h *handler) apiRotateWebhookSecret(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookUsecase.RotateSecret(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWebhookResponse(webhook))
+}
+
+func (h *handler) apiDeleteWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookUsecase.Delete(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
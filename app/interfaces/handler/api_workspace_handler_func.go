@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type workspaceRequest struct {
+	Name string `json:"name"`
+}
+
+type roleRequest struct {
+	Role string `json:"role"`
+}
+
+type workspaceResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+type workspaceListResponse struct {
+	Workspaces []*workspaceResponse `json:"workspaces"`
+}
+
+type workspaceDetailResponse struct {
+	*workspaceResponse
+	Role        string                `json:"role"`
+	Members     []*memberResponse     `json:"members"`
+	Invitations []*invitationResponse `json:"invitations,omitempty"`
+}
+
+type memberResponse struct {
+	WorkspaceID string `json:"workspace_id"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type invitationResponse struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	Token       string `json:"token"`
+	URL         string `json:"url"`
+	Role        string `json:"role"`
+	ExpiresAt   string `json:"expires_at"`
+	Accepted    bool   `json:"accepted"`
+}
+
+func newWorkspaceResponse(w *model.Workspace) *workspaceResponse {
+	return &workspaceResponse{
+		ID:        string(w.ID),
+		Name:      w.Name,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newMemberResponse(m *model.Member, u *model.User) *memberResponse {
+	res := &memberResponse{
+		WorkspaceID: string(m.WorkspaceID),
+		UserID:      string(m.UserID),
+		Role:        m.Role.String(),
+		CreatedAt:   m.CreatedAt.Format(time.RFC3339),
+	}
+
+	if u != nil {
+		res.Email = string(u.Email)
+	}
+
+	return res
+}
+
+func newInvitationResponse(i *model.Invitation) *invitationResponse {
+	return &invitationResponse{
+		ID:          string(i.ID),
+		WorkspaceID: string(i.WorkspaceID),
+		Token:       i.Token,
+		URL:         invitationURL(i),
+		Role:        i.Role.String(),
+		ExpiresAt:   i.ExpiresAt.Format(time.RFC3339),
+		Accepted:    i.IsAccepted(),
+	}
+}
+
+func newWorkspaceDetailResponse(d *model.WorkspaceDetail) *workspaceDetailResponse {
+	res := &workspaceDetailResponse{
+		workspaceResponse: newWorkspaceResponse(d.Workspace),
+		Role:              d.Role.String(),
+		Members:           make([]*memberResponse, 0, len(d.Members)),
+	}
+
+	for _, m := range d.Members {
+		res.Members = append(res.Members, newMemberResponse(m, d.Users[m.UserID]))
+	}
+
+	for _, i := range d.Invitations {
+		res.Invitations = append(res.Invitations, newInvitationResponse(i))
+	}
+
+	return res
+}
+
+func (h *handler) apiFindAllWorkspace(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	workspaces, err := h.workspaceUsecase.FindByUser(*s)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := &workspaceListResponse{Workspaces: make([]*workspaceResponse, 0, len(workspaces))}
+	for _, workspace := range workspaces {
+		res.Workspaces = append(res.Workspaces, newWorkspaceResponse(workspace))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiCreateWorkspace(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req workspaceRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.Create(*s, req.Name)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint("/api/v1/workspaces/", workspace.ID))
+	writeJSON(w, http.StatusCreated, newWorkspaceResponse(workspace))
+}
+
+func (h *handler) apiFindWorkspace(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.FindByID(*s, model.WorkspaceID(ps.ByName("id")))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWorkspaceDetailResponse(workspace))
+}
+
+func (h *handler) apiInviteWorkspace(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req roleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	role, err := model.ParseRole(req.Role)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	invitation, err := h.workspaceUsecase.Invite(*s, model.WorkspaceID(ps.ByName("id")), role)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newInvitationResponse(invitation))
+}
+
+func (h *handler) apiUpdateMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	var req roleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	role, err := model.ParseRole(req.Role)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_argument", err.Error())
+
+		return
+	}
+
+	member, err := h.workspaceUsecase.ChangeRole(*s, model.WorkspaceID(ps.ByName("id")), model.UserID(ps.ByName("user_id")), role)
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newMemberResponse(member, nil))
+}
+
+func (h *handler) apiRemoveMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceUsecase.RemoveMember(*s, model.WorkspaceID(ps.ByName("id")), model.UserID(ps.ByName("user_id"))); err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) apiFindInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	invitation, workspace, err := h.workspaceUsecase.FindInvitation(*s, ps.ByName("token"))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	res := &struct {
+		*invitationResponse
+		Workspace *workspaceResponse `json:"workspace"`
+	}{newInvitationResponse(invitation), newWorkspaceResponse(workspace)}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) apiAcceptInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, ok := h.apiAuthorize(w, r)
+	if !ok {
+		return
+	}
+
+	member, err := h.workspaceUsecase.Accept(*s, ps.ByName("token"))
+	if err != nil {
+		apiErrorResponse(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newMemberResponse(member, nil))
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxUploadSize leaves room for the rest of the multipart form besides the file.
+	maxUploadSize = model.MaxAttachmentSize + 1<<20
+	// maxUploadMemory is the part of an upload kept in memory, the rest is buffered in temporary files.
+	maxUploadMemory = 1 << 20
+)
+
+// attachmentView is an attachment and whether the session user can delete it.
+type attachmentView struct {
+	*model.Attachment
+	Deletable bool
+}
+
+func newAttachmentViews(attachments []*model.Attachment, userID model.UserID, access model.TaskAccess) []*attachmentView {
+	views := make([]*attachmentView, 0, len(attachments))
+	for _, a := range attachments {
+		views = append(views, &attachmentView{Attachment: a, Deletable: model.AttachmentDeletable(*a, userID, access)})
+	}
+
+	return views
+}
+
+// formatSize formats the size of a file in bytes for people.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func attachmentsURL(taskID string) string {
+	return fmt.Sprint("/tasks/show/", taskID, "#attachments")
+}
+
+// uploadedFile reads the file sent in the field "file" of a multipart form.
+func uploadedFile(w http.ResponseWriter, r *http.Request) (multipart.File, *multipart.FileHeader, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read upload, which has to be a file of at most %d MB", model.MaxAttachmentSize>>20)
+	}
+
+	f, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read file")
+	}
+
+	return f, header, nil
+}
+
+// serveAttachment sends the file of the attachment as a download.
+// The stored content type is sent without sniffing, so that a file is never run as a page of this site.
+func serveAttachment(w http.ResponseWriter, a *model.Attachment, f io.ReadCloser) {
+	defer f.Close()
+
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := io.Copy(w, f); err != nil {
+		log.Println(errors.Wrapf(err, "failed to send file of attachment %s", a.ID))
+	}
+}
+
+func (h *handler) uploadAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	f, header, err := uploadedFile(w, r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+	defer f.Close()
+
+	if _, err := h.attachmentUsecase.Upload(*s, model.TaskID(ps.ByName("id")), header.Filename, header.Size, f); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, attachmentsURL(ps.ByName("id")), http.StatusFound)
+}
+
+func (h *handler) downloadAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	a, f, err := h.attachmentUsecase.Download(*s, model.AttachmentID(ps.ByName("attachment_id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if a.TaskID != model.TaskID(ps.ByName("id")) {
+		f.Close()
+		errorResponse(w, r, errors.Wrapf(usecase.ErrNotFound, "attachment is not found, attachmentID: %s", a.ID))
+
+		return
+	}
+
+	serveAttachment(w, a, f)
+}
+
+func (h *handler) deleteAttachment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := h.attachmentUsecase.Delete(*s, model.AttachmentID(ps.ByName("attachment_id"))); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, attachmentsURL(ps.ByName("id")), http.StatusFound)
+}
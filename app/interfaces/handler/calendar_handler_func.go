@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/interfaces/ical"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// calendarURL is the link of the iCalendar feed of the user, or an empty string while the feed is disabled.
+func calendarURL(u *model.User) string {
+	if u.CalendarToken == "" {
+		return ""
+	}
+
+	return fmt.Sprint("/calendar/", u.CalendarToken, ".ics")
+}
+
+// findCalendar serves the iCalendar feed. Calendar apps cannot follow the error page, so errors are plain statuses.
+func (h *handler) findCalendar(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	token := strings.TrimSuffix(ps.ByName("token"), ".ics")
+
+	user, tasks, err := h.taskUsecase.FindByCalendarToken(token)
+	if errors.Is(err, usecase.ErrNotFound) {
+		http.NotFound(w, r)
+
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	calendar := ical.NewCalendar(fmt.Sprint("Tasks of ", user.Email), user.TimeZone, tasks, time.Now())
+
+	var b bytes.Buffer
+	if err := ical.Encode(&b, calendar); err != nil {
+		log.Println(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if _, err := b.WriteTo(w); err != nil {
+		log.Println(errors.Wrap(err, "failed to write calendar"))
+	}
+}
+
+func (h *handler) issueCalendarToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if _, err := h.userUsecase.IssueCalendarToken(*s); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/settings", http.StatusFound)
+}
+
+func (h *handler) revokeCalendarToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := h.userUsecase.RevokeCalendarToken(*s); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/settings", http.StatusFound)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// commentView is a comment with its author and the replies to it, and what the session user can do with it.
+type commentView struct {
+	*model.Comment
+	Author    *model.User
+	Editable  bool
+	Deletable bool
+	Replies   []*commentView
+}
+
+func newCommentViews(d *model.Discussion, threads []*model.CommentThread, userID model.UserID) []*commentView {
+	views := make([]*commentView, 0, len(threads))
+
+	for _, t := range threads {
+		c := t.Comment
+		views = append(views, &commentView{
+			Comment:   c,
+			Author:    d.Users[c.UserID],
+			Editable:  !c.IsDeleted() && c.IsWrittenBy(userID),
+			Deletable: !c.IsDeleted() && model.CommentModeratable(*c, userID, d.Access),
+			Replies:   newCommentViews(d, t.Replies, userID),
+		})
+	}
+
+	return views
+}
+
+func commentsURL(taskID string) string {
+	return fmt.Sprint("/tasks/show/", taskID, "#comments")
+}
+
+func (h *handler) createComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.handleComment(w, r, ps, func(s usecase.Session) error {
+		_, err := h.commentUsecase.Create(s, model.TaskID(ps.ByName("id")), model.CommentID(r.PostFormValue("parent_id")), r.PostFormValue("body"))
+
+		return err
+	})
+}
+
+func (h *handler) updateComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.handleComment(w, r, ps, func(s usecase.Session) error {
+		_, err := h.commentUsecase.Update(s, model.CommentID(ps.ByName("comment_id")), r.PostFormValue("body"))
+
+		return err
+	})
+}
+
+func (h *handler) deleteComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.handleComment(w, r, ps, func(s usecase.Session) error {
+		return h.commentUsecase.Delete(s, model.CommentID(ps.ByName("comment_id")))
+	})
+}
+
+// handleComment applies f to the comments of the task and goes back to them.
+func (h *handler) handleComment(w http.ResponseWriter, r *http.Request, ps httprouter.Params, f func(usecase.Session) error) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if err := f(*s); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, commentsURL(ps.ByName("id")), http.StatusFound)
+}
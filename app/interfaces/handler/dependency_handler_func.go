@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// blockerCandidates returns the tasks which can be added as blockers of the task, i.e. the other tasks which do not block it yet.
+func blockerCandidates(t *model.Task, d *model.TaskDependencies, tasks []*model.Task) []*model.Task {
+	excluded := map[model.TaskID]bool{t.ID: true}
+	for _, b := range d.BlockedBy {
+		excluded[b.ID] = true
+	}
+
+	var candidates []*model.Task
+
+	for _, c := range tasks {
+		if !excluded[c.ID] {
+			candidates = append(candidates, c)
+		}
+	}
+
+	return candidates
+}
+
+func dependenciesURL(taskID string) string {
+	return fmt.Sprint("/tasks/show/", taskID, "#dependencies")
+}
+
+func (h *handler) addBlocker(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.handleDependency(w, r, ps, func(s usecase.Session) error {
+		return h.dependencyUsecase.Add(s, model.TaskID(ps.ByName("id")), model.TaskID(r.PostFormValue("blocker_id")))
+	})
+}
+
+func (h *handler) removeBlocker(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.handleDependency(w, r, ps, func(s usecase.Session) error {
+		return h.dependencyUsecase.Remove(s, model.TaskID(ps.ByName("id")), model.TaskID(ps.ByName("blocker_id")))
+	})
+}
+
+// handleDependency applies f to the dependencies of the task and goes back to them.
+func (h *handler) handleDependency(w http.ResponseWriter, r *http.Request, ps httprouter.Params, f func(usecase.Session) error) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if err := f(*s); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, dependenciesURL(ps.ByName("id")), http.StatusFound)
+}
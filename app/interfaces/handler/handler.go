@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"time"
+	"todo-app/interfaces/caldav"
 	"todo-app/usecase"
 
 	"github.com/julienschmidt/httprouter"
@@ -16,17 +17,35 @@ type Handler interface {
 }
 
 type handler struct {
-	taskUsecase    usecase.TaskUsecase
-	userUsecase    usecase.UserUsecase
-	sessionUsecase usecase.SessionUsecase
-	server         *http.Server
+	taskUsecase         usecase.TaskUsecase
+	userUsecase         usecase.UserUsecase
+	sessionUsecase      usecase.SessionUsecase
+	labelUsecase        usecase.LabelUsecase
+	searchUsecase       usecase.SearchUsecase
+	postponementUsecase usecase.PostponementUsecase
+	workspaceUsecase    usecase.WorkspaceUsecase
+	commentUsecase      usecase.CommentUsecase
+	attachmentUsecase   usecase.AttachmentUsecase
+	dependencyUsecase   usecase.DependencyUsecase
+	transferUsecase     usecase.TransferUsecase
+	webhookUsecase      usecase.WebhookUsecase
+	server              *http.Server
 }
 
-func NewHandler(tu usecase.TaskUsecase, uu usecase.UserUsecase, su usecase.SessionUsecase) Handler {
+func NewHandler(tu usecase.TaskUsecase, uu usecase.UserUsecase, su usecase.SessionUsecase, lu usecase.LabelUsecase, seu usecase.SearchUsecase, pu usecase.PostponementUsecase, wu usecase.WorkspaceUsecase, cu usecase.CommentUsecase, au usecase.AttachmentUsecase, du usecase.DependencyUsecase, tru usecase.TransferUsecase, whu usecase.WebhookUsecase) Handler {
 	h := &handler{
-		taskUsecase:    tu,
-		userUsecase:    uu,
-		sessionUsecase: su,
+		taskUsecase:         tu,
+		userUsecase:         uu,
+		sessionUsecase:      su,
+		labelUsecase:        lu,
+		searchUsecase:       seu,
+		postponementUsecase: pu,
+		workspaceUsecase:    wu,
+		commentUsecase:      cu,
+		attachmentUsecase:   au,
+		dependencyUsecase:   du,
+		transferUsecase:     tru,
+		webhookUsecase:      whu,
 	}
 
 	h.setupServer()
@@ -58,10 +77,83 @@ func (h *handler) setupServer() {
 	router.GET("/", h.home)
 	router.GET("/tasks", h.findAllTask)
 	router.GET("/tasks/new", h.newTask)
+	router.GET("/tasks/archived", h.findArchivedTask)
+	router.GET("/tasks/trash", h.findTrashedTask)
+	router.GET("/tasks/search", h.searchTask)
+	router.GET("/tasks/export", h.exportTask)
+	router.GET("/tasks/import", h.newImport)
+	router.POST("/tasks/import", h.previewImport)
+	router.POST("/tasks/import/commit", h.commitImport)
 	router.POST("/tasks", h.createTask)
 	router.GET("/tasks/show/:id", h.findTask)
 	router.GET("/tasks/show/:id/edit", h.editTask)
 	router.POST("/tasks/show/:id", h.updateTask)
+	router.GET("/tasks/show/:id/share", h.shareTask)
+	router.POST("/tasks/show/:id/share", h.updateShare)
+	router.POST("/tasks/show/:id/assign", h.assignTask)
+	router.POST("/tasks/show/:id/subtasks", h.createSubtask)
+	router.POST("/tasks/show/:id/complete", h.completeTask)
+	router.POST("/tasks/show/:id/reopen", h.reopenTask)
+	router.POST("/tasks/show/:id/archive", h.changeTask(h.taskUsecase.Archive, "/tasks/archived"))
+	router.POST("/tasks/show/:id/unarchive", h.changeTask(h.taskUsecase.Unarchive, "/tasks/show/:id"))
+	router.POST("/tasks/show/:id/trash", h.changeTask(h.taskUsecase.Trash, "/tasks/trash"))
+	router.POST("/tasks/show/:id/restore", h.changeTask(h.taskUsecase.Restore, "/tasks/show/:id"))
+	router.POST("/tasks/show/:id/delete", h.changeTask(h.taskUsecase.Delete, "/tasks/trash"))
+	router.POST("/tasks/show/:id/labels", h.updateTaskLabels)
+	router.GET("/tasks/show/:id/postpone", h.newPostponement)
+	router.POST("/tasks/show/:id/postpone", h.requestPostponement)
+	router.POST("/tasks/show/:id/comments", h.createComment)
+	router.POST("/tasks/show/:id/comments/:comment_id", h.updateComment)
+	router.POST("/tasks/show/:id/comments/:comment_id/delete", h.deleteComment)
+	router.POST("/tasks/show/:id/attachments", h.uploadAttachment)
+	router.GET("/tasks/show/:id/attachments/:attachment_id", h.downloadAttachment)
+	router.POST("/tasks/show/:id/attachments/:attachment_id/delete", h.deleteAttachment)
+	router.POST("/tasks/show/:id/blockers", h.addBlocker)
+	router.POST("/tasks/show/:id/blockers/:blocker_id/delete", h.removeBlocker)
+
+	router.GET("/postponements", h.findPendingPostponement)
+	router.POST("/postponements/:id/approve", h.decidePostponement(h.postponementUsecase.Approve))
+	router.POST("/postponements/:id/reject", h.decidePostponement(h.postponementUsecase.Reject))
+
+	router.GET("/workspaces", h.findAllWorkspace)
+	router.POST("/workspaces", h.createWorkspace)
+	router.GET("/workspaces/:id", h.findWorkspace)
+	router.POST("/workspaces/:id/invitations", h.inviteWorkspace)
+	router.POST("/workspaces/:id/members/:user_id", h.updateMember)
+	router.POST("/workspaces/:id/members/:user_id/remove", h.removeMember)
+	router.GET("/workspaces/:id/webhooks", h.findAllWebhook)
+	router.POST("/workspaces/:id/webhooks", h.createWebhook)
+	router.GET("/workspaces/:id/webhooks/:webhook_id", h.findWebhook)
+	router.POST("/workspaces/:id/webhooks/:webhook_id", h.updateWebhook)
+	router.POST("/workspaces/:id/webhooks/:webhook_id/secret", h.rotateWebhookSecret)
+	router.POST("/workspaces/:id/webhooks/:webhook_id/delete", h.deleteWebhook)
+
+	router.GET("/invitations/:token", h.findInvitation)
+	router.POST("/invitations/:token/accept", h.acceptInvitation)
+
+	router.GET("/labels", h.findAllLabel)
+	router.POST("/labels", h.createLabel)
+	router.GET("/labels/:id/edit", h.editLabel)
+	router.POST("/labels/:id", h.updateLabel)
+	router.POST("/labels/:id/delete", h.deleteLabel)
+
+	router.GET("/settings", h.findSettings)
+	router.POST("/settings", h.updateSettings)
+	router.POST("/settings/calendar", h.issueCalendarToken)
+	router.POST("/settings/calendar/delete", h.revokeCalendarToken)
+
+	router.GET("/calendar/:token", h.findCalendar)
+
+	dav := caldav.NewHandler(h.taskUsecase, h.userUsecase)
+	for _, method := range caldav.Methods {
+		router.Handler(method, caldav.Prefix+"*path", dav)
+	}
+
+	// INFO: clients discover the server from the well-known URI of RFC 6764
+	router.Handler(http.MethodGet, "/.well-known/caldav", http.RedirectHandler(caldav.Prefix, http.StatusMovedPermanently))
+	router.Handler("PROPFIND", "/.well-known/caldav", http.RedirectHandler(caldav.Prefix, http.StatusMovedPermanently))
+
+	router.GET("/public/tasks/:token", h.findPublicTask)
 
 	router.GET("/signup", h.signUp)
 	router.POST("/signup", h.signupUser)
@@ -72,6 +164,78 @@ func (h *handler) setupServer() {
 
 	router.GET("/err", h.err)
 
+	router.POST("/api/v1/users", h.apiSignUp)
+	router.GET("/api/v1/users/me", h.apiFindMe)
+	router.PUT("/api/v1/users/me", h.apiUpdateMe)
+	router.PUT("/api/v1/users/me/calendar", h.apiIssueCalendarToken)
+	router.DELETE("/api/v1/users/me/calendar", h.apiRevokeCalendarToken)
+	router.POST("/api/v1/sessions", h.apiLogin)
+	router.DELETE("/api/v1/sessions", h.apiLogout)
+
+	router.GET("/api/v1/tasks", h.apiFindAllTask)
+	router.POST("/api/v1/tasks", h.apiCreateTask)
+	router.GET("/api/v1/tasks/:id", h.apiFindTask)
+	router.PUT("/api/v1/tasks/:id", h.apiUpdateTask)
+	router.DELETE("/api/v1/tasks/:id", h.apiChangeTask(h.taskUsecase.Trash))
+	router.PUT("/api/v1/tasks/:id/share", h.apiShareTask)
+	router.PUT("/api/v1/tasks/:id/assignee", h.apiAssignTask)
+	router.GET("/api/v1/tasks/:id/subtasks", h.apiFindTaskTree)
+	router.GET("/api/v1/tasks/:id/history", h.apiFindTaskHistory)
+	router.POST("/api/v1/tasks/:id/complete", h.apiCompleteTask)
+	router.POST("/api/v1/tasks/:id/reopen", h.apiChangeTask(h.taskUsecase.Reopen))
+	router.POST("/api/v1/tasks/:id/archive", h.apiChangeTask(h.taskUsecase.Archive))
+	router.DELETE("/api/v1/tasks/:id/archive", h.apiChangeTask(h.taskUsecase.Unarchive))
+	router.POST("/api/v1/tasks/:id/restore", h.apiChangeTask(h.taskUsecase.Restore))
+
+	router.GET("/api/v1/archive", h.apiFindArchivedTask)
+	router.GET("/api/v1/trash", h.apiFindTrashedTask)
+	router.GET("/api/v1/search", h.apiSearchTask)
+	router.GET("/api/v1/history", h.apiFindUserHistory)
+	router.GET("/api/v1/export", h.apiExportTask)
+	router.POST("/api/v1/import", h.apiImportTask)
+	router.DELETE("/api/v1/trash/:id", h.apiDeleteTask)
+
+	router.GET("/api/v1/public/tasks/:token", h.apiFindPublicTask)
+
+	router.GET("/api/v1/labels", h.apiFindAllLabel)
+	router.POST("/api/v1/labels", h.apiCreateLabel)
+	router.PUT("/api/v1/labels/:id", h.apiUpdateLabel)
+	router.DELETE("/api/v1/labels/:id", h.apiDeleteLabel)
+	router.GET("/api/v1/tasks/:id/labels", h.apiFindTaskLabels)
+	router.PUT("/api/v1/tasks/:id/labels", h.apiSetTaskLabels)
+
+	router.GET("/api/v1/tasks/:id/postponements", h.apiFindTaskPostponements)
+	router.POST("/api/v1/tasks/:id/postponements", h.apiRequestPostponement)
+	router.GET("/api/v1/tasks/:id/comments", h.apiFindTaskComments)
+	router.POST("/api/v1/tasks/:id/comments", h.apiCreateComment)
+	router.PUT("/api/v1/comments/:id", h.apiUpdateComment)
+	router.DELETE("/api/v1/comments/:id", h.apiDeleteComment)
+	router.GET("/api/v1/tasks/:id/attachments", h.apiFindTaskAttachments)
+	router.POST("/api/v1/tasks/:id/attachments", h.apiUploadAttachment)
+	router.GET("/api/v1/attachments/:id", h.apiDownloadAttachment)
+	router.DELETE("/api/v1/attachments/:id", h.apiDeleteAttachment)
+	router.GET("/api/v1/tasks/:id/dependencies", h.apiFindTaskDependencies)
+	router.PUT("/api/v1/tasks/:id/blockers/:blocker_id", h.apiAddBlocker)
+	router.DELETE("/api/v1/tasks/:id/blockers/:blocker_id", h.apiRemoveBlocker)
+	router.GET("/api/v1/postponements", h.apiFindPendingPostponements)
+	router.POST("/api/v1/postponements/:id/approve", h.apiDecidePostponement(h.postponementUsecase.Approve))
+	router.POST("/api/v1/postponements/:id/reject", h.apiDecidePostponement(h.postponementUsecase.Reject))
+
+	router.GET("/api/v1/workspaces", h.apiFindAllWorkspace)
+	router.POST("/api/v1/workspaces", h.apiCreateWorkspace)
+	router.GET("/api/v1/workspaces/:id", h.apiFindWorkspace)
+	router.POST("/api/v1/workspaces/:id/invitations", h.apiInviteWorkspace)
+	router.PUT("/api/v1/workspaces/:id/members/:user_id", h.apiUpdateMember)
+	router.DELETE("/api/v1/workspaces/:id/members/:user_id", h.apiRemoveMember)
+	router.GET("/api/v1/workspaces/:id/webhooks", h.apiFindAllWebhook)
+	router.POST("/api/v1/workspaces/:id/webhooks", h.apiCreateWebhook)
+	router.GET("/api/v1/workspaces/:id/webhooks/:webhook_id", h.apiFindWebhook)
+	router.PUT("/api/v1/workspaces/:id/webhooks/:webhook_id", h.apiUpdateWebhook)
+	router.DELETE("/api/v1/workspaces/:id/webhooks/:webhook_id", h.apiDeleteWebhook)
+	router.POST("/api/v1/workspaces/:id/webhooks/:webhook_id/secret", h.apiRotateWebhookSecret)
+	router.GET("/api/v1/invitations/:token", h.apiFindInvitation)
+	router.POST("/api/v1/invitations/:token/accept", h.apiAcceptInvitation)
+
 	h.server = &http.Server{
 		Handler: router,
 		Addr:    ":8080",
@@ -2,28 +2,77 @@ package handler
 
 import (
 	"fmt"
+	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
-	"text/template"
+	"strings"
 	"time"
 	"todo-app/domain/model"
 	"todo-app/usecase"
+	"unicode"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
 )
 
-const timeLayout = "2006-01-02"
+const (
+	timeLayout    = "2006-01-02"
+	dueTimeLayout = "15:04"
+	// publicPagePolicy allows only the stylesheet of the layout on the shared task page, which anyone with the link can open.
+	publicPagePolicy = "default-src 'none'; style-src https://cdn.jsdelivr.net 'unsafe-inline'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+)
 
 var funcMap = template.FuncMap{
 	"formatDate": func(t time.Time) string {
 		return t.Format(timeLayout)
 	},
+	"formatTime": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04")
+	},
+	"formatDueTime": func(t time.Time) string {
+		return t.Format(dueTimeLayout)
+	},
+	"invitationURL": invitationURL,
+	"calendarURL":   calendarURL,
+	"formatSize":    formatSize,
+	"webhookURL":    webhookURL,
 }
 
 type data struct {
-	Session *usecase.Session
-	Tasks   []*model.Task
-	Task    *model.Task
+	Session       *usecase.Session
+	Tasks         []*model.Task
+	Task          *model.Task
+	Tree          *model.TaskTree
+	Users         []*model.User
+	Label         *model.Label
+	Labels        []*model.Label
+	TaskLabels    map[model.TaskID][]*model.Label
+	Selected      map[model.LabelID]bool
+	Match         string
+	Sort          string
+	Query         url.Values
+	NextURL       string
+	Hits          []*model.TaskHit
+	Timeline      *model.TaskTimeline
+	Changes       []model.FieldChange
+	Postponement  *model.Postponement
+	Postponements []*model.Postponement
+	TaskByID      map[model.TaskID]*model.Task
+	Workspaces    []*model.Workspace
+	Workspace     *model.WorkspaceDetail
+	Invitation    *model.Invitation
+	Comments      []*commentView
+	Attachments   []*attachmentView
+	Blocked       map[model.TaskID]bool
+	Dependencies  *model.TaskDependencies
+	Candidates    []*model.Task
+	User          *model.User
+	Import        *importView
+	Webhooks      []*model.Webhook
+	Webhook       *model.Webhook
+	Deliveries    []*model.WebhookDelivery
+	EventTypes    []model.EventType
 }
 
 func (h *handler) home(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -49,7 +98,42 @@ func (h *handler) findAllTask(w http.ResponseWriter, r *http.Request, _ httprout
 		return
 	}
 
-	tasks, err := h.taskUsecase.FindAll()
+	loc, err := h.location(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	q, err := parseTaskQuery(*s, r.URL.Query(), loc)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	page, err := h.taskUsecase.Find(*s, q)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	labels, err := h.labelUsecase.FindByUserID(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	taskLabels, err := h.taskUsecase.FindLabels(*s, page.Tasks)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	blocked, err := h.dependencyUsecase.FindBlocked(*s, page.Tasks)
 	if err != nil {
 		errorResponse(w, r, err)
 
@@ -57,8 +141,16 @@ func (h *handler) findAllTask(w http.ResponseWriter, r *http.Request, _ httprout
 	}
 
 	d := &data{
-		Session: s,
-		Tasks:   tasks,
+		Session:    s,
+		Tasks:      page.Tasks,
+		Labels:     labels,
+		TaskLabels: taskLabels,
+		Blocked:    blocked,
+		Selected:   selectedLabels(q.LabelIDs),
+		Match:      q.LabelMatch.String(),
+		Sort:       q.Sort.String(),
+		Query:      r.URL.Query(),
+		NextURL:    nextPageURL(r.URL, page.NextCursor),
 	}
 
 	generateHTML(w, r, d, "layout", "task_all")
@@ -70,8 +162,12 @@ func (h *handler) newTask(w http.ResponseWriter, r *http.Request, _ httprouter.P
 		errorResponse(w, r, err)
 	} else if s == nil {
 		http.Redirect(w, r, "/login", http.StatusFound)
+	} else if workspaces, err := h.workspaceUsecase.FindByUser(*s); err != nil {
+		errorResponse(w, r, err)
+	} else if user, err := h.userUsecase.Find(*s); err != nil {
+		errorResponse(w, r, err)
 	} else {
-		generateHTML(w, r, nil, "layout", "task_new")
+		generateHTML(w, r, &data{Session: s, Workspaces: workspaces, User: user}, "layout", "task_new")
 	}
 }
 
@@ -93,14 +189,28 @@ func (h *handler) createTask(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
-	deadline, err := time.Parse(timeLayout, r.PostFormValue("deadline"))
+	due, err := h.parseDue(*s, r.PostFormValue("deadline"), r.PostFormValue("due_time"), r.PostFormValue("time_zone"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	recurrence, err := model.ParseRecurrence(r.PostFormValue("recurrence"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	priority, err := model.ParsePriority(r.PostFormValue("priority"))
 	if err != nil {
 		errorResponse(w, r, err)
 
 		return
 	}
 
-	if err := h.taskUsecase.Create(*s, r.PostFormValue("name"), r.PostFormValue("detail"), deadline); err != nil {
+	if _, err := h.taskUsecase.Create(*s, model.WorkspaceID(r.PostFormValue("workspace")), r.PostFormValue("name"), r.PostFormValue("detail"), due, recurrence, priority); err != nil {
 		errorResponse(w, r, err)
 
 		return
@@ -123,16 +233,92 @@ func (h *handler) findTask(w http.ResponseWriter, r *http.Request, ps httprouter
 
 	id := model.TaskID(ps.ByName("id"))
 
-	task, err := h.taskUsecase.FindByID(id)
+	tree, err := h.taskUsecase.FindTree(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	labels, err := h.labelUsecase.FindByUserID(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	taskLabels, err := h.taskUsecase.FindLabels(*s, []*model.Task{tree.Task})
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	timeline, err := h.taskUsecase.FindHistory(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	postponements, err := h.postponementUsecase.FindByTaskID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	discussion, err := h.commentUsecase.FindByTaskID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	attachments, err := h.attachmentUsecase.FindByTaskID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	dependencies, err := h.dependencyUsecase.FindByTaskID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	// INFO: the open tasks of the same workspace can be added as blockers
+	candidates, err := h.taskUsecase.Find(*s, model.TaskQuery{
+		WorkspaceID: tree.Task.WorkspaceID,
+		Statuses:    []model.Status{model.Working, model.Behind},
+		Limit:       model.MaxPageSize,
+	})
 	if err != nil {
 		errorResponse(w, r, err)
 
 		return
 	}
 
+	var labelIDs []model.LabelID
+	for _, l := range taskLabels[id] {
+		labelIDs = append(labelIDs, l.ID)
+	}
+
 	d := &data{
-		Session: s,
-		Task:    task,
+		Session:       s,
+		Task:          tree.Task,
+		Tree:          tree,
+		Labels:        labels,
+		TaskLabels:    taskLabels,
+		Selected:      selectedLabels(labelIDs),
+		Timeline:      timeline,
+		Postponements: postponements,
+		Comments:      newCommentViews(discussion, discussion.Threads, s.UserID),
+		Attachments:   newAttachmentViews(attachments, s.UserID, discussion.Access),
+		Dependencies:  dependencies,
+		Candidates:    blockerCandidates(tree.Task, dependencies, candidates.Tasks),
 	}
 
 	generateHTML(w, r, d, "layout", "task_detail")
@@ -152,19 +338,19 @@ func (h *handler) editTask(w http.ResponseWriter, r *http.Request, ps httprouter
 
 	id := model.TaskID(ps.ByName("id"))
 
-	task, err := h.taskUsecase.FindByID(id)
+	task, err := h.taskUsecase.FindByID(*s, id)
 	if err != nil {
 		errorResponse(w, r, err)
 
 		return
 	}
 
-	files := []string{"/opt/templates/layout.html", "/opt/templates/task_edit.html"}
-	templates := template.Must(template.New("editTask").Funcs(funcMap).ParseFiles(files...))
-
-	if err := templates.ExecuteTemplate(w, "layout", task); err != nil {
-		errorResponse(w, r, err)
+	d := &data{
+		Session: s,
+		Task:    task,
 	}
+
+	generateHTML(w, r, d, "layout", "task_edit")
 }
 
 func (h *handler) updateTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -194,14 +380,92 @@ func (h *handler) updateTask(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
-	deadline, err := time.Parse(timeLayout, r.PostFormValue("deadline"))
+	due, err := h.parseDue(*s, r.PostFormValue("deadline"), r.PostFormValue("due_time"), r.PostFormValue("time_zone"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	recurrence, err := model.ParseRecurrence(r.PostFormValue("recurrence"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	priority, err := model.ParsePriority(r.PostFormValue("priority"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	version, err := strconv.Atoi(r.PostFormValue("version"))
 	if err != nil {
 		errorResponse(w, r, err)
 
 		return
 	}
 
-	if err := h.taskUsecase.Update(*s, id, r.PostFormValue("name"), r.PostFormValue("detail"), model.Status(status), deadline); err != nil {
+	cascade := r.PostFormValue("cascade") != ""
+
+	err = h.taskUsecase.Update(*s, id, r.PostFormValue("name"), r.PostFormValue("detail"), model.Status(status), due, recurrence, priority, cascade, version)
+	if errors.Is(err, usecase.ErrConflict) {
+		current, err := h.taskUsecase.FindByID(*s, id)
+		if err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		// INFO: show the edit form again with the values of the user on the latest version,
+		// so that the user can merge them with the changes made in the meantime and submit again
+		draft := *current
+		draft.Name = r.PostFormValue("name")
+		draft.Detail = r.PostFormValue("detail")
+		draft.Status = model.Status(status)
+		draft.Deadline = due.At
+		draft.HasDueTime = due.HasTime
+		draft.TimeZone = due.At.Location().String()
+		draft.Recurrence = recurrence
+		draft.Priority = priority
+
+		changes := model.TaskChanges(current, draft)
+		if len(changes) == 0 {
+			http.Redirect(w, r, fmt.Sprint("/tasks/show/", id), http.StatusFound)
+
+			return
+		}
+
+		d := &data{
+			Session: s,
+			Task:    &draft,
+			Changes: changes,
+		}
+
+		generateHTML(w, r, d, "layout", "task_edit")
+
+		return
+	} else if errors.Is(err, usecase.ErrApprovalRequired) {
+		current, err := h.taskUsecase.FindByID(*s, id)
+		if err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		// INFO: save the other changes with the current deadline, and let the user request the postponement of the deadline
+		if err := h.taskUsecase.Update(*s, id, r.PostFormValue("name"), r.PostFormValue("detail"), model.Status(status), current.Due(), recurrence, priority, cascade, version); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		http.Redirect(w, r, postponeURL(id, due), http.StatusFound)
+
+		return
+	} else if err != nil {
 		errorResponse(w, r, err)
 
 		return
@@ -210,3 +474,294 @@ func (h *handler) updateTask(w http.ResponseWriter, r *http.Request, ps httprout
 	url := fmt.Sprint("/tasks/show/", id)
 	http.Redirect(w, r, url, http.StatusFound)
 }
+
+func (h *handler) shareTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	task, err := h.taskUsecase.FindByID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	users, err := h.taskUsecase.FindSharedUsers(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Task:    task,
+		Users:   users,
+	}
+
+	generateHTML(w, r, d, "layout", "task_share")
+}
+
+func (h *handler) updateShare(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	visibility, err := strconv.Atoi(r.PostFormValue("visibility"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	emails := strings.FieldsFunc(r.PostFormValue("emails"), func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+
+	if _, err := h.taskUsecase.Share(*s, id, model.Visibility(visibility), emails); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	url := fmt.Sprint("/tasks/show/", id, "/share")
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (h *handler) assignTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	if _, err := h.taskUsecase.Assign(*s, id, r.PostFormValue("email")); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/tasks/show/", id), http.StatusFound)
+}
+
+func (h *handler) findPublicTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	task, err := h.taskUsecase.FindByShareToken(ps.ByName("token"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Task: task,
+	}
+
+	// INFO: the page shows what the owner wrote to anyone with the link, so that it runs no scripts and does not leak the link on leaving it
+	w.Header().Set("Content-Security-Policy", publicPagePolicy)
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	generateHTML(w, r, d, "layout", "task_public")
+}
+
+func (h *handler) findArchivedTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	tasks, err := h.taskUsecase.FindArchived(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Tasks:   tasks,
+	}
+
+	generateHTML(w, r, d, "layout", "task_archived")
+}
+
+func (h *handler) findTrashedTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	tasks, err := h.taskUsecase.FindTrashed(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Tasks:   tasks,
+	}
+
+	generateHTML(w, r, d, "layout", "task_trash")
+}
+
+// changeTask returns a handler which applies action to the task and redirects to the given url.
+// The ":id" in the url is replaced with the task ID.
+func (h *handler) changeTask(action func(usecase.Session, model.TaskID) error, url string) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		s, err := h.session(r)
+		if err != nil {
+			errorResponse(w, r, err)
+
+			return
+		} else if s == nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+
+			return
+		}
+
+		id := model.TaskID(ps.ByName("id"))
+
+		if err := action(*s, id); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		http.Redirect(w, r, strings.Replace(url, ":id", string(id), 1), http.StatusFound)
+	}
+}
+
+func (h *handler) createSubtask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	parentID := model.TaskID(ps.ByName("id"))
+
+	due, err := h.parseDue(*s, r.PostFormValue("deadline"), r.PostFormValue("due_time"), r.PostFormValue("time_zone"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if _, err := h.taskUsecase.CreateSubtask(*s, parentID, r.PostFormValue("name"), r.PostFormValue("detail"), due, model.NoRecurrence, model.DefaultPriority); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	url := fmt.Sprint("/tasks/show/", parentID)
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (h *handler) completeTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.checkTask(w, r, ps, func(s usecase.Session, id model.TaskID) error {
+		_, err := h.taskUsecase.Complete(s, id, r.PostFormValue("cascade") != "", nil)
+
+		return err
+	})
+}
+
+func (h *handler) reopenTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.checkTask(w, r, ps, h.taskUsecase.Reopen)
+}
+
+// checkTask applies action to the task from the checklist, and goes back to the page given by the "back" form value.
+func (h *handler) checkTask(w http.ResponseWriter, r *http.Request, ps httprouter.Params, action func(usecase.Session, model.TaskID) error) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	if err := action(*s, id); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	url := r.PostFormValue("back")
+	if !strings.HasPrefix(url, "/tasks/") {
+		url = fmt.Sprint("/tasks/show/", id)
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (h *handler) findAllLabel(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	labels, err := h.labelUsecase.FindByUserID(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Labels:  labels,
+	}
+
+	generateHTML(w, r, d, "layout", "label_all")
+}
+
+func (h *handler) createLabel(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if _, err := h.labelUsecase.Create(*s, r.PostFormValue("name"), r.PostFormValue("color")); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/labels", http.StatusFound)
+}
+
+func (h *handler) editLabel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	label, err := h.labelUsecase.FindByID(*s, model.LabelID(ps.ByName("id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Label:   label,
+	}
+
+	generateHTML(w, r, d, "layout", "label_edit")
+}
+
+func (h *handler) updateLabel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if err := h.labelUsecase.Update(*s, model.LabelID(ps.ByName("id")), r.PostFormValue("name"), r.PostFormValue("color")); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/labels", http.StatusFound)
+}
+
+func (h *handler) deleteLabel(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := h.labelUsecase.Delete(*s, model.LabelID(ps.ByName("id"))); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/labels", http.StatusFound)
+}
+
+func (h *handler) updateTaskLabels(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	if err := h.taskUsecase.SetLabels(*s, id, parseLabelIDs(r.PostForm["label"])); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	url := fmt.Sprint("/tasks/show/", id)
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func parseLabelIDs(values []string) []model.LabelID {
+	labelIDs := make([]model.LabelID, 0, len(values))
+
+	for _, v := range values {
+		if v != "" {
+			labelIDs = append(labelIDs, model.LabelID(v))
+		}
+	}
+
+	return labelIDs
+}
+
+func selectedLabels(labelIDs []model.LabelID) map[model.LabelID]bool {
+	selected := make(map[model.LabelID]bool, len(labelIDs))
+	for _, id := range labelIDs {
+		selected[id] = true
+	}
+
+	return selected
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// postponeURL is the page to request a postponement of the task to the deadline.
+func postponeURL(id model.TaskID, due model.Due) string {
+	values := url.Values{}
+	values.Set("deadline", due.At.Format(timeLayout))
+	values.Set("time_zone", due.At.Location().String())
+
+	if due.HasTime {
+		values.Set("due_time", due.At.Format(dueTimeLayout))
+	}
+
+	return fmt.Sprintf("/tasks/show/%s/postpone?%s", id, values.Encode())
+}
+
+func (h *handler) newPostponement(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	task, err := h.taskUsecase.FindByID(*s, model.TaskID(ps.ByName("id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	due := task.Due()
+	due.At = due.At.AddDate(0, 0, 1)
+
+	if q := r.URL.Query(); q.Get("deadline") != "" {
+		if due, err = h.parseDue(*s, q.Get("deadline"), q.Get("due_time"), q.Get("time_zone")); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+	}
+
+	d := &data{
+		Session:      s,
+		Task:         task,
+		Postponement: &model.Postponement{TaskID: task.ID, Deadline: due.At, HasDueTime: due.HasTime, TimeZone: due.At.Location().String()},
+	}
+
+	generateHTML(w, r, d, "layout", "task_postpone")
+}
+
+func (h *handler) requestPostponement(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.TaskID(ps.ByName("id"))
+
+	due, err := h.parseDue(*s, r.PostFormValue("deadline"), r.PostFormValue("due_time"), r.PostFormValue("time_zone"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if _, err := h.postponementUsecase.Request(*s, id, due, r.PostFormValue("reason")); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/tasks/show/", id), http.StatusFound)
+}
+
+func (h *handler) findPendingPostponement(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	postponements, err := h.postponementUsecase.FindPending(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	tasks := make(map[model.TaskID]*model.Task, len(postponements))
+
+	for _, p := range postponements {
+		task, err := h.taskUsecase.FindByID(*s, p.TaskID)
+		if err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		tasks[p.TaskID] = task
+	}
+
+	d := &data{
+		Session:       s,
+		Postponements: postponements,
+		TaskByID:      tasks,
+	}
+
+	generateHTML(w, r, d, "layout", "postponement_all")
+}
+
+// decidePostponement approves or rejects the postponement with the comment,
+// and goes back to the task page given by the "back" form value or to the pending postponements.
+func (h *handler) decidePostponement(decide func(usecase.Session, model.PostponementID, string) (*model.Postponement, error)) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		s, err := h.session(r)
+		if err != nil {
+			errorResponse(w, r, err)
+
+			return
+		} else if s == nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		if _, err := decide(*s, model.PostponementID(ps.ByName("id")), r.PostFormValue("comment")); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+
+		url := r.PostFormValue("back")
+		if !strings.HasPrefix(url, "/tasks/") {
+			url = "/postponements"
+		}
+
+		http.Redirect(w, r, url, http.StatusFound)
+	}
+}
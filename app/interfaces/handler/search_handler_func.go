@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (h *handler) searchTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	d := &data{
+		Session: s,
+		Query:   r.URL.Query(),
+	}
+
+	// INFO: the page only shows the search box until something is entered
+	if text := d.Query.Get("q"); strings.TrimSpace(text) != "" {
+		if d.Hits, err = h.searchUsecase.Search(*s, text, 0); err != nil {
+			errorResponse(w, r, err)
+
+			return
+		}
+	}
+
+	generateHTML(w, r, d, "layout", "task_search")
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+// parseTaskQuery builds the query of a task list from the URL query parameters,
+// which both the HTML list and the API accept:
+// status (repeatable), from, to, q, workspace, owner ("me" or a user ID), assignee ("me" or a user ID), label (repeatable), match, sort, limit and cursor.
+// The days of from and to are the ones in the time zone of the session user.
+func parseTaskQuery(s usecase.Session, values url.Values, loc *time.Location) (model.TaskQuery, error) {
+	q := model.TaskQuery{
+		ViewerID:    s.UserID,
+		WorkspaceID: model.WorkspaceID(values.Get("workspace")),
+		OwnerID:     model.UserID(values.Get("owner")),
+		AssigneeID:  model.UserID(values.Get("assignee")),
+		Text:        values.Get("q"),
+		LabelIDs:    parseLabelIDs(values["label"]),
+		Cursor:      values.Get("cursor"),
+	}
+
+	if q.OwnerID == "me" {
+		q.OwnerID = s.UserID
+	}
+
+	if q.AssigneeID == "me" {
+		q.AssigneeID = s.UserID
+	}
+
+	for _, name := range values["status"] {
+		if name == "" {
+			continue
+		}
+
+		status, err := model.ParseStatus(name)
+		if err != nil {
+			return q, err
+		}
+
+		q.Statuses = append(q.Statuses, status)
+	}
+
+	var err error
+
+	if q.DeadlineFrom, err = parseDate(values.Get("from"), loc); err != nil {
+		return q, err
+	}
+
+	if q.DeadlineTo, err = parseDate(values.Get("to"), loc); err != nil {
+		return q, err
+	} else if q.DeadlineTo != nil {
+		// INFO: the range includes the deadlines at any time of the last day
+		to := q.DeadlineTo.AddDate(0, 0, 1).Add(-time.Second)
+		q.DeadlineTo = &to
+	}
+
+	if q.LabelMatch, err = model.ParseLabelMatch(values.Get("match")); err != nil {
+		return q, err
+	}
+
+	if q.Sort, err = model.ParseTaskSort(values.Get("sort")); err != nil {
+		return q, err
+	}
+
+	if q.Limit, err = parseLimit(values); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+// parseLimit returns the limit parameter, or 0 to use the default when it is not given.
+func parseLimit(values url.Values) (int, error) {
+	limit := values.Get("limit")
+	if limit == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(limit)
+	if err != nil {
+		return 0, errors.Errorf("limit must be a number. limit: %s", limit)
+	}
+
+	return n, nil
+}
+
+func parseDate(value string, loc *time.Location) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(timeLayout, value, loc)
+	if err != nil {
+		return nil, errors.Errorf("date must be formatted as %s. date: %s", timeLayout, value)
+	}
+
+	return &t, nil
+}
+
+// parseDue parses the day and the optional time of a deadline in the time zone.
+// The time zone defaults to the location, which is the one of the session user.
+func parseDue(date, dueTime, timeZone string, loc *time.Location) (model.Due, error) {
+	if timeZone != "" {
+		if err := model.TimeZoneSpecSatisfied(timeZone); err != nil {
+			return model.Due{}, err
+		}
+
+		loc = model.LoadLocation(timeZone)
+	}
+
+	if dueTime == "" {
+		d, err := time.ParseInLocation(timeLayout, date, loc)
+		if err != nil {
+			return model.Due{}, errors.Errorf("deadline must be formatted as %s. deadline: %s", timeLayout, date)
+		}
+
+		return model.DueOn(d), nil
+	}
+
+	at, err := time.ParseInLocation(timeLayout+" "+dueTimeLayout, date+" "+dueTime, loc)
+	if err != nil {
+		return model.Due{}, errors.Errorf("deadline must be formatted as %s and due time as %s. deadline: %s, due time: %s", timeLayout, dueTimeLayout, date, dueTime)
+	}
+
+	return model.DueAt(at), nil
+}
+
+// nextPageURL returns the url of the page after the current one, keeping the other query parameters.
+func nextPageURL(u *url.URL, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+
+	values := u.Query()
+	values.Set("cursor", cursor)
+
+	return u.Path + "?" + values.Encode()
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/interfaces/transfer"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// importView is a checked import with its file, which is posted again to commit the import after the preview.
+// INFO: the file is sent back in base64, since the values of the hidden fields are not escaped by the templates
+type importView struct {
+	*model.TaskImport
+	Format      transfer.Format
+	WorkspaceID string
+	Data        string
+}
+
+func newImportView(imp *model.TaskImport, f transfer.Format, workspaceID string, file []byte) *importView {
+	return &importView{
+		TaskImport:  imp,
+		Format:      f,
+		WorkspaceID: workspaceID,
+		Data:        base64.StdEncoding.EncodeToString(file),
+	}
+}
+
+// exportFormat parses the name of a format in which tasks can be exported.
+func exportFormat(name string) (transfer.Format, error) {
+	f, err := transfer.ParseFormat(name)
+	if err != nil {
+		return "", err
+	} else if !f.Exportable() {
+		return "", errors.Errorf("format cannot be exported. format: %s", f)
+	}
+
+	return f, nil
+}
+
+// encodeExport writes the records in the format.
+func encodeExport(f transfer.Format, records []model.TaskRecord) (*bytes.Buffer, error) {
+	var b bytes.Buffer
+	if err := transfer.Encode(&b, f, records); err != nil {
+		return nil, errors.Wrap(err, "failed to encode export")
+	}
+
+	return &b, nil
+}
+
+// serveExport sends the export as a download.
+func serveExport(w http.ResponseWriter, f transfer.Format, b *bytes.Buffer) {
+	w.Header().Set("Content-Type", f.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.FileName()}))
+
+	if _, err := b.WriteTo(w); err != nil {
+		log.Println(errors.Wrap(err, "failed to write export"))
+	}
+}
+
+// readImport reads a file to import, which is too large when it cannot be read within transfer.MaxFileSize.
+// The times in the file are read in loc.
+func readImport(r io.Reader, f transfer.Format, loc *time.Location) ([]byte, []model.TaskRecord, error) {
+	file, err := io.ReadAll(io.LimitReader(r, transfer.MaxFileSize+1))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read file")
+	} else if len(file) > transfer.MaxFileSize {
+		return nil, nil, errors.Errorf("file has to be at most %d MB", transfer.MaxFileSize>>20)
+	}
+
+	records, err := transfer.Decode(bytes.NewReader(file), f, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, records, nil
+}
+
+func (h *handler) exportTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	f, err := exportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	records, err := h.transferUsecase.Export(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	b, err := encodeExport(f, records)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	serveExport(w, f, b)
+}
+
+func (h *handler) newImport(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+	} else if workspaces, err := h.workspaceUsecase.FindByUser(*s); err != nil {
+		errorResponse(w, r, err)
+	} else {
+		generateHTML(w, r, &data{Session: s, Workspaces: workspaces}, "layout", "task_import")
+	}
+}
+
+// previewImport checks the uploaded file and shows the rows with their errors, without importing them.
+// The format defaults to the extension of the file.
+func (h *handler) previewImport(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	upload, header, err := uploadedFile(w, r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+	defer upload.Close()
+
+	var f transfer.Format
+	if name := r.PostFormValue("format"); name != "" {
+		f, err = transfer.ParseFormat(name)
+	} else {
+		f, err = transfer.ParseFileName(header.Filename)
+	}
+
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	file, records, err := readImport(upload, f, loc)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	workspaceID := r.PostFormValue("workspace")
+
+	imp, err := h.transferUsecase.Preview(*s, model.WorkspaceID(workspaceID), records)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	generateHTML(w, r, &data{Session: s, Import: newImportView(imp, f, workspaceID, file)}, "layout", "task_import")
+}
+
+// commitImport imports the file of a preview. The rows are checked again, and shown when they have become invalid.
+func (h *handler) commitImport(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, transfer.MaxFileSize*2)
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	f, err := transfer.ParseFormat(r.PostFormValue("format"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	loc, err := h.location(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	file, records, err := readImport(base64.NewDecoder(base64.StdEncoding, strings.NewReader(r.PostFormValue("data"))), f, loc)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	workspaceID := r.PostFormValue("workspace")
+
+	imp, err := h.transferUsecase.Import(*s, model.WorkspaceID(workspaceID), records)
+	if errors.Is(err, usecase.ErrInvalidArgument) && imp != nil {
+		generateHTML(w, r, &data{Session: s, Import: newImportView(imp, f, workspaceID, file)}, "layout", "task_import")
+
+		return
+	} else if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/tasks", http.StatusFound)
+}
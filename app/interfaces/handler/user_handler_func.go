@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// location returns the time zone of the session user, in which the days and times the user enters are interpreted.
+func (h *handler) location(s usecase.Session) (*time.Location, error) {
+	user, err := h.userUsecase.Find(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Location(), nil
+}
+
+// parseDue parses the deadline of a form. The time zone of the form defaults to the one of the session user.
+func (h *handler) parseDue(s usecase.Session, date, dueTime, timeZone string) (model.Due, error) {
+	loc, err := h.location(s)
+	if err != nil {
+		return model.Due{}, err
+	}
+
+	return parseDue(date, dueTime, timeZone, loc)
+}
+
+func (h *handler) findSettings(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	user, err := h.userUsecase.Find(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	generateHTML(w, r, &data{Session: s, User: user}, "layout", "settings")
+}
+
+func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if err := h.userUsecase.SetTimeZone(*s, r.PostFormValue("time_zone")); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, "/settings", http.StatusFound)
+}
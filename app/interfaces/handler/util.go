@@ -2,9 +2,9 @@ package handler
 
 import (
 	"fmt"
+	"html/template"
 	"log"
 	"net/http"
-	"text/template"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -29,7 +29,7 @@ func generateHTML(w http.ResponseWriter, r *http.Request, data interface{}, file
 		files = append(files, fmt.Sprintf("/opt/templates/%s.html", file))
 	}
 
-	templates := template.Must(template.ParseFiles(files...))
+	templates := template.Must(template.New(filenames[0]).Funcs(funcMap).ParseFiles(files...))
 
 	if err := templates.ExecuteTemplate(w, "layout", data); err != nil {
 		errorResponse(w, r, err)
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// webhookURL is the page of the webhook with its delivery log.
+func webhookURL(w *model.Webhook) string {
+	return fmt.Sprint("/workspaces/", w.WorkspaceID, "/webhooks/", w.ID)
+}
+
+func (h *handler) findAllWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	webhooks, err := h.webhookUsecase.FindByWorkspace(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.FindByID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session:    s,
+		Workspace:  workspace,
+		Webhooks:   webhooks,
+		EventTypes: model.EventTypes,
+	}
+
+	generateHTML(w, r, d, "layout", "webhook_all")
+}
+
+func (h *handler) createWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	webhook, err := h.webhookUsecase.Create(*s, model.WorkspaceID(ps.ByName("id")), r.PostFormValue("url"), r.PostForm["events"])
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, webhookURL(webhook), http.StatusFound)
+}
+
+func (h *handler) findWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	webhook, deliveries, err := h.webhookUsecase.Find(*s, id, model.WebhookID(ps.ByName("webhook_id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.FindByID(*s, id)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session:    s,
+		Workspace:  workspace,
+		Webhook:    webhook,
+		Deliveries: deliveries,
+		EventTypes: model.EventTypes,
+	}
+
+	generateHTML(w, r, d, "layout", "webhook_detail")
+}
+
+func (h *handler) updateWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	webhook, err := h.webhookUsecase.Update(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id")),
+		r.PostFormValue("url"), r.PostForm["events"], r.PostFormValue("active") == "on")
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, webhookURL(webhook), http.StatusFound)
+}
+
+func (h *handler) rotateWebhookSecret(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	webhook, err := h.webhookUsecase.RotateSecret(*s, model.WorkspaceID(ps.ByName("id")), model.WebhookID(ps.ByName("webhook_id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, webhookURL(webhook), http.StatusFound)
+}
+
+func (h *handler) deleteWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	if err := h.webhookUsecase.Delete(*s, id, model.WebhookID(ps.ByName("webhook_id"))); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/workspaces/", id, "/webhooks"), http.StatusFound)
+}
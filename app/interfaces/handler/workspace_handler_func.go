@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"todo-app/domain/model"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// invitationURL is the link to accept the invitation.
+func invitationURL(i *model.Invitation) string {
+	return fmt.Sprint("/invitations/", i.Token)
+}
+
+func (h *handler) findAllWorkspace(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	workspaces, err := h.workspaceUsecase.FindByUser(*s)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session:    s,
+		Workspaces: workspaces,
+	}
+
+	generateHTML(w, r, d, "layout", "workspace_all")
+}
+
+func (h *handler) createWorkspace(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.Create(*s, r.PostFormValue("name"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/workspaces/", workspace.ID), http.StatusFound)
+}
+
+func (h *handler) findWorkspace(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	workspace, err := h.workspaceUsecase.FindByID(*s, model.WorkspaceID(ps.ByName("id")))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session:   s,
+		Workspace: workspace,
+	}
+
+	generateHTML(w, r, d, "layout", "workspace_detail")
+}
+
+func (h *handler) inviteWorkspace(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	role, err := model.ParseRole(r.PostFormValue("role"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if _, err := h.workspaceUsecase.Invite(*s, id, role); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/workspaces/", id), http.StatusFound)
+}
+
+func (h *handler) updateMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+
+	role, err := model.ParseRole(r.PostFormValue("role"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	if _, err := h.workspaceUsecase.ChangeRole(*s, id, model.UserID(ps.ByName("user_id")), role); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/workspaces/", id), http.StatusFound)
+}
+
+func (h *handler) removeMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	id := model.WorkspaceID(ps.ByName("id"))
+	userID := model.UserID(ps.ByName("user_id"))
+
+	if err := h.workspaceUsecase.RemoveMember(*s, id, userID); err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	// INFO: the user who left the workspace cannot see it any more
+	url := fmt.Sprint("/workspaces/", id)
+	if userID == s.UserID {
+		url = "/workspaces"
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (h *handler) findInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	invitation, workspace, err := h.workspaceUsecase.FindInvitation(*s, ps.ByName("token"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	d := &data{
+		Session:    s,
+		Workspace:  &model.WorkspaceDetail{Workspace: workspace},
+		Invitation: invitation,
+	}
+
+	generateHTML(w, r, d, "layout", "invitation")
+}
+
+func (h *handler) acceptInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.session(r)
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	} else if s == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+
+		return
+	}
+
+	member, err := h.workspaceUsecase.Accept(*s, ps.ByName("token"))
+	if err != nil {
+		errorResponse(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprint("/workspaces/", member.WorkspaceID), http.StatusFound)
+}
@@ -0,0 +1,307 @@
+// Package ical reads and writes iCalendar objects (RFC 5545).
+package ical
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Component is a calendar component like VCALENDAR, VTODO or VEVENT.
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// Property is a content line of a component. Value is the encoded value, i.e. text values are escaped.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// maxLineOctets is the length at which content lines are folded.
+const maxLineOctets = 75
+
+// Add appends a property whose value is already encoded.
+func (c *Component) Add(name, value string, params map[string]string) {
+	c.Properties = append(c.Properties, &Property{Name: name, Params: params, Value: value})
+}
+
+// AddText appends a property of the TEXT value type.
+func (c *Component) AddText(name, text string) {
+	c.Add(name, escapeText(text), nil)
+}
+
+// Get returns the first property of the name, or nil when the component does not have it.
+func (c *Component) Get(name string) *Property {
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Text returns the value of the TEXT property of the name, or an empty string when the component does not have it.
+func (c *Component) Text(name string) string {
+	if p := c.Get(name); p != nil {
+		return unescapeText(p.Value)
+	}
+
+	return ""
+}
+
+// Children returns the sub-components of the name.
+func (c *Component) Children(name string) []*Component {
+	var children []*Component
+
+	for _, child := range c.Components {
+		if strings.EqualFold(child.Name, name) {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// Param returns the value of the parameter of the property.
+func (p *Property) Param(name string) string {
+	for k, v := range p.Params {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// Encode writes the component with CRLF line breaks, folding the lines longer than 75 octets.
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+
+	if err := encodeComponent(bw, c); err != nil {
+		return err
+	}
+
+	return errors.Wrap(bw.Flush(), "failed to write calendar")
+}
+
+func encodeComponent(w *bufio.Writer, c *Component) error {
+	if err := writeLine(w, "BEGIN:"+c.Name); err != nil {
+		return err
+	}
+
+	for _, p := range c.Properties {
+		if err := writeLine(w, encodeProperty(p)); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range c.Components {
+		if err := encodeComponent(w, child); err != nil {
+			return err
+		}
+	}
+
+	return writeLine(w, "END:"+c.Name)
+}
+
+func encodeProperty(p *Property) string {
+	var b strings.Builder
+
+	b.WriteString(p.Name)
+
+	names := make([]string, 0, len(p.Params))
+	for name := range p.Params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := p.Params[name]
+		if strings.ContainsAny(value, ";:,") {
+			value = `"` + value + `"`
+		}
+
+		b.WriteString(";" + name + "=" + value)
+	}
+
+	b.WriteString(":" + p.Value)
+
+	return b.String()
+}
+
+// writeLine folds the line by inserting CRLF followed by a space, without splitting multi-byte characters.
+func writeLine(w *bufio.Writer, line string) error {
+	limit := maxLineOctets
+
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		if _, err := w.WriteString(line[:i] + "\r\n "); err != nil {
+			return errors.Wrap(err, "failed to write calendar")
+		}
+
+		line = line[i:]
+		// INFO: the leading space of a continuation line counts toward its length
+		limit = maxLineOctets - 1
+	}
+
+	_, err := w.WriteString(line + "\r\n")
+
+	return errors.Wrap(err, "failed to write calendar")
+}
+
+// Decode reads a component, which is usually a VCALENDAR. Bare LF line breaks are accepted as well as CRLF.
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+
+	for i, line := range lines {
+		p, err := decodeProperty(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid content line %d", i+1)
+		}
+
+		switch strings.ToUpper(p.Name) {
+		case "BEGIN":
+			stack = append(stack, &Component{Name: strings.ToUpper(p.Value)})
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1].Name, p.Value) {
+				return nil, errors.Errorf("unexpected END:%s at line %d", p.Value, i+1)
+			}
+
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if len(stack) == 0 {
+				return c, nil
+			}
+
+			parent := stack[len(stack)-1]
+			parent.Components = append(parent.Components, c)
+		default:
+			if len(stack) == 0 {
+				return nil, errors.Errorf("property outside of component at line %d", i+1)
+			}
+
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+
+	return nil, errors.New("calendar is not closed")
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for s.Scan() {
+		line := strings.TrimSuffix(s.Text(), "\r")
+
+		switch {
+		case line == "":
+			continue
+		case (line[0] == ' ' || line[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read calendar")
+	}
+
+	return lines, nil
+}
+
+// decodeProperty parses a content line like `DUE;VALUE=DATE:20220126`. Parameter values may be quoted.
+func decodeProperty(line string) (*Property, error) {
+	p := &Property{}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, errors.Errorf("property name is missing. line: %s", line)
+	}
+
+	p.Name = strings.ToUpper(line[:i])
+	rest := line[i:]
+
+	for rest != "" && rest[0] == ';' {
+		rest = rest[1:]
+
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return nil, errors.Errorf("invalid parameter. line: %s", line)
+		}
+
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+
+		if rest != "" && rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, errors.Errorf("unterminated quoted parameter. line: %s", line)
+			}
+
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return nil, errors.Errorf("value is missing. line: %s", line)
+			}
+
+			value = rest[:end]
+			rest = rest[end:]
+		}
+
+		if p.Params == nil {
+			p.Params = map[string]string{}
+		}
+
+		p.Params[name] = value
+	}
+
+	if rest == "" || rest[0] != ':' {
+		return nil, errors.Errorf("value is missing. line: %s", line)
+	}
+
+	p.Value = rest[1:]
+
+	return p, nil
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
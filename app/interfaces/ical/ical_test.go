@@ -0,0 +1,197 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	c := &Component{Name: "VTODO"}
+	c.AddText("SUMMARY", "Reserve venue; conference, day 1\nand \\ 2")
+	c.AddText("DESCRIPTION", strings.Repeat("会場を予約する", 10))
+	c.Add("DUE", "20220126", map[string]string{"VALUE": "DATE"})
+	c.Add("X-LOCATION", "Tokyo", map[string]string{"X-ADDRESS": "1-1, Chiyoda"})
+
+	var b bytes.Buffer
+	if err := Encode(&b, c); err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	output := b.String()
+	assert.True(t, strings.HasSuffix(output, "END:VTODO\r\n"))
+	assert.Contains(t, output, "SUMMARY:Reserve venue\\; conference\\, day 1\\nand \\\\ 2\r\n")
+	assert.Contains(t, output, "DUE;VALUE=DATE:20220126\r\n")
+	assert.Contains(t, output, `X-LOCATION;X-ADDRESS="1-1, Chiyoda":Tokyo`)
+
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets, "line is not folded: %q", line)
+		assert.True(t, strings.ToValidUTF8(line, "") == line, "multi-byte character is split: %q", line)
+	}
+
+	decoded, err := Decode(&b)
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Exactly(t, "Reserve venue; conference, day 1\nand \\ 2", decoded.Text("SUMMARY"))
+	assert.Exactly(t, strings.Repeat("会場を予約する", 10), decoded.Text("DESCRIPTION"))
+	assert.Exactly(t, "1-1, Chiyoda", decoded.Get("X-LOCATION").Param("X-ADDRESS"))
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{
+			"normal case: LF line breaks and folded lines",
+			"BEGIN:VCALENDAR\nVERSION:2.0\nBEGIN:VTODO\nUID:abc\nSUMMARY:Venue\n  Reservation\nEND:VTODO\nEND:VCALENDAR\n",
+			"",
+		},
+		{
+			"error case: not closed",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VTODO\r\n",
+			"calendar is not closed",
+		},
+		{
+			"error case: mismatched END",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n",
+			"unexpected END:VCALENDAR",
+		},
+		{
+			"error case: no value",
+			"BEGIN:VCALENDAR\r\nVERSION\r\nEND:VCALENDAR\r\n",
+			"property name is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := Decode(strings.NewReader(tt.input))
+			if err != nil {
+				if tt.expectedErr != "" {
+					assert.Contains(t, err.Error(), tt.expectedErr)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+
+				return
+			}
+
+			assert.Empty(t, tt.expectedErr, "error is expected but received nil")
+			assert.Exactly(t, "2.0", output.Text("VERSION"))
+			assert.Len(t, output.Children("VTODO"), 1)
+			assert.Exactly(t, "Venue Reservation", output.Children("VTODO")[0].Text("SUMMARY"))
+		})
+	}
+}
+
+func TestTodoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tokyo := model.LoadLocation("Asia/Tokyo")
+	berlin := model.LoadLocation("Europe/Berlin")
+	now := time.Date(2022, 1, 20, 9, 0, 0, 0, time.UTC)
+	completionDate := time.Date(2022, 1, 25, 0, 0, 0, 0, tokyo)
+	parentID := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3aa")
+
+	tests := []struct {
+		name string
+		task *model.Task
+		loc  *time.Location
+		uid  string
+	}{
+		{
+			"working task due on a day",
+			&model.Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab", Name: "Venue Reservation", Detail: "Reserve venue, for conference", Status: model.Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo", Priority: model.P1},
+			tokyo,
+			"72c24944-f532-4c5d-a695-70fa3e72f3ab",
+		},
+		{
+			"completed task with completion date",
+			&model.Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ac", Name: "Venue Reservation", Status: model.Completed, CompletionDate: &completionDate, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo", Priority: model.P4, ParentID: &parentID},
+			tokyo,
+			"72c24944-f532-4c5d-a695-70fa3e72f3ac",
+		},
+		{
+			"behind task due at a time",
+			&model.Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ad", Name: "Venue Reservation", Status: model.Behind, Deadline: time.Date(2022, 1, 19, 23, 30, 0, 0, berlin).UTC(), HasDueTime: true, TimeZone: "Europe/Berlin", Priority: model.P2},
+			berlin,
+			"72c24944-f532-4c5d-a695-70fa3e72f3ad",
+		},
+		{
+			"task created by a calendar client",
+			&model.Task{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ae", Name: "Venue Reservation", Status: model.Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo", Priority: model.P4, CalendarUID: "6f1a2b3c4d@google.com", CalendarName: "6f1a2b3c4d"},
+			tokyo,
+			"6f1a2b3c4d@google.com",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			if err := Encode(&b, NewCalendar("abc@example.com", tt.loc.String(), []*model.Task{tt.task}, now)); err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			calendar, err := Decode(&b)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			todos, err := Todos(calendar, tt.loc)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			if !assert.Len(t, todos, 1) {
+				return
+			}
+
+			todo := todos[0]
+			status := tt.task.Status
+			if status == model.Behind {
+				status = model.Working
+			}
+
+			assert.Exactly(t, tt.uid, todo.UID)
+			assert.Exactly(t, tt.task.Name, todo.Summary)
+			assert.Exactly(t, tt.task.Detail, todo.Description)
+			assert.Exactly(t, status, todo.Status)
+			assert.Exactly(t, tt.task.Priority, todo.Priority)
+			assert.Exactly(t, tt.task.Due().String(), todo.Due.String())
+			assert.True(t, tt.task.Due().At.Equal(todo.Due.At))
+
+			if tt.task.CompletionDate != nil {
+				assert.True(t, tt.task.CompletionDate.Equal(*todo.CompletionDate), "expected %s but received %v", tt.task.CompletionDate, todo.CompletionDate)
+			} else {
+				assert.Nil(t, todo.CompletionDate)
+			}
+
+			events := calendar.Children("VEVENT")
+			if !assert.Len(t, events, 1) {
+				return
+			}
+
+			assert.Exactly(t, tt.task.Due().Day().Format("20060102"), events[0].Text("DTSTART"))
+			assert.Exactly(t, "DATE", events[0].Get("DTSTART").Param("VALUE"))
+			assert.Exactly(t, tt.task.Due().Day().AddDate(0, 0, 1).Format("20060102"), events[0].Text("DTEND"))
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	prodID = "-//todo-app//Tasks//EN"
+
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+
+	statusNeedsAction = "NEEDS-ACTION"
+	statusCompleted   = "COMPLETED"
+)
+
+// eventUIDSuffix tells the deadline event of a task from its todo, since UIDs are unique in a calendar.
+const eventUIDSuffix = "-deadline"
+
+// NewCalendar makes the calendar of the tasks: a VTODO for each task and an all-day VEVENT on its due day,
+// so that the deadlines show up in calendar apps which do not list todos.
+// The calendar is named after its owner, and now is the time stamp of the components.
+func NewCalendar(name string, timeZone string, tasks []*model.Task, now time.Time) *Component {
+	c := newCalendar()
+	c.Add("METHOD", "PUBLISH", nil)
+	c.AddText("X-WR-CALNAME", name)
+	c.AddText("X-WR-TIMEZONE", timeZone)
+
+	for _, t := range tasks {
+		c.Components = append(c.Components, NewTodo(t, now))
+
+		if !t.Deadline.IsZero() {
+			c.Components = append(c.Components, NewEvent(t, now))
+		}
+	}
+
+	return c
+}
+
+// NewObject makes the calendar object resource of the task for CalDAV, which holds only its VTODO.
+func NewObject(t *model.Task, now time.Time) *Component {
+	c := newCalendar()
+	c.Components = append(c.Components, NewTodo(t, now))
+
+	return c
+}
+
+func newCalendar() *Component {
+	c := &Component{Name: "VCALENDAR"}
+	c.Add("VERSION", "2.0", nil)
+	c.Add("PRODID", prodID, nil)
+	c.Add("CALSCALE", "GREGORIAN", nil)
+
+	return c
+}
+
+// NewTodo makes the VTODO of the task. Behind tasks are still NEEDS-ACTION, and the completion date is
+// exported as the start of the completion day in the time zone of the task.
+func NewTodo(t *model.Task, now time.Time) *Component {
+	c := &Component{Name: "VTODO"}
+	c.Add("UID", todoUID(t), nil)
+	c.Add("DTSTAMP", formatUTC(now), nil)
+
+	if !t.CreatedAt.IsZero() {
+		c.Add("CREATED", formatUTC(t.CreatedAt), nil)
+	}
+
+	c.AddText("SUMMARY", t.Name)
+
+	if t.Detail != "" {
+		c.AddText("DESCRIPTION", t.Detail)
+	}
+
+	if !t.Deadline.IsZero() {
+		due := t.Due()
+		if due.HasTime {
+			c.Add("DUE", formatUTC(due.At), nil)
+		} else {
+			c.Add("DUE", due.At.Format(dateLayout), map[string]string{"VALUE": "DATE"})
+		}
+	}
+
+	if t.Status == model.Completed {
+		c.Add("STATUS", statusCompleted, nil)
+
+		if t.CompletionDate != nil {
+			c.Add("COMPLETED", formatUTC(*t.CompletionDate), nil)
+		}
+	} else {
+		c.Add("STATUS", statusNeedsAction, nil)
+	}
+
+	c.Add("PRIORITY", strconv.Itoa(priorityValue(t.Priority)), nil)
+
+	if t.ParentID != nil {
+		c.Add("RELATED-TO", string(*t.ParentID), map[string]string{"RELTYPE": "PARENT"})
+	}
+
+	return c
+}
+
+// NewEvent makes the all-day VEVENT on the due day of the task, which is the day in the time zone of the task
+// even when the task is due at a time.
+func NewEvent(t *model.Task, now time.Time) *Component {
+	day := t.Due().Day()
+
+	c := &Component{Name: "VEVENT"}
+	c.Add("UID", string(t.ID)+eventUIDSuffix, nil)
+	c.Add("DTSTAMP", formatUTC(now), nil)
+	c.Add("DTSTART", day.Format(dateLayout), map[string]string{"VALUE": "DATE"})
+	c.Add("DTEND", day.AddDate(0, 0, 1).Format(dateLayout), map[string]string{"VALUE": "DATE"})
+	c.AddText("SUMMARY", t.Name)
+	c.Add("TRANSP", "TRANSPARENT", nil)
+	c.Add("RELATED-TO", todoUID(t), nil)
+
+	return c
+}
+
+// todoUID is the UID of the todo of the task, which is the UID given by the calendar client which created the task,
+// or else the task ID.
+func todoUID(t *model.Task) string {
+	if t.CalendarUID != "" {
+		return t.CalendarUID
+	}
+
+	return string(t.ID)
+}
+
+// Todo is a VTODO read from a calendar. Due is nil when the todo has no due date,
+// and Priority is zero when it has no priority.
+type Todo struct {
+	UID            string
+	Summary        string
+	Description    string
+	Status         model.Status
+	CompletionDate *time.Time
+	Due            *model.Due
+	Priority       model.Priority
+}
+
+// Todos reads the VTODOs of the calendar. The dates without a time zone are interpreted in loc.
+func Todos(calendar *Component, loc *time.Location) ([]*Todo, error) {
+	var todos []*Todo
+
+	for _, c := range calendar.Children("VTODO") {
+		t, err := ParseTodo(c, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, t)
+	}
+
+	return todos, nil
+}
+
+// ParseTodo reads the VTODO. Any status but COMPLETED means the todo is still to be done.
+func ParseTodo(c *Component, loc *time.Location) (*Todo, error) {
+	t := &Todo{
+		UID:         c.Text("UID"),
+		Summary:     c.Text("SUMMARY"),
+		Description: c.Text("DESCRIPTION"),
+		Status:      model.Working,
+	}
+
+	if t.UID == "" {
+		return nil, errors.New("UID of todo is required")
+	}
+
+	if strings.EqualFold(c.Text("STATUS"), statusCompleted) {
+		t.Status = model.Completed
+	}
+
+	if p := c.Get("COMPLETED"); p != nil {
+		at, _, err := parseTime(p, loc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid COMPLETED of todo. uid: %s", t.UID)
+		}
+
+		t.CompletionDate = &at
+	}
+
+	if p := c.Get("DUE"); p != nil {
+		at, hasTime, err := parseTime(p, loc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid DUE of todo. uid: %s", t.UID)
+		}
+
+		due := model.DueOn(at)
+		if hasTime {
+			due = model.DueAt(at)
+		}
+
+		t.Due = &due
+	}
+
+	if p := c.Get("PRIORITY"); p != nil {
+		v, err := strconv.Atoi(p.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid PRIORITY of todo. uid: %s", t.UID)
+		}
+
+		t.Priority = parsePriorityValue(v)
+	}
+
+	return t, nil
+}
+
+// parseTime parses a DATE or DATE-TIME value. UTC and TZID times are converted to loc, and floating times
+// and dates are in loc. It reports whether the value has a time of the day.
+func parseTime(p *Property, loc *time.Location) (time.Time, bool, error) {
+	if strings.EqualFold(p.Param("VALUE"), "DATE") || len(p.Value) == len(dateLayout) {
+		d, err := time.ParseInLocation(dateLayout, p.Value, loc)
+
+		return d, false, errors.Wrapf(err, "invalid date. value: %s", p.Value)
+	}
+
+	if strings.HasSuffix(p.Value, "Z") {
+		at, err := time.Parse(utcLayout, p.Value)
+
+		return at.In(loc), true, errors.Wrapf(err, "invalid date-time. value: %s", p.Value)
+	}
+
+	zone := loc
+	if tzid := p.Param("TZID"); tzid != "" {
+		if err := model.TimeZoneSpecSatisfied(tzid); err != nil {
+			return time.Time{}, false, err
+		}
+
+		zone = model.LoadLocation(tzid)
+	}
+
+	at, err := time.ParseInLocation(dateTimeLayout, p.Value, zone)
+
+	return at.In(loc), true, errors.Wrapf(err, "invalid date-time. value: %s", p.Value)
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// priorityValue maps the priorities to the high, medium and low ranges of iCalendar, as calendar apps do.
+// The default priority is left undefined.
+func priorityValue(p model.Priority) int {
+	switch p {
+	case model.P1:
+		return 1
+	case model.P2:
+		return 5
+	case model.P3:
+		return 9
+	default:
+		return 0
+	}
+}
+
+func parsePriorityValue(v int) model.Priority {
+	switch {
+	case v >= 1 && v <= 4:
+		return model.P1
+	case v == 5:
+		return model.P2
+	case v >= 6 && v <= 9:
+		return model.P3
+	default:
+		return model.DefaultPriority
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+	"todo-app/usecase"
+
+	"github.com/pkg/errors"
+)
+
+func NewOverdueJob(u usecase.TaskStatusUsecase, interval time.Duration) Job {
+	return Job{
+		Name:     "overdue",
+		Interval: interval,
+		Run: func(now time.Time) error {
+			count, err := u.TransitionOverdue(now)
+			if err != nil {
+				return errors.Wrap(err, "failed to transition overdue tasks")
+			}
+
+			if count > 0 {
+				log.Printf("%d tasks became behind", count)
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewReminderJob(u usecase.ReminderUsecase, interval time.Duration) Job {
+	return Job{
+		Name:     "reminder",
+		Interval: interval,
+		Run: func(now time.Time) error {
+			count, err := u.SendReminders(now)
+			if count > 0 {
+				log.Printf("%d reminders were sent", count)
+			}
+
+			if err != nil {
+				return errors.Wrap(err, "failed to send reminders")
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewPurgeJob(u usecase.TrashUsecase, interval time.Duration) Job {
+	return Job{
+		Name:     "purge",
+		Interval: interval,
+		Run: func(now time.Time) error {
+			count, err := u.Purge(now)
+			if err != nil {
+				return errors.Wrap(err, "failed to purge trash")
+			}
+
+			if count > 0 {
+				log.Printf("%d trashed tasks were purged", count)
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewWebhookJob(u usecase.WebhookUsecase, interval time.Duration) Job {
+	return Job{
+		Name:     "webhook",
+		Interval: interval,
+		Run: func(now time.Time) error {
+			count, err := u.Deliver(now)
+			if count > 0 {
+				log.Printf("%d webhook deliveries were attempted", count)
+			}
+
+			if err != nil {
+				return errors.Wrap(err, "failed to deliver webhooks")
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Scheduler interface {
+	Start()
+	Stop()
+}
+
+// Clock returns the current time. It is injected so that jobs can be run at an arbitrary time in tests.
+type Clock func() time.Time
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(now time.Time) error
+}
+
+type scheduler struct {
+	clock  Clock
+	jobs   []Job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(clock Clock, jobs ...Job) Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &scheduler{
+		clock:  clock,
+		jobs:   jobs,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+
+		go s.loop(job)
+	}
+}
+
+func (s *scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+
+	log.Println("Scheduler stopped")
+}
+
+func (s *scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.run(job)
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(job)
+		}
+	}
+}
+
+func (s *scheduler) run(job Job) {
+	if err := job.Run(s.clock()); err != nil {
+		log.Printf("job %s failed: %+v", job.Name, err)
+	}
+}
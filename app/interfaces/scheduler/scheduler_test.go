@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunsJobWithClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.Local)
+	received := make(chan time.Time, 10)
+
+	s := NewScheduler(func() time.Time { return now }, Job{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Run: func(now time.Time) error {
+			received <- now
+
+			return nil
+		},
+	})
+
+	s.Start()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case r := <-received:
+			assert.Exactly(t, now, r)
+		case <-time.After(time.Second):
+			t.Fatal("job is not run")
+		}
+	}
+
+	s.Stop()
+}
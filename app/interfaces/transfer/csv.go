@@ -0,0 +1,127 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// columns are the header of CSV files. Imports may have the columns in any order and leave out all of them but name.
+var columns = []string{"id", "parent_id", "name", "detail", "status", "deadline", "due_time", "time_zone", "recurrence", "priority", "completion_date", "archived", "labels"}
+
+// labelSeparator separates the names of the labels in the labels column.
+const labelSeparator = ","
+
+// byteOrderMark is written by spreadsheets at the start of UTF-8 files.
+const byteOrderMark = "\ufeff"
+
+func encodeCSV(w io.Writer, records []model.TaskRecord) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(columns); err != nil {
+		return errors.Wrap(err, "failed to write csv header")
+	}
+
+	for _, r := range records {
+		row := []string{r.ID, r.ParentID, r.Name, r.Detail, r.Status, r.Deadline, r.DueTime, r.TimeZone, r.Recurrence, r.Priority, r.CompletionDate, strconv.FormatBool(r.Archived), strings.Join(r.Labels, labelSeparator+" ")}
+		if err := cw.Write(row); err != nil {
+			return errors.Wrapf(err, "failed to write csv row. id: %s", r.ID)
+		}
+	}
+
+	cw.Flush()
+
+	return errors.Wrap(cw.Error(), "failed to write csv")
+}
+
+func decodeCSV(r io.Reader) ([]model.TaskRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv has no header")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv header")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, byteOrderMark)
+		}
+
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := index["name"]; !ok {
+		return nil, errors.Errorf("csv header must have name column. header: %s", strings.Join(header, ","))
+	}
+
+	var records []model.TaskRecord
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to read csv")
+		}
+
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+
+			// INFO: details are kept as they are, since their line breaks and indents are part of the text
+			if name == "detail" {
+				return row[i]
+			}
+
+			return strings.TrimSpace(row[i])
+		}
+
+		archived := false
+		if v := field("archived"); v != "" {
+			if archived, err = strconv.ParseBool(v); err != nil {
+				line, _ := cr.FieldPos(0)
+
+				return nil, errors.Errorf("archived must be true or false. line: %d, archived: %s", line, v)
+			}
+		}
+
+		records = append(records, model.TaskRecord{
+			ID:             field("id"),
+			ParentID:       field("parent_id"),
+			Name:           field("name"),
+			Detail:         field("detail"),
+			Status:         field("status"),
+			Deadline:       field("deadline"),
+			DueTime:        field("due_time"),
+			TimeZone:       field("time_zone"),
+			Recurrence:     field("recurrence"),
+			Priority:       field("priority"),
+			CompletionDate: field("completion_date"),
+			Archived:       archived,
+			Labels:         splitLabels(field("labels")),
+		})
+	}
+}
+
+// splitLabels splits the labels column into the names of the labels, leaving out the empty ones.
+func splitLabels(v string) []string {
+	var names []string
+
+	for _, name := range strings.Split(v, labelSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,99 @@
+package transfer
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// jsonFile is the JSON of an export, whose tasks have the field names of the CSV columns.
+// Imports may also be a bare array of the tasks, and unknown fields are ignored like unknown CSV columns.
+type jsonFile struct {
+	Tasks []*jsonRecord `json:"tasks"`
+}
+
+type jsonRecord struct {
+	ID             string   `json:"id,omitempty"`
+	ParentID       string   `json:"parent_id,omitempty"`
+	Name           string   `json:"name"`
+	Detail         string   `json:"detail,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Deadline       string   `json:"deadline,omitempty"`
+	DueTime        string   `json:"due_time,omitempty"`
+	TimeZone       string   `json:"time_zone,omitempty"`
+	Recurrence     string   `json:"recurrence,omitempty"`
+	Priority       string   `json:"priority,omitempty"`
+	CompletionDate string   `json:"completion_date,omitempty"`
+	Archived       bool     `json:"archived"`
+	Labels         []string `json:"labels,omitempty"`
+}
+
+func encodeJSON(w io.Writer, records []model.TaskRecord) error {
+	f := &jsonFile{Tasks: make([]*jsonRecord, 0, len(records))}
+	for _, r := range records {
+		jr := jsonRecord(r)
+		f.Tasks = append(f.Tasks, &jr)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return errors.Wrap(enc.Encode(f), "failed to write json")
+}
+
+func decodeJSON(r io.Reader) ([]model.TaskRecord, error) {
+	br := bufio.NewReader(r)
+
+	first, err := firstByte(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var f jsonFile
+
+	dec := json.NewDecoder(br)
+
+	if first == '[' {
+		err = dec.Decode(&f.Tasks)
+	} else {
+		err = dec.Decode(&f)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read json")
+	}
+
+	records := make([]model.TaskRecord, 0, len(f.Tasks))
+
+	for i, jr := range f.Tasks {
+		if jr == nil {
+			return nil, errors.Errorf("task must be an object. index: %d", i)
+		}
+
+		records = append(records, model.TaskRecord(*jr))
+	}
+
+	return records, nil
+}
+
+// firstByte peeks the first byte of the JSON other than white space.
+func firstByte(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return 0, errors.New("json is empty")
+		} else if err != nil {
+			return 0, errors.Wrap(err, "failed to read json")
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		return b, br.UnreadByte()
+	}
+}
@@ -0,0 +1,137 @@
+package transfer
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// taskwarriorTimeLayout is the layout of the times in the exports of Taskwarrior, which are in UTC.
+const taskwarriorTimeLayout = "20060102T150405Z"
+
+var taskwarriorPriorities = map[string]string{"H": model.P1.String(), "M": model.P2.String(), "L": model.P3.String()}
+
+// taskwarriorTask is a task of `task export`.
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Due         string                  `json:"due"`
+	End         string                  `json:"end"`
+	Priority    string                  `json:"priority"`
+	Project     string                  `json:"project"`
+	Tags        []string                `json:"tags"`
+	Annotations []taskwarriorAnnotation `json:"annotations"`
+}
+
+type taskwarriorAnnotation struct {
+	Description string `json:"description"`
+}
+
+// decodeTaskwarrior reads the JSON of `task export`, which is an array of the tasks,
+// or a task per line in the older versions of Taskwarrior.
+// Deleted tasks are left out, and so are the templates of recurring tasks, whose pending instances are imported.
+func decodeTaskwarrior(r io.Reader, loc *time.Location) ([]model.TaskRecord, error) {
+	br := bufio.NewReader(r)
+
+	first, err := firstByte(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*taskwarriorTask
+
+	dec := json.NewDecoder(br)
+
+	if first == '[' {
+		if err := dec.Decode(&tasks); err != nil {
+			return nil, errors.Wrap(err, "failed to read json")
+		}
+	} else {
+		for {
+			var t *taskwarriorTask
+			if err := dec.Decode(&t); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, errors.Wrap(err, "failed to read json")
+			}
+
+			tasks = append(tasks, t)
+		}
+	}
+
+	records := make([]model.TaskRecord, 0, len(tasks))
+
+	for i, t := range tasks {
+		if t == nil {
+			return nil, errors.Errorf("task must be an object. index: %d", i)
+		}
+
+		if t.Status == "deleted" || t.Status == "recurring" {
+			continue
+		}
+
+		record, err := taskwarriorRecord(t, loc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read task. index: %d", i)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func taskwarriorRecord(t *taskwarriorTask, loc *time.Location) (model.TaskRecord, error) {
+	r := model.TaskRecord{
+		ID:       t.UUID,
+		Name:     t.Description,
+		Priority: t.Priority,
+	}
+
+	if p, ok := taskwarriorPriorities[t.Priority]; ok {
+		r.Priority = p
+	}
+
+	notes := make([]string, 0, len(t.Annotations))
+	for _, a := range t.Annotations {
+		notes = append(notes, a.Description)
+	}
+
+	r.Detail = strings.Join(notes, "\n")
+
+	if t.Due != "" {
+		due, err := time.Parse(taskwarriorTimeLayout, t.Due)
+		if err != nil {
+			return r, errors.Errorf("due must be formatted as %s. due: %s", taskwarriorTimeLayout, t.Due)
+		}
+
+		r.Deadline, r.DueTime = dueOf(due, loc)
+	}
+
+	if t.Status == "completed" {
+		r.Status = model.Completed.String()
+
+		if t.End != "" {
+			end, err := time.Parse(taskwarriorTimeLayout, t.End)
+			if err != nil {
+				return r, errors.Errorf("end must be formatted as %s. end: %s", taskwarriorTimeLayout, t.End)
+			}
+
+			r.CompletionDate = end.In(loc).Format(model.RecordDateLayout)
+		}
+	}
+
+	if t.Project != "" {
+		r.Labels = append(r.Labels, t.Project)
+	}
+
+	r.Labels = append(r.Labels, t.Tags...)
+
+	return r, nil
+}
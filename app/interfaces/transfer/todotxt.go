@@ -0,0 +1,107 @@
+package transfer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// todoTxtPriorities are the priorities of todo.txt, from (A) to (Z). The ones after (C) are P4.
+var todoTxtPriorities = map[byte]string{'A': model.P1.String(), 'B': model.P2.String(), 'C': model.P3.String()}
+
+// decodeTodoTxt reads a todo.txt file, a task per line like
+//
+//	x 2022-01-25 2022-01-20 (A) Reserve the venue +Conference @phone due:2022-01-26
+//
+// The projects and the contexts become labels, and the other key:value pairs are kept in the name.
+func decodeTodoTxt(r io.Reader) ([]model.TaskRecord, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), MaxFileSize)
+
+	var records []model.TaskRecord
+
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), byteOrderMark))
+		if line == "" {
+			continue
+		}
+
+		records = append(records, todoTxtRecord(line))
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read todo.txt")
+	}
+
+	return records, nil
+}
+
+func todoTxtRecord(line string) model.TaskRecord {
+	var r model.TaskRecord
+
+	words := strings.Fields(line)
+
+	if len(words) > 0 && words[0] == "x" {
+		r.Status = model.Completed.String()
+		words = words[1:]
+
+		if len(words) > 0 && isTodoTxtDate(words[0]) {
+			r.CompletionDate = words[0]
+			words = words[1:]
+		}
+	}
+
+	if len(words) > 0 && isTodoTxtPriority(words[0]) {
+		r.Priority = todoTxtPriority(words[0][1])
+		words = words[1:]
+	}
+
+	// INFO: the creation date has no place in a task, which is created by the import
+	if len(words) > 0 && isTodoTxtDate(words[0]) {
+		words = words[1:]
+	}
+
+	name := make([]string, 0, len(words))
+
+	for _, w := range words {
+		switch {
+		case len(w) > 1 && (w[0] == '+' || w[0] == '@'):
+			r.Labels = append(r.Labels, w[1:])
+		case strings.HasPrefix(w, "due:"):
+			r.Deadline = strings.TrimPrefix(w, "due:")
+		case strings.HasPrefix(w, "pri:") && len(w) == len("pri:")+1 && r.Priority == "":
+			// INFO: completed tasks keep their priority as pri:A, since (A) is not allowed after x
+			r.Priority = todoTxtPriority(strings.ToUpper(w)[len("pri:")])
+		default:
+			name = append(name, w)
+		}
+	}
+
+	r.Name = strings.Join(name, " ")
+
+	return r
+}
+
+func isTodoTxtDate(w string) bool {
+	_, err := time.Parse(model.RecordDateLayout, w)
+
+	return err == nil
+}
+
+func isTodoTxtPriority(w string) bool {
+	return len(w) == 3 && w[0] == '(' && w[1] >= 'A' && w[1] <= 'Z' && w[2] == ')'
+}
+
+func todoTxtPriority(b byte) string {
+	if p, ok := todoTxtPriorities[b]; ok {
+		return p
+	} else if b >= 'A' && b <= 'Z' {
+		return model.P4.String()
+	}
+
+	return string(b)
+}
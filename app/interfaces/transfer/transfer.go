@@ -0,0 +1,118 @@
+// Package transfer reads and writes the files in which tasks are exported and imported.
+// Every format is a list of model.TaskRecord, so that the rows are checked by the domain regardless of the format.
+// Besides the CSV and JSON of the exports, the files of todo.txt, Taskwarrior and Trello can be imported.
+package transfer
+
+import (
+	"io"
+	"path"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+type Format string
+
+const (
+	CSV         Format = "csv"
+	JSON        Format = "json"
+	TodoTxt     Format = "todotxt"
+	Taskwarrior Format = "taskwarrior"
+	Trello      Format = "trello"
+)
+
+// MaxFileSize is the largest file which can be imported.
+const MaxFileSize = 4 << 20
+
+var formats = []Format{CSV, JSON, TodoTxt, Taskwarrior, Trello}
+
+// ParseFormat parses the name of a format like "csv". An empty name means CSV.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return CSV, nil
+	}
+
+	for _, f := range formats {
+		if strings.EqualFold(string(f), name) {
+			return f, nil
+		}
+	}
+
+	return "", errors.Errorf("unknown format. name: %s", name)
+}
+
+// ParseFileName returns the format of a file by its extension. A .txt file is todo.txt,
+// while the JSON of Taskwarrior and Trello cannot be told from the JSON of an export by the extension.
+func ParseFileName(name string) (Format, error) {
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	if strings.EqualFold(ext, "txt") {
+		return TodoTxt, nil
+	}
+
+	return ParseFormat(ext)
+}
+
+// Exportable reports whether tasks can be exported in the format. The formats of other apps are only imported.
+func (f Format) Exportable() bool {
+	return f == CSV || f == JSON
+}
+
+func (f Format) ContentType() string {
+	if f == JSON {
+		return "application/json; charset=utf-8"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// FileName is the name under which an export is downloaded.
+func (f Format) FileName() string {
+	return "tasks." + string(f)
+}
+
+// Encode writes the records in the format.
+func Encode(w io.Writer, f Format, records []model.TaskRecord) error {
+	switch f {
+	case CSV:
+		return encodeCSV(w, records)
+	case JSON:
+		return encodeJSON(w, records)
+	default:
+		return errors.Errorf("format cannot be exported. format: %s", f)
+	}
+}
+
+// Decode reads the records of a file in the format. It fails on a file which cannot be read as a whole,
+// while the fields of the records are left to be checked row by row.
+// The times of the formats which have them are converted to dates in loc, the time zone of the user.
+func Decode(r io.Reader, f Format, loc *time.Location) ([]model.TaskRecord, error) {
+	switch f {
+	case CSV:
+		return decodeCSV(r)
+	case JSON:
+		return decodeJSON(r)
+	case TodoTxt:
+		return decodeTodoTxt(r)
+	case Taskwarrior:
+		return decodeTaskwarrior(r, loc)
+	case Trello:
+		return decodeTrello(r, loc)
+	default:
+		return nil, errors.Errorf("unknown format. format: %s", f)
+	}
+}
+
+// dueOf returns the deadline and the due time of a time in loc. A time at midnight is taken as a date,
+// since the apps which have no dates without times put them at the start of the day.
+func dueOf(t time.Time, loc *time.Location) (deadline, dueTime string) {
+	t = t.In(loc)
+	deadline = t.Format(model.RecordDateLayout)
+
+	if h, m, s := t.Clock(); h != 0 || m != 0 || s != 0 {
+		dueTime = t.Format(model.RecordTimeLayout)
+	}
+
+	return deadline, dueTime
+}
@@ -0,0 +1,248 @@
+package transfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := []model.TaskRecord{
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3aa", Name: "Conference", Detail: "Day 1, \"keynote\"\n  and workshops\n", Status: "completed", Deadline: "2022-01-26", TimeZone: "Asia/Tokyo", Priority: "P1", CompletionDate: "2022-01-25", Archived: true},
+		{ID: "72c24944-f532-4c5d-a695-70fa3e72f3ab", ParentID: "72c24944-f532-4c5d-a695-70fa3e72f3aa", Name: "会場を予約する", Status: "behind", Deadline: "2022-01-19", DueTime: "23:30", TimeZone: "Europe/Berlin", Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH", Priority: "P2", Labels: []string{"Work", "Calls"}},
+	}
+
+	for _, f := range formats {
+		if !f.Exportable() {
+			continue
+		}
+
+		f := f // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(string(f), func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			if err := Encode(&b, f, records); err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			output, err := Decode(&b, f, time.UTC)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			assert.Exactly(t, records, output)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		format         Format
+		input          string
+		expectedOutput []model.TaskRecord
+		expectedErr    string
+	}{
+		{
+			"normal case: csv of a spreadsheet with columns in another order",
+			CSV,
+			"\ufeffPriority,Name,Deadline,Notes,Labels\r\np1, Venue Reservation ,2022-01-26,call first,\"Work, ,Calls\"\r\n,Catering\r\n",
+			[]model.TaskRecord{
+				{Name: "Venue Reservation", Deadline: "2022-01-26", Priority: "p1", Labels: []string{"Work", "Calls"}},
+				{Name: "Catering"},
+			},
+			"",
+		},
+		{
+			"normal case: bare json array with unknown fields",
+			JSON,
+			` [{"name": "Venue Reservation", "archived": true, "color": "red", "labels": ["work"]}]`,
+			[]model.TaskRecord{{Name: "Venue Reservation", Archived: true, Labels: []string{"work"}}},
+			"",
+		},
+		{
+			"normal case: json object",
+			JSON,
+			`{"tasks": [{"id": "1", "name": "Venue Reservation", "status": "completed"}]}`,
+			[]model.TaskRecord{{ID: "1", Name: "Venue Reservation", Status: "completed"}},
+			"",
+		},
+		{
+			"normal case: todo.txt",
+			TodoTxt,
+			"(A) 2022-01-20 Reserve the venue +Conference @phone due:2022-01-26 url:https://example.com\n\n" +
+				"x 2022-01-25 2022-01-20 Send invitations pri:b +Conference\n" +
+				"(D) Order drinks\r\n" +
+				"x Book a photographer\n",
+			[]model.TaskRecord{
+				{Name: "Reserve the venue url:https://example.com", Deadline: "2022-01-26", Priority: "P1", Labels: []string{"Conference", "phone"}},
+				{Name: "Send invitations", Status: "completed", Priority: "P2", CompletionDate: "2022-01-25", Labels: []string{"Conference"}},
+				{Name: "Order drinks", Priority: "P4"},
+				{Name: "Book a photographer", Status: "completed"},
+			},
+			"",
+		},
+		{
+			"normal case: taskwarrior array",
+			Taskwarrior,
+			`[{"uuid": "a1", "description": "Reserve the venue", "status": "pending", "due": "20220126T093000Z", "priority": "H", "project": "Conference", "tags": ["phone"], "annotations": [{"entry": "20220120T000000Z", "description": "call first"}, {"description": "ask for a discount"}]},` +
+				`{"uuid": "a2", "description": "Send invitations", "status": "completed", "due": "20220125T150000Z", "end": "20220124T160000Z"},` +
+				`{"uuid": "a3", "description": "Old task", "status": "deleted"},` +
+				`{"uuid": "a4", "description": "Weekly meeting", "status": "recurring", "recur": "weekly"}]`,
+			[]model.TaskRecord{
+				{ID: "a1", Name: "Reserve the venue", Detail: "call first\nask for a discount", Deadline: "2022-01-26", DueTime: "18:30", Priority: "P1", Labels: []string{"Conference", "phone"}},
+				{ID: "a2", Name: "Send invitations", Status: "completed", Deadline: "2022-01-26", CompletionDate: "2022-01-25"},
+			},
+			"",
+		},
+		{
+			"normal case: taskwarrior lines",
+			Taskwarrior,
+			"{\"uuid\": \"a1\", \"description\": \"Reserve the venue\", \"status\": \"waiting\", \"priority\": \"X\"}\n{\"uuid\": \"a2\", \"description\": \"Send invitations\", \"status\": \"pending\"}\n",
+			[]model.TaskRecord{
+				{ID: "a1", Name: "Reserve the venue", Priority: "X"},
+				{ID: "a2", Name: "Send invitations"},
+			},
+			"",
+		},
+		{
+			"normal case: trello board",
+			Trello,
+			`{"name": "Conference", "lists": [{"id": "l1", "closed": false}, {"id": "l2", "closed": true}],` +
+				`"cards": [` +
+				`{"id": "c1", "idList": "l1", "name": "Reserve the venue", "desc": "call first", "due": "2022-01-26T09:30:00.000Z", "labels": [{"name": "High", "color": "red"}, {"name": "Venue", "color": "green"}, {"name": "", "color": "blue"}]},` +
+				`{"id": "c2", "idList": "l1", "name": "Send invitations", "due": "2022-01-24T15:00:00.000Z", "dueComplete": true, "labels": [{"name": "p3"}]},` +
+				`{"id": "c3", "idList": "l1", "name": "Archived card", "closed": true},` +
+				`{"id": "c4", "idList": "l2", "name": "Card of archived list"}],` +
+				`"checklists": [` +
+				`{"idCard": "c1", "checkItems": [{"id": "i1", "name": "Compare prices", "state": "complete"}, {"id": "i2", "name": "Sign contract", "state": "incomplete", "due": "2022-01-27T15:00:00.000Z"}]},` +
+				`{"idCard": "c2", "checkItems": [{"id": "i3", "name": "Print cards", "state": "incomplete"}]}]}`,
+			[]model.TaskRecord{
+				{ID: "c1", Name: "Reserve the venue", Detail: "call first", Deadline: "2022-01-26", DueTime: "18:30", Priority: "P1", Labels: []string{"Venue", "blue"}},
+				{ID: "i1", ParentID: "c1", Name: "Compare prices", Status: "completed", Deadline: "2022-01-26", DueTime: "18:30"},
+				{ID: "i2", ParentID: "c1", Name: "Sign contract", Deadline: "2022-01-28"},
+				{ID: "c2", Name: "Send invitations", Status: "completed", Deadline: "2022-01-25", Priority: "P3"},
+				{ID: "i3", ParentID: "c2", Name: "Print cards", Status: "completed", Deadline: "2022-01-25"},
+			},
+			"",
+		},
+		{
+			"error case: csv without name column",
+			CSV,
+			"title,deadline\nVenue Reservation,2022-01-26\n",
+			nil,
+			"csv header must have name column",
+		},
+		{
+			"error case: csv with invalid archived",
+			CSV,
+			"name,archived\nVenue Reservation,false\nCatering,someday\n",
+			nil,
+			"archived must be true or false. line: 3",
+		},
+		{
+			"error case: empty csv",
+			CSV,
+			"",
+			nil,
+			"csv has no header",
+		},
+		{
+			"error case: broken csv",
+			CSV,
+			"name\n\"Venue Reservation\n",
+			nil,
+			"failed to read csv",
+		},
+		{
+			"error case: empty json",
+			JSON,
+			"  \n",
+			nil,
+			"json is empty",
+		},
+		{
+			"error case: null task in json",
+			JSON,
+			`[{"name": "Venue Reservation"}, null]`,
+			nil,
+			"task must be an object. index: 1",
+		},
+		{
+			"error case: broken json",
+			JSON,
+			`{"tasks": [{"name": 1}]}`,
+			nil,
+			"failed to read json",
+		},
+		{
+			"error case: taskwarrior with invalid due",
+			Taskwarrior,
+			`[{"uuid": "a1", "description": "Reserve the venue", "status": "pending", "due": "2022-01-26"}]`,
+			nil,
+			"due must be formatted as 20060102T150405Z",
+		},
+		{
+			"error case: broken trello board",
+			Trello,
+			`{"cards": {}}`,
+			nil,
+			"failed to read json",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := Decode(strings.NewReader(tt.input), tt.format, model.LoadLocation("Asia/Tokyo"))
+			if err != nil {
+				if tt.expectedErr != "" {
+					assert.Contains(t, err.Error(), tt.expectedErr)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+
+				return
+			}
+
+			assert.Empty(t, tt.expectedErr, "error is expected but received nil")
+			assert.Exactly(t, tt.expectedOutput, output)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]Format{"": CSV, "csv": CSV, "JSON": JSON, "todotxt": TodoTxt, "Trello": Trello} {
+		output, err := ParseFormat(name)
+		assert.NoError(t, err)
+		assert.Exactly(t, expected, output)
+	}
+
+	_, err := ParseFormat("xlsx")
+	assert.Contains(t, err.Error(), "unknown format")
+
+	for name, expected := range map[string]Format{"tasks.csv": CSV, "todo.TXT": TodoTxt, "export.json": JSON} {
+		output, err := ParseFileName(name)
+		assert.NoError(t, err)
+		assert.Exactly(t, expected, output)
+	}
+
+	output, err := ParseFileName("tasks")
+	assert.NoError(t, err)
+	assert.Exactly(t, CSV, output)
+
+	assert.Error(t, Encode(&bytes.Buffer{}, Trello, nil))
+}
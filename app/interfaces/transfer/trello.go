@@ -0,0 +1,136 @@
+package transfer
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+	"todo-app/domain/model"
+
+	"github.com/pkg/errors"
+)
+
+// trelloPriorities are the names of the labels which are taken as priorities rather than labels.
+var trelloPriorities = map[string]string{"high": model.P1.String(), "medium": model.P2.String(), "low": model.P3.String()}
+
+// trelloBoard is the JSON export of a Trello board.
+type trelloBoard struct {
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Checklists []trelloChecklist `json:"checklists"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloCard struct {
+	ID          string        `json:"id"`
+	IDList      string        `json:"idList"`
+	Name        string        `json:"name"`
+	Desc        string        `json:"desc"`
+	Closed      bool          `json:"closed"`
+	Due         *time.Time    `json:"due"`
+	DueComplete bool          `json:"dueComplete"`
+	Labels      []trelloLabel `json:"labels"`
+}
+
+type trelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloChecklist struct {
+	IDCard     string            `json:"idCard"`
+	CheckItems []trelloCheckItem `json:"checkItems"`
+}
+
+type trelloCheckItem struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	State string     `json:"state"`
+	Due   *time.Time `json:"due"`
+}
+
+// decodeTrello reads the JSON export of a Trello board. The cards become tasks, and the items of their checklists
+// become the subtasks of the tasks. The archived cards and the cards of the archived lists are left out.
+func decodeTrello(r io.Reader, loc *time.Location) ([]model.TaskRecord, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, errors.Wrap(err, "failed to read json")
+	}
+
+	closedLists := make(map[string]bool, len(board.Lists))
+	for _, l := range board.Lists {
+		closedLists[l.ID] = l.Closed
+	}
+
+	items := make(map[string][]trelloCheckItem, len(board.Checklists))
+	for _, c := range board.Checklists {
+		items[c.IDCard] = append(items[c.IDCard], c.CheckItems...)
+	}
+
+	var records []model.TaskRecord
+
+	for _, c := range board.Cards {
+		if c.Closed || closedLists[c.IDList] {
+			continue
+		}
+
+		card := model.TaskRecord{ID: c.ID, Name: c.Name, Detail: c.Desc}
+
+		if c.Due != nil {
+			card.Deadline, card.DueTime = dueOf(*c.Due, loc)
+		}
+
+		if c.DueComplete {
+			card.Status = model.Completed.String()
+		}
+
+		for _, l := range c.Labels {
+			name := strings.TrimSpace(l.Name)
+			if name == "" {
+				name = l.Color
+			}
+
+			if p, ok := trelloPriority(name); ok && card.Priority == "" {
+				card.Priority = p
+			} else if name != "" {
+				card.Labels = append(card.Labels, name)
+			}
+		}
+
+		records = append(records, card)
+
+		for _, item := range items[c.ID] {
+			subtask := model.TaskRecord{ID: item.ID, ParentID: c.ID, Name: item.Name, Deadline: card.Deadline, DueTime: card.DueTime}
+
+			if item.Due != nil {
+				subtask.Deadline, subtask.DueTime = dueOf(*item.Due, loc)
+			}
+
+			// INFO: the items of a completed card are completed with it, since a completed task cannot have open subtasks
+			if item.State == "complete" || c.DueComplete {
+				subtask.Status = model.Completed.String()
+			}
+
+			records = append(records, subtask)
+		}
+	}
+
+	return records, nil
+}
+
+// trelloPriority returns the priority of a label named like "P1" or "High".
+func trelloPriority(name string) (string, bool) {
+	if p, ok := trelloPriorities[strings.ToLower(name)]; ok {
+		return p, true
+	}
+
+	if p, err := model.ParsePriority(name); err == nil && name != "" {
+		return p.String(), true
+	}
+
+	return "", false
+}
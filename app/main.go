@@ -5,33 +5,88 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 	"todo-app/config"
 	"todo-app/domain/service"
+	"todo-app/infrastructure/event"
 	"todo-app/infrastructure/persistence"
+	"todo-app/interfaces/cli"
 	"todo-app/interfaces/handler"
+	"todo-app/interfaces/scheduler"
 	"todo-app/usecase"
+
+	// INFO: the time zones of the users are loaded from the embedded database, since the image has no tzdata
+	_ "time/tzdata"
 )
 
 func main() {
 	conn := config.NewDBConn()
+	fileStorage := config.NewFileStorage()
+	schedulerConfig := config.NewSchedulerConfig()
+
+	eventBus := event.NewEventBus()
+	eventBus.Subscribe(event.LogSubscriber)
+
 	taskRepository := persistence.NewTaskPersistence(conn)
 	userRepository := persistence.NewUserPersistence(conn)
 	sessionRepository := persistence.NewSessionPersistence(conn)
-	taskUsecase := usecase.NewTaskUsecase(taskRepository)
+	labelRepository := persistence.NewLabelPersistence(conn)
+	historyRepository := persistence.NewHistoryPersistence(conn)
+	postponementRepository := persistence.NewPostponementPersistence(conn)
+	workspaceRepository := persistence.NewWorkspacePersistence(conn)
+	invitationRepository := persistence.NewInvitationPersistence(conn)
+	commentRepository := persistence.NewCommentPersistence(conn)
+	attachmentRepository := persistence.NewAttachmentPersistence(conn)
+	dependencyRepository := persistence.NewDependencyPersistence(conn)
+	webhookRepository := persistence.NewWebhookPersistence(conn)
+	taskSearcher := persistence.NewTaskSearchPersistence(conn)
+	taskUsecase := usecase.NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventBus, fileStorage)
+	taskStatusUsecase := usecase.NewTaskStatusUsecase(taskRepository, eventBus)
+	trashUsecase := usecase.NewTrashUsecase(taskRepository, fileStorage, schedulerConfig.TrashRetention)
+	reminderUsecase := usecase.NewReminderUsecase(taskRepository, userRepository, config.NewNotificationSender())
 	userService := service.NewUService(userRepository)
 	userUsecase := usecase.NewUserUsecase(userRepository, userService)
 	sessionUsecase := usecase.NewSessionUsecase(sessionRepository)
+	labelUsecase := usecase.NewLabelUsecase(labelRepository)
+	searchUsecase := usecase.NewSearchUsecase(taskSearcher)
+	postponementUsecase := usecase.NewPostponementUsecase(taskRepository, postponementRepository, workspaceRepository, eventBus)
+
+	workspaceUsecase := usecase.NewWorkspaceUsecase(workspaceRepository, invitationRepository, userRepository)
+	commentUsecase := usecase.NewCommentUsecase(taskRepository, commentRepository, userRepository, workspaceRepository)
+	dependencyUsecase := usecase.NewDependencyUsecase(taskRepository, dependencyRepository, workspaceRepository)
+	attachmentUsecase := usecase.NewAttachmentUsecase(taskRepository, attachmentRepository, workspaceRepository, fileStorage)
+	transferUsecase := usecase.NewTransferUsecase(taskRepository, userRepository, labelRepository, workspaceRepository, eventBus)
+	webhookUsecase := usecase.NewWebhookUsecase(taskRepository, webhookRepository, workspaceRepository, config.NewWebhookSender())
 
-	handler := handler.NewHandler(taskUsecase, userUsecase, sessionUsecase)
+	eventBus.Subscribe(event.WebhookSubscriber(webhookUsecase))
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := cli.NewImportCommand(userUsecase, transferUsecase, os.Stdout, os.Getenv).Run(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	handler := handler.NewHandler(taskUsecase, userUsecase, sessionUsecase, labelUsecase, searchUsecase, postponementUsecase, workspaceUsecase, commentUsecase, attachmentUsecase, dependencyUsecase, transferUsecase, webhookUsecase)
+	scheduler := scheduler.NewScheduler(time.Now,
+		scheduler.NewOverdueJob(taskStatusUsecase, schedulerConfig.OverdueInterval),
+		scheduler.NewReminderJob(reminderUsecase, schedulerConfig.ReminderInterval),
+		scheduler.NewPurgeJob(trashUsecase, schedulerConfig.PurgeInterval),
+		scheduler.NewWebhookJob(webhookUsecase, schedulerConfig.WebhookInterval),
+	)
 
 	go func() {
 		handler.Start()
 	}()
 
+	scheduler.Start()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM)
 	<-quit
 	log.Println("Caught SIGTERM, shutting down")
 
+	scheduler.Stop()
 	handler.Stop()
 }
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dependency_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDependencyRepository is a mock of DependencyRepository interface.
+type MockDependencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependencyRepositoryMockRecorder
+}
+
+// MockDependencyRepositoryMockRecorder is the mock recorder for MockDependencyRepository.
+type MockDependencyRepositoryMockRecorder struct {
+	mock *MockDependencyRepository
+}
+
+// NewMockDependencyRepository creates a new mock instance.
+func NewMockDependencyRepository(ctrl *gomock.Controller) *MockDependencyRepository {
+	mock := &MockDependencyRepository{ctrl: ctrl}
+	mock.recorder = &MockDependencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependencyRepository) EXPECT() *MockDependencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDependencyRepository) Create(arg0 *model.Dependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDependencyRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDependencyRepository)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockDependencyRepository) Delete(taskID, blockerID model.TaskID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", taskID, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDependencyRepositoryMockRecorder) Delete(taskID, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDependencyRepository)(nil).Delete), taskID, blockerID)
+}
+
+// FindBlockers mocks base method.
+func (m *MockDependencyRepository) FindBlockers(arg0 []model.TaskID) (map[model.TaskID][]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlockers", arg0)
+	ret0, _ := ret[0].(map[model.TaskID][]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlockers indicates an expected call of FindBlockers.
+func (mr *MockDependencyRepositoryMockRecorder) FindBlockers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlockers", reflect.TypeOf((*MockDependencyRepository)(nil).FindBlockers), arg0)
+}
+
+// FindBlocking mocks base method.
+func (m *MockDependencyRepository) FindBlocking(arg0 model.TaskID) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlocking", arg0)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlocking indicates an expected call of FindBlocking.
+func (mr *MockDependencyRepositoryMockRecorder) FindBlocking(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlocking", reflect.TypeOf((*MockDependencyRepository)(nil).FindBlocking), arg0)
+}
+
+// FindByWorkspaceID mocks base method.
+func (m *MockDependencyRepository) FindByWorkspaceID(arg0 model.WorkspaceID) ([]*model.Dependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWorkspaceID", arg0)
+	ret0, _ := ret[0].([]*model.Dependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWorkspaceID indicates an expected call of FindByWorkspaceID.
+func (mr *MockDependencyRepositoryMockRecorder) FindByWorkspaceID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWorkspaceID", reflect.TypeOf((*MockDependencyRepository)(nil).FindByWorkspaceID), arg0)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: label_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLabelRepository is a mock of LabelRepository interface.
+type MockLabelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelRepositoryMockRecorder
+}
+
+// MockLabelRepositoryMockRecorder is the mock recorder for MockLabelRepository.
+type MockLabelRepositoryMockRecorder struct {
+	mock *MockLabelRepository
+}
+
+// NewMockLabelRepository creates a new mock instance.
+func NewMockLabelRepository(ctrl *gomock.Controller) *MockLabelRepository {
+	mock := &MockLabelRepository{ctrl: ctrl}
+	mock.recorder = &MockLabelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelRepository) EXPECT() *MockLabelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLabelRepository) Create(arg0 *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabelRepository)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockLabelRepository) Delete(arg0 model.LabelID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLabelRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLabelRepository)(nil).Delete), arg0)
+}
+
+// FindByID mocks base method.
+func (m *MockLabelRepository) FindByID(arg0 model.LabelID) (*model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockLabelRepositoryMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockLabelRepository)(nil).FindByID), arg0)
+}
+
+// FindByTaskIDs mocks base method.
+func (m *MockLabelRepository) FindByTaskIDs(arg0 model.UserID, arg1 []model.TaskID) (map[model.TaskID][]*model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTaskIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[model.TaskID][]*model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTaskIDs indicates an expected call of FindByTaskIDs.
+func (mr *MockLabelRepositoryMockRecorder) FindByTaskIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTaskIDs", reflect.TypeOf((*MockLabelRepository)(nil).FindByTaskIDs), arg0, arg1)
+}
+
+// FindByUserID mocks base method.
+func (m *MockLabelRepository) FindByUserID(arg0 model.UserID) ([]*model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0)
+	ret0, _ := ret[0].([]*model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockLabelRepositoryMockRecorder) FindByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockLabelRepository)(nil).FindByUserID), arg0)
+}
+
+// Update mocks base method.
+func (m *MockLabelRepository) Update(arg0 *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabelRepository)(nil).Update), arg0)
+}
+
+// UpdateTaskLabels mocks base method.
+func (m *MockLabelRepository) UpdateTaskLabels(arg0 model.TaskID, arg1 model.UserID, arg2 []model.LabelID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskLabels", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskLabels indicates an expected call of UpdateTaskLabels.
+func (mr *MockLabelRepositoryMockRecorder) UpdateTaskLabels(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskLabels", reflect.TypeOf((*MockLabelRepository)(nil).UpdateTaskLabels), arg0, arg1, arg2)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_sender.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationSender is a mock of NotificationSender interface.
+type MockNotificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSenderMockRecorder
+}
+
+// MockNotificationSenderMockRecorder is the mock recorder for MockNotificationSender.
+type MockNotificationSenderMockRecorder struct {
+	mock *MockNotificationSender
+}
+
+// NewMockNotificationSender creates a new mock instance.
+func NewMockNotificationSender(ctrl *gomock.Controller) *MockNotificationSender {
+	mock := &MockNotificationSender{ctrl: ctrl}
+	mock.recorder = &MockNotificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSender) EXPECT() *MockNotificationSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockNotificationSender) Send(to model.Email, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNotificationSenderMockRecorder) Send(to, subject, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationSender)(nil).Send), to, subject, body)
+}
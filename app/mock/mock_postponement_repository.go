@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postponement_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostponementRepository is a mock of PostponementRepository interface.
+type MockPostponementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostponementRepositoryMockRecorder
+}
+
+// MockPostponementRepositoryMockRecorder is the mock recorder for MockPostponementRepository.
+type MockPostponementRepositoryMockRecorder struct {
+	mock *MockPostponementRepository
+}
+
+// NewMockPostponementRepository creates a new mock instance.
+func NewMockPostponementRepository(ctrl *gomock.Controller) *MockPostponementRepository {
+	mock := &MockPostponementRepository{ctrl: ctrl}
+	mock.recorder = &MockPostponementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostponementRepository) EXPECT() *MockPostponementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPostponementRepository) Create(arg0 *model.Postponement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPostponementRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostponementRepository)(nil).Create), arg0)
+}
+
+// Decide mocks base method.
+func (m *MockPostponementRepository) Decide(arg0 *model.Postponement, arg1 *model.Task, arg2 *model.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockPostponementRepositoryMockRecorder) Decide(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockPostponementRepository)(nil).Decide), arg0, arg1, arg2)
+}
+
+// FindByID mocks base method.
+func (m *MockPostponementRepository) FindByID(arg0 model.PostponementID) (*model.Postponement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*model.Postponement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPostponementRepositoryMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPostponementRepository)(nil).FindByID), arg0)
+}
+
+// FindByTaskID mocks base method.
+func (m *MockPostponementRepository) FindByTaskID(arg0 model.TaskID) ([]*model.Postponement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTaskID", arg0)
+	ret0, _ := ret[0].([]*model.Postponement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTaskID indicates an expected call of FindByTaskID.
+func (mr *MockPostponementRepositoryMockRecorder) FindByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTaskID", reflect.TypeOf((*MockPostponementRepository)(nil).FindByTaskID), arg0)
+}
+
+// FindPendingByApproverID mocks base method.
+func (m *MockPostponementRepository) FindPendingByApproverID(arg0 model.UserID) ([]*model.Postponement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByApproverID", arg0)
+	ret0, _ := ret[0].([]*model.Postponement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByApproverID indicates an expected call of FindPendingByApproverID.
+func (mr *MockPostponementRepositoryMockRecorder) FindPendingByApproverID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByApproverID", reflect.TypeOf((*MockPostponementRepository)(nil).FindPendingByApproverID), arg0)
+}
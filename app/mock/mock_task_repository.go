@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 	model "todo-app/domain/model"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,33 +35,108 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Complete mocks base method.
+func (m *MockTaskRepository) Complete(arg0 []*model.Task, arg1 map[model.TaskID]*model.Task, arg2 []*model.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockTaskRepositoryMockRecorder) Complete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockTaskRepository)(nil).Complete), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
-func (m *MockTaskRepository) Create(arg0 *model.Task) error {
+func (m *MockTaskRepository) Create(arg0 *model.Task, arg1 *model.TaskHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTaskRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockTaskRepository) Delete(arg0 model.TaskID) ([]*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].([]*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskRepository)(nil).Delete), arg0)
+}
+
+// DeleteTrashedBefore mocks base method.
+func (m *MockTaskRepository) DeleteTrashedBefore(arg0 time.Time) (int, []*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrashedBefore", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*model.Attachment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteTrashedBefore indicates an expected call of DeleteTrashedBefore.
+func (mr *MockTaskRepositoryMockRecorder) DeleteTrashedBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrashedBefore", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTrashedBefore), arg0)
+}
+
+// Find mocks base method.
+func (m *MockTaskRepository) Find(arg0 model.TaskQuery) (*model.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].(*model.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockTaskRepositoryMockRecorder) Find(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskRepository)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTaskRepository)(nil).Find), arg0)
 }
 
-// FindAll mocks base method.
-func (m *MockTaskRepository) FindAll() ([]*model.Task, error) {
+// FindArchivedByUserID mocks base method.
+func (m *MockTaskRepository) FindArchivedByUserID(arg0 model.UserID) ([]*model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindArchivedByUserID", arg0)
 	ret0, _ := ret[0].([]*model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAll indicates an expected call of FindAll.
-func (mr *MockTaskRepositoryMockRecorder) FindAll() *gomock.Call {
+// FindArchivedByUserID indicates an expected call of FindArchivedByUserID.
+func (mr *MockTaskRepositoryMockRecorder) FindArchivedByUserID(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTaskRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArchivedByUserID", reflect.TypeOf((*MockTaskRepository)(nil).FindArchivedByUserID), arg0)
+}
+
+// FindByCalendarName mocks base method.
+func (m *MockTaskRepository) FindByCalendarName(arg0 model.UserID, arg1 string) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCalendarName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCalendarName indicates an expected call of FindByCalendarName.
+func (mr *MockTaskRepositoryMockRecorder) FindByCalendarName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCalendarName", reflect.TypeOf((*MockTaskRepository)(nil).FindByCalendarName), arg0, arg1)
 }
 
 // FindByID mocks base method.
@@ -78,16 +154,148 @@ func (mr *MockTaskRepositoryMockRecorder) FindByID(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTaskRepository)(nil).FindByID), arg0)
 }
 
+// FindByParentID mocks base method.
+func (m *MockTaskRepository) FindByParentID(arg0 model.TaskID) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByParentID", arg0)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByParentID indicates an expected call of FindByParentID.
+func (mr *MockTaskRepositoryMockRecorder) FindByParentID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByParentID", reflect.TypeOf((*MockTaskRepository)(nil).FindByParentID), arg0)
+}
+
+// FindByShareToken mocks base method.
+func (m *MockTaskRepository) FindByShareToken(arg0 string) (*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByShareToken", arg0)
+	ret0, _ := ret[0].(*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByShareToken indicates an expected call of FindByShareToken.
+func (mr *MockTaskRepositoryMockRecorder) FindByShareToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByShareToken", reflect.TypeOf((*MockTaskRepository)(nil).FindByShareToken), arg0)
+}
+
+// FindByStatus mocks base method.
+func (m *MockTaskRepository) FindByStatus(arg0 model.Status) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", arg0)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockTaskRepositoryMockRecorder) FindByStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockTaskRepository)(nil).FindByStatus), arg0)
+}
+
+// FindSharedUserIDs mocks base method.
+func (m *MockTaskRepository) FindSharedUserIDs(arg0 model.TaskID) ([]model.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSharedUserIDs", arg0)
+	ret0, _ := ret[0].([]model.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSharedUserIDs indicates an expected call of FindSharedUserIDs.
+func (mr *MockTaskRepositoryMockRecorder) FindSharedUserIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSharedUserIDs", reflect.TypeOf((*MockTaskRepository)(nil).FindSharedUserIDs), arg0)
+}
+
+// FindTrashedByParentID mocks base method.
+func (m *MockTaskRepository) FindTrashedByParentID(arg0 model.TaskID) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashedByParentID", arg0)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashedByParentID indicates an expected call of FindTrashedByParentID.
+func (mr *MockTaskRepositoryMockRecorder) FindTrashedByParentID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashedByParentID", reflect.TypeOf((*MockTaskRepository)(nil).FindTrashedByParentID), arg0)
+}
+
+// FindTrashedByUserID mocks base method.
+func (m *MockTaskRepository) FindTrashedByUserID(arg0 model.UserID) ([]*model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashedByUserID", arg0)
+	ret0, _ := ret[0].([]*model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashedByUserID indicates an expected call of FindTrashedByUserID.
+func (mr *MockTaskRepositoryMockRecorder) FindTrashedByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashedByUserID", reflect.TypeOf((*MockTaskRepository)(nil).FindTrashedByUserID), arg0)
+}
+
+// Import mocks base method.
+func (m *MockTaskRepository) Import(arg0 []*model.Task, arg1 []*model.Label, arg2 map[model.TaskID][]model.LabelID, arg3 []*model.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockTaskRepositoryMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockTaskRepository)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// Share mocks base method.
+func (m *MockTaskRepository) Share(arg0 *model.Task, arg1 []model.UserID, arg2 *model.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockTaskRepositoryMockRecorder) Share(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockTaskRepository)(nil).Share), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
-func (m *MockTaskRepository) Update(arg0 *model.Task) error {
+func (m *MockTaskRepository) Update(arg0 *model.Task, arg1 *model.TaskHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTaskRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepository)(nil).Update), arg0, arg1)
+}
+
+// UpdateAll mocks base method.
+func (m *MockTaskRepository) UpdateAll(arg0 []*model.Task, arg1 []*model.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAll indicates an expected call of UpdateAll.
+func (mr *MockTaskRepositoryMockRecorder) UpdateAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepository)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAll", reflect.TypeOf((*MockTaskRepository)(nil).UpdateAll), arg0, arg1)
 }
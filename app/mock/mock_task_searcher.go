@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_searcher.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskSearcher is a mock of TaskSearcher interface.
+type MockTaskSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskSearcherMockRecorder
+}
+
+// MockTaskSearcherMockRecorder is the mock recorder for MockTaskSearcher.
+type MockTaskSearcherMockRecorder struct {
+	mock *MockTaskSearcher
+}
+
+// NewMockTaskSearcher creates a new mock instance.
+func NewMockTaskSearcher(ctrl *gomock.Controller) *MockTaskSearcher {
+	mock := &MockTaskSearcher{ctrl: ctrl}
+	mock.recorder = &MockTaskSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskSearcher) EXPECT() *MockTaskSearcherMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockTaskSearcher) Search(arg0 model.TaskSearch) ([]*model.TaskHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0)
+	ret0, _ := ret[0].([]*model.TaskHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskSearcherMockRecorder) Search(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskSearcher)(nil).Search), arg0)
+}
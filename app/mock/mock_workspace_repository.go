@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "todo-app/domain/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkspaceRepository is a mock of WorkspaceRepository interface.
+type MockWorkspaceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceRepositoryMockRecorder
+}
+
+// MockWorkspaceRepositoryMockRecorder is the mock recorder for MockWorkspaceRepository.
+type MockWorkspaceRepositoryMockRecorder struct {
+	mock *MockWorkspaceRepository
+}
+
+// NewMockWorkspaceRepository creates a new mock instance.
+func NewMockWorkspaceRepository(ctrl *gomock.Controller) *MockWorkspaceRepository {
+	mock := &MockWorkspaceRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceRepository) EXPECT() *MockWorkspaceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceRepository) Create(arg0 *model.Workspace, arg1 *model.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteMember mocks base method.
+func (m *MockWorkspaceRepository) DeleteMember(arg0 model.WorkspaceID, arg1 model.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockWorkspaceRepositoryMockRecorder) DeleteMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockWorkspaceRepository)(nil).DeleteMember), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockWorkspaceRepository) FindByID(arg0 model.WorkspaceID) (*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWorkspaceRepositoryMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWorkspaceRepository)(nil).FindByID), arg0)
+}
+
+// FindByUserID mocks base method.
+func (m *MockWorkspaceRepository) FindByUserID(arg0 model.UserID) ([]*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0)
+	ret0, _ := ret[0].([]*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockWorkspaceRepositoryMockRecorder) FindByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockWorkspaceRepository)(nil).FindByUserID), arg0)
+}
+
+// FindMember mocks base method.
+func (m *MockWorkspaceRepository) FindMember(arg0 model.WorkspaceID, arg1 model.UserID) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMember", arg0, arg1)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMember indicates an expected call of FindMember.
+func (mr *MockWorkspaceRepositoryMockRecorder) FindMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMember", reflect.TypeOf((*MockWorkspaceRepository)(nil).FindMember), arg0, arg1)
+}
+
+// FindMembers mocks base method.
+func (m *MockWorkspaceRepository) FindMembers(arg0 model.WorkspaceID) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMembers", arg0)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMembers indicates an expected call of FindMembers.
+func (mr *MockWorkspaceRepositoryMockRecorder) FindMembers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMembers", reflect.TypeOf((*MockWorkspaceRepository)(nil).FindMembers), arg0)
+}
+
+// UpdateMember mocks base method.
+func (m *MockWorkspaceRepository) UpdateMember(arg0 *model.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockWorkspaceRepositoryMockRecorder) UpdateMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockWorkspaceRepository)(nil).UpdateMember), arg0)
+}
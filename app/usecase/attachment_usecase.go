@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"net/http"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type AttachmentUsecase interface {
+	FindByTaskID(session Session, taskID model.TaskID) ([]*model.Attachment, error)
+	Upload(session Session, taskID model.TaskID, name string, size int64, r io.Reader) (*model.Attachment, error)
+	Download(session Session, id model.AttachmentID) (*model.Attachment, io.ReadCloser, error)
+	Delete(session Session, id model.AttachmentID) error
+}
+
+type attachmentUsecase struct {
+	taskRepository       repository.TaskRepository
+	attachmentRepository repository.AttachmentRepository
+	workspaceRepository  repository.WorkspaceRepository
+	fileStorage          FileStorage
+}
+
+func NewAttachmentUsecase(tr repository.TaskRepository, ar repository.AttachmentRepository, wr repository.WorkspaceRepository, fs FileStorage) AttachmentUsecase {
+	return &attachmentUsecase{
+		taskRepository:       tr,
+		attachmentRepository: ar,
+		workspaceRepository:  wr,
+		fileStorage:          fs,
+	}
+}
+
+func (u *attachmentUsecase) FindByTaskID(s Session, taskID model.TaskID) ([]*model.Attachment, error) {
+	if _, err := findVisibleTask(u.taskRepository, u.workspaceRepository, s, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := u.attachmentRepository.FindByTaskID(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find attachments, taskID: %s", taskID)
+	}
+
+	return attachments, nil
+}
+
+// Upload attaches the file of the size read from r to the task. The users who can work on the task can attach files.
+// The type of the file is detected from its content.
+func (u *attachmentUsecase) Upload(s Session, taskID model.TaskID, name string, size int64, r io.Reader) (*model.Attachment, error) {
+	t, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	a, err := model.NewAttachment(model.AttachmentID(model.CreateUUID()), *t, s.UserID, name, http.DetectContentType(head), size, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to attach file")
+	}
+
+	if err := u.fileStorage.Put(a.StorageKey, br, a.Size, a.ContentType); err != nil {
+		return nil, errors.Wrap(err, "failed to store file")
+	}
+
+	if err := u.attachmentRepository.Create(a); err != nil {
+		u.deleteFile(a)
+
+		return nil, errors.Wrap(err, "failed to store attachment")
+	}
+
+	return a, nil
+}
+
+// Download opens the attached file. The caller has to close it.
+func (u *attachmentUsecase) Download(s Session, id model.AttachmentID) (*model.Attachment, io.ReadCloser, error) {
+	a, _, err := u.findAttachment(s, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := u.fileStorage.Get(a.StorageKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to open file, attachmentID: %s", id)
+	}
+
+	return a, f, nil
+}
+
+// Delete removes the attachment and its file. The uploader and the users who manage the task can delete it.
+func (u *attachmentUsecase) Delete(s Session, id model.AttachmentID) error {
+	a, access, err := u.findAttachment(s, id)
+	if err != nil {
+		return err
+	}
+
+	if !model.AttachmentDeletable(*a, s.UserID, access) {
+		return errors.Wrapf(ErrForbidden, "session user is not uploader or task manager, attachmentID: %s", id)
+	}
+
+	if err := u.attachmentRepository.Delete(id); err != nil {
+		return errors.Wrap(err, "failed to delete attachment")
+	}
+
+	u.deleteFile(a)
+
+	return nil
+}
+
+// findAttachment finds the attachment of a task visible to the session user, together with the access of the user to the task.
+func (u *attachmentUsecase) findAttachment(s Session, id model.AttachmentID) (*model.Attachment, model.TaskAccess, error) {
+	a, err := u.attachmentRepository.FindByID(id)
+	if err != nil {
+		return nil, model.NoAccess, errors.Wrapf(err, "failed to find attachment, attachmentID: %s", id)
+	} else if a == nil {
+		return nil, model.NoAccess, errors.Wrapf(ErrNotFound, "attachment is not found, attachmentID: %s", id)
+	}
+
+	_, access, err := findTask(u.taskRepository, u.workspaceRepository, s, a.TaskID, model.ViewAccess)
+	if err != nil {
+		return nil, model.NoAccess, err
+	}
+
+	return a, access, nil
+}
+
+// deleteFile deletes the file of the attachment which is not stored any more.
+func (u *attachmentUsecase) deleteFile(a *model.Attachment) {
+	deleteFiles(u.fileStorage, []*model.Attachment{a})
+}
+
+// deleteFiles deletes the files of the attachments which are not stored any more, e.g. of deleted tasks.
+// A file which fails to be deleted is only logged, since nothing refers to it.
+func deleteFiles(fs FileStorage, attachments []*model.Attachment) {
+	for _, a := range attachments {
+		if err := fs.Delete(a.StorageKey); err != nil {
+			log.Printf("failed to delete file of attachment %s: %+v", a.ID, err)
+		}
+	}
+}
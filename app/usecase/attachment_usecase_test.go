@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentUploadUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+
+	task := &model.Task{ID: taskID, UserID: session.UserID, Name: "Venue Reservation"}
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	errStorage := errors.New("disk is full")
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name                    string
+		role                    model.Role
+		content                 []byte
+		putErr                  error
+		createErr               error
+		expectedPutCallTimes    int
+		expectedCreateCallTimes int
+		expectedDeleteCallTimes int
+		expectedContentType     string
+		expectedErr             error
+	}{
+		{
+			"normal case",
+			model.RoleMember,
+			png,
+			nil,
+			nil,
+			1,
+			1,
+			0,
+			"image/png",
+			nil,
+		},
+		{
+			"text case",
+			model.RoleMember,
+			[]byte("Venue is booked"),
+			nil,
+			nil,
+			1,
+			1,
+			0,
+			"text/plain",
+			nil,
+		},
+		{
+			"error case: html is not allowed",
+			model.RoleMember,
+			[]byte("<html><script>alert(1)</script></html>"),
+			nil,
+			nil,
+			0,
+			0,
+			0,
+			"",
+			ErrInvalidArgument,
+		},
+		{
+			"error case: viewer cannot attach",
+			model.RoleViewer,
+			png,
+			nil,
+			nil,
+			0,
+			0,
+			0,
+			"",
+			ErrForbidden,
+		},
+		{
+			"error case: storage fails",
+			model.RoleMember,
+			png,
+			errStorage,
+			nil,
+			1,
+			0,
+			0,
+			"image/png",
+			errStorage,
+		},
+		{
+			"error case: file is deleted when attachment fails to be stored",
+			model.RoleMember,
+			png,
+			nil,
+			errDB,
+			1,
+			1,
+			1,
+			"image/png",
+			errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			attachmentRepository := mock.NewMockAttachmentRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewAttachmentUsecase(taskRepository, attachmentRepository, workspaceRepository, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+
+			var key string
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(taskID).Return(task, nil).Times(1),
+				fileStorage.EXPECT().Put(gomock.Any(), gomock.Any(), int64(len(tt.content)), tt.expectedContentType).DoAndReturn(func(k string, r io.Reader, size int64, contentType string) error {
+					key = k
+					stored, _ := io.ReadAll(r)
+					assert.Exactly(t, tt.content, stored)
+
+					return tt.putErr
+				}).Times(tt.expectedPutCallTimes),
+				attachmentRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(a *model.Attachment) error {
+					assert.Exactly(t, key, a.StorageKey)
+
+					return tt.createErr
+				}).Times(tt.expectedCreateCallTimes),
+				fileStorage.EXPECT().Delete(gomock.Any()).DoAndReturn(func(k string) error {
+					assert.Exactly(t, key, k)
+
+					return nil
+				}).Times(tt.expectedDeleteCallTimes),
+			)
+
+			output, err := usecase.Upload(session, taskID, "upload", int64(len(tt.content)), bytes.NewReader(tt.content))
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedContentType, output.ContentType)
+				assert.Exactly(t, session.UserID, output.UserID)
+			}
+		})
+	}
+}
+
+func TestAttachmentDeleteUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	id := model.AttachmentID("c0f0d3a4-7a55-4f0e-9a55-1f7a3c6d2b10")
+
+	task := &model.Task{ID: taskID, UserID: model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33"), Name: "Venue Reservation"}
+	attachment := func(userID model.UserID) *model.Attachment {
+		return &model.Attachment{ID: id, TaskID: taskID, UserID: userID, StorageKey: "tasks/" + string(taskID) + "/" + string(id)}
+	}
+
+	tests := []struct {
+		name              string
+		role              model.Role
+		attachment        *model.Attachment
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case: uploader",
+			model.RoleMember,
+			attachment(session.UserID),
+			1,
+			nil,
+		},
+		{
+			"normal case: workspace admin",
+			model.RoleAdmin,
+			attachment("other"),
+			1,
+			nil,
+		},
+		{
+			"error case: attachment of other user",
+			model.RoleMember,
+			attachment("other"),
+			0,
+			ErrForbidden,
+		},
+		{
+			"error case: attachment is not found",
+			model.RoleMember,
+			nil,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			attachmentRepository := mock.NewMockAttachmentRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewAttachmentUsecase(taskRepository, attachmentRepository, workspaceRepository, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+			taskRepository.EXPECT().FindByID(taskID).Return(task, nil).AnyTimes()
+
+			gomock.InOrder(
+				attachmentRepository.EXPECT().FindByID(id).Return(tt.attachment, nil).Times(1),
+				attachmentRepository.EXPECT().Delete(id).Return(nil).Times(tt.expectedCallTimes),
+				fileStorage.EXPECT().Delete("tasks/"+string(taskID)+"/"+string(id)).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			err := usecase.Delete(session, id)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
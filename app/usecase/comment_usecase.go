@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type CommentUsecase interface {
+	FindByTaskID(session Session, taskID model.TaskID) (*model.Discussion, error)
+	Create(session Session, taskID model.TaskID, parentID model.CommentID, body string) (*model.Comment, error)
+	Update(session Session, id model.CommentID, body string) (*model.Comment, error)
+	Delete(session Session, id model.CommentID) error
+}
+
+type commentUsecase struct {
+	taskRepository      repository.TaskRepository
+	commentRepository   repository.CommentRepository
+	userRepository      repository.UserRepository
+	workspaceRepository repository.WorkspaceRepository
+}
+
+func NewCommentUsecase(tr repository.TaskRepository, cr repository.CommentRepository, ur repository.UserRepository, wr repository.WorkspaceRepository) CommentUsecase {
+	return &commentUsecase{
+		taskRepository:      tr,
+		commentRepository:   cr,
+		userRepository:      ur,
+		workspaceRepository: wr,
+	}
+}
+
+// FindByTaskID finds the comment threads of the task with their authors.
+func (u *commentUsecase) FindByTaskID(s Session, taskID model.TaskID) (*model.Discussion, error) {
+	_, access, err := findTask(u.taskRepository, u.workspaceRepository, s, taskID, model.ViewAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := u.commentRepository.FindByTaskID(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find comments, taskID: %s", taskID)
+	}
+
+	userIDs := make([]model.UserID, 0, len(comments))
+	for _, c := range comments {
+		userIDs = append(userIDs, c.UserID)
+	}
+
+	users, err := findUsers(u.userRepository, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Discussion{Threads: model.CommentThreads(comments), Users: users, Access: access}, nil
+}
+
+// Create comments on the task, or replies to the parent comment when parentID is given.
+// The users who can view the task can comment on it.
+func (u *commentUsecase) Create(s Session, taskID model.TaskID, parentID model.CommentID, body string) (*model.Comment, error) {
+	t, err := findVisibleTask(u.taskRepository, u.workspaceRepository, s, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *model.Comment
+
+	if parentID != "" {
+		if parent, err = u.commentRepository.FindByID(parentID); err != nil {
+			return nil, errors.Wrapf(err, "failed to find comment, commentID: %s", parentID)
+		} else if parent == nil {
+			return nil, errors.Wrapf(ErrInvalidArgument, "parent comment is not found, commentID: %s", parentID)
+		}
+	}
+
+	c, err := model.NewComment(model.CommentID(model.CreateUUID()), *t, s.UserID, parent, body, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create comment")
+	}
+
+	if err := u.commentRepository.Create(c); err != nil {
+		return nil, errors.Wrap(err, "failed to store comment")
+	}
+
+	return c, nil
+}
+
+// Update edits the comment, which only its author can.
+func (u *commentUsecase) Update(s Session, id model.CommentID, body string) (*model.Comment, error) {
+	fetched, _, err := u.findComment(s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fetched.IsWrittenBy(s.UserID) {
+		return nil, errors.Wrapf(ErrForbidden, "session user is not comment author, commentID: %s", id)
+	}
+
+	c, err := model.CommentEdit(*fetched, body, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to edit comment")
+	}
+
+	if err := u.commentRepository.Update(c); err != nil {
+		return nil, errors.Wrap(err, "failed to update comment")
+	}
+
+	return c, nil
+}
+
+// Delete deletes the comment. Its author and the users who manage the task can delete it.
+func (u *commentUsecase) Delete(s Session, id model.CommentID) error {
+	fetched, access, err := u.findComment(s, id)
+	if err != nil {
+		return err
+	}
+
+	if !model.CommentModeratable(*fetched, s.UserID, access) {
+		return errors.Wrapf(ErrForbidden, "session user is not comment author or task manager, commentID: %s", id)
+	}
+
+	c, err := model.CommentDelete(*fetched, getNow())
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to delete comment")
+	}
+
+	if err := u.commentRepository.Update(c); err != nil {
+		return errors.Wrap(err, "failed to update comment")
+	}
+
+	return nil
+}
+
+// findComment finds the comment on a task visible to the session user, together with the access of the user to the task.
+func (u *commentUsecase) findComment(s Session, id model.CommentID) (*model.Comment, model.TaskAccess, error) {
+	c, err := u.commentRepository.FindByID(id)
+	if err != nil {
+		return nil, model.NoAccess, errors.Wrapf(err, "failed to find comment, commentID: %s", id)
+	} else if c == nil {
+		return nil, model.NoAccess, errors.Wrapf(ErrNotFound, "comment is not found, commentID: %s", id)
+	}
+
+	_, access, err := findTask(u.taskRepository, u.workspaceRepository, s, c.TaskID, model.ViewAccess)
+	if err != nil {
+		return nil, model.NoAccess, err
+	}
+
+	return c, access, nil
+}
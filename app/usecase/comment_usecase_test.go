@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentCreateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	parentID := model.CommentID("c0f0d3a4-7a55-4f0e-9a55-1f7a3c6d2b10")
+
+	task := &model.Task{ID: taskID, UserID: model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33"), Name: "Venue Reservation"}
+
+	tests := []struct {
+		name                  string
+		parentID              model.CommentID
+		parent                *model.Comment
+		body                  string
+		expectedFindCallTimes int
+		expectedCallTimes     int
+		expectedErr           error
+	}{
+		{
+			"normal case",
+			"",
+			nil,
+			"Venue is booked",
+			0,
+			1,
+			nil,
+		},
+		{
+			"reply case",
+			parentID,
+			&model.Comment{ID: parentID, TaskID: taskID},
+			"Thanks",
+			1,
+			1,
+			nil,
+		},
+		{
+			"error case: parent is not found",
+			parentID,
+			nil,
+			"Thanks",
+			1,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: body is empty",
+			"",
+			nil,
+			" ",
+			0,
+			0,
+			ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			commentRepository := mock.NewMockCommentRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			usecase := NewCommentUsecase(taskRepository, commentRepository, userRepository, workspaceRepository)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleViewer}, nil).AnyTimes()
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(taskID).Return(task, nil).Times(1),
+				commentRepository.EXPECT().FindByID(parentID).Return(tt.parent, nil).Times(tt.expectedFindCallTimes),
+				commentRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(c *model.Comment) error {
+					assert.Exactly(t, taskID, c.TaskID)
+					assert.Exactly(t, session.UserID, c.UserID)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Create(session, taskID, tt.parentID, tt.body)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				if tt.parent != nil {
+					assert.Exactly(t, parentID, *output.ParentID)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentUpdateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	id := model.CommentID("c0f0d3a4-7a55-4f0e-9a55-1f7a3c6d2b10")
+	createdAt := time.Date(2022, 1, 26, 10, 0, 0, 0, time.Local)
+
+	task := &model.Task{ID: taskID, UserID: session.UserID, Name: "Venue Reservation"}
+
+	tests := []struct {
+		name              string
+		comment           *model.Comment
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			&model.Comment{ID: id, TaskID: taskID, UserID: session.UserID, Body: "Venue is booked", CreatedAt: createdAt, UpdatedAt: createdAt},
+			1,
+			nil,
+		},
+		{
+			"error case: comment of other user",
+			&model.Comment{ID: id, TaskID: taskID, UserID: "other", Body: "Venue is booked", CreatedAt: createdAt, UpdatedAt: createdAt},
+			0,
+			ErrForbidden,
+		},
+		{
+			"error case: comment is not found",
+			nil,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			commentRepository := mock.NewMockCommentRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			usecase := NewCommentUsecase(taskRepository, commentRepository, userRepository, workspaceRepository)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+			taskRepository.EXPECT().FindByID(taskID).Return(task, nil).AnyTimes()
+
+			gomock.InOrder(
+				commentRepository.EXPECT().FindByID(id).Return(tt.comment, nil).Times(1),
+				commentRepository.EXPECT().Update(gomock.Any()).DoAndReturn(func(c *model.Comment) error {
+					assert.Exactly(t, "Venue is booked for Friday", c.Body)
+					assert.True(t, c.IsEdited())
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if _, err := usecase.Update(session, id, "Venue is booked for Friday"); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestCommentDeleteUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	otherID := model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	id := model.CommentID("c0f0d3a4-7a55-4f0e-9a55-1f7a3c6d2b10")
+
+	tests := []struct {
+		name              string
+		taskOwnerID       model.UserID
+		authorID          model.UserID
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"author case",
+			otherID,
+			session.UserID,
+			1,
+			nil,
+		},
+		{
+			"task owner moderates case",
+			session.UserID,
+			otherID,
+			1,
+			nil,
+		},
+		{
+			"error case: neither author nor task owner",
+			otherID,
+			otherID,
+			0,
+			ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			commentRepository := mock.NewMockCommentRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			usecase := NewCommentUsecase(taskRepository, commentRepository, userRepository, workspaceRepository)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: taskID, UserID: tt.taskOwnerID, Name: "Venue Reservation"}
+			comment := &model.Comment{ID: id, TaskID: taskID, UserID: tt.authorID, Body: "Venue is booked"}
+
+			gomock.InOrder(
+				commentRepository.EXPECT().FindByID(id).Return(comment, nil).Times(1),
+				taskRepository.EXPECT().FindByID(taskID).Return(task, nil).Times(1),
+				commentRepository.EXPECT().Update(gomock.Any()).DoAndReturn(func(c *model.Comment) error {
+					assert.True(t, c.IsDeleted())
+					assert.Empty(t, c.Body)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Delete(session, id); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
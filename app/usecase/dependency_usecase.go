@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type DependencyUsecase interface {
+	FindByTaskID(session Session, taskID model.TaskID) (*model.TaskDependencies, error)
+	FindBlocked(session Session, tasks []*model.Task) (map[model.TaskID]bool, error)
+	Add(session Session, taskID, blockerID model.TaskID) error
+	Remove(session Session, taskID, blockerID model.TaskID) error
+}
+
+type dependencyUsecase struct {
+	taskRepository       repository.TaskRepository
+	dependencyRepository repository.DependencyRepository
+	workspaceRepository  repository.WorkspaceRepository
+}
+
+func NewDependencyUsecase(tr repository.TaskRepository, dr repository.DependencyRepository, wr repository.WorkspaceRepository) DependencyUsecase {
+	return &dependencyUsecase{
+		taskRepository:       tr,
+		dependencyRepository: dr,
+		workspaceRepository:  wr,
+	}
+}
+
+// FindByTaskID finds the tasks which block the task and the ones blocked by it, which the session user can see.
+func (u *dependencyUsecase) FindByTaskID(s Session, taskID model.TaskID) (*model.TaskDependencies, error) {
+	t, err := findVisibleTask(u.taskRepository, u.workspaceRepository, s, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := u.dependencyRepository.FindBlockers([]model.TaskID{taskID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find blockers, taskID: %s", taskID)
+	}
+
+	blocking, err := u.dependencyRepository.FindBlocking(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find blocked tasks, taskID: %s", taskID)
+	}
+
+	// INFO: a task shared with a user outside of the workspace must not reveal the other tasks of the workspace
+	m, err := u.workspaceRepository.FindMember(t.WorkspaceID, s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member, workspaceID: %s", t.WorkspaceID)
+	}
+
+	return &model.TaskDependencies{
+		BlockedBy: visibleTasks(blockers[taskID], s.UserID, m),
+		Blocking:  visibleTasks(blocking, s.UserID, m),
+		Blocked:   len(model.IncompleteBlockers(blockers[taskID])) > 0,
+	}, nil
+}
+
+// FindBlocked finds which of the tasks are blocked by incomplete tasks.
+func (u *dependencyUsecase) FindBlocked(s Session, tasks []*model.Task) (map[model.TaskID]bool, error) {
+	taskIDs := make([]model.TaskID, 0, len(tasks))
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+	}
+
+	blockers, err := u.dependencyRepository.FindBlockers(taskIDs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find blockers of tasks, userID: %s", s.UserID)
+	}
+
+	blocked := make(map[model.TaskID]bool)
+	for id, b := range blockers {
+		blocked[id] = len(model.IncompleteBlockers(b)) > 0
+	}
+
+	return blocked, nil
+}
+
+// Add makes the task blocked by the blocker. The users who can work on the task can add its blockers,
+// which have to be visible to them.
+func (u *dependencyUsecase) Add(s Session, taskID, blockerID model.TaskID) error {
+	t, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, taskID)
+	if err != nil {
+		return err
+	}
+
+	blocker, err := findVisibleTask(u.taskRepository, u.workspaceRepository, s, blockerID)
+	if errors.Is(err, ErrNotFound) {
+		return errors.Wrapf(ErrInvalidArgument, "blocking task is not found, blockerID: %s", blockerID)
+	} else if err != nil {
+		return err
+	}
+
+	dependencies, err := u.dependencyRepository.FindByWorkspaceID(t.WorkspaceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find dependencies, workspaceID: %s", t.WorkspaceID)
+	}
+
+	d, err := model.NewDependency(*t, *blocker, dependencies, getNow())
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to add dependency")
+	}
+
+	if err := u.dependencyRepository.Create(d); err != nil {
+		return errors.Wrap(err, "failed to store dependency")
+	}
+
+	return nil
+}
+
+func (u *dependencyUsecase) Remove(s Session, taskID, blockerID model.TaskID) error {
+	if _, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, taskID); err != nil {
+		return err
+	}
+
+	if err := u.dependencyRepository.Delete(taskID, blockerID); err != nil {
+		return errors.Wrap(err, "failed to delete dependency")
+	}
+
+	return nil
+}
+
+// visibleTasks filters the tasks which the user can see as the member of their workspace.
+func visibleTasks(tasks []*model.Task, userID model.UserID, m *model.Member) []*model.Task {
+	var visible []*model.Task
+
+	for _, t := range tasks {
+		if model.TaskAccessOf(*t, userID, m, nil) >= model.ViewAccess {
+			visible = append(visible, t)
+		}
+	}
+
+	return visible
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyAddUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("0a5c1f9e-3c55-4d4e-9d8b-8f0e8e1f3b11")
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	blockerID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+
+	task := &model.Task{ID: taskID, UserID: session.UserID, WorkspaceID: workspaceID, Name: "Venue Reservation"}
+	blocker := &model.Task{ID: blockerID, UserID: session.UserID, WorkspaceID: workspaceID, Name: "Decide date"}
+
+	tests := []struct {
+		name                  string
+		role                  model.Role
+		blocker               *model.Task
+		dependencies          []*model.Dependency
+		expectedFindCallTimes int
+		expectedCallTimes     int
+		expectedErr           error
+	}{
+		{
+			"normal case",
+			model.RoleMember,
+			blocker,
+			nil,
+			1,
+			1,
+			nil,
+		},
+		{
+			"error case: cycle",
+			model.RoleMember,
+			blocker,
+			[]*model.Dependency{{TaskID: blockerID, BlockerID: taskID}},
+			1,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: blocker is not found",
+			model.RoleMember,
+			nil,
+			nil,
+			0,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: viewer cannot add",
+			model.RoleViewer,
+			blocker,
+			nil,
+			0,
+			0,
+			ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			usecase := NewDependencyUsecase(taskRepository, dependencyRepository, workspaceRepository)
+
+			workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+			taskRepository.EXPECT().FindByID(taskID).Return(task, nil).Times(1)
+			taskRepository.EXPECT().FindByID(blockerID).Return(tt.blocker, nil).AnyTimes()
+
+			gomock.InOrder(
+				dependencyRepository.EXPECT().FindByWorkspaceID(workspaceID).Return(tt.dependencies, nil).Times(tt.expectedFindCallTimes),
+				dependencyRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(d *model.Dependency) error {
+					assert.Exactly(t, taskID, d.TaskID)
+					assert.Exactly(t, blockerID, d.BlockerID)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			err := usecase.Add(session, taskID, blockerID)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestDependencyFindBlockedUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	usecase := NewDependencyUsecase(taskRepository, dependencyRepository, workspaceRepository)
+
+	tasks := []*model.Task{{ID: "blocked"}, {ID: "unblocked"}, {ID: "free"}}
+
+	dependencyRepository.EXPECT().FindBlockers([]model.TaskID{"blocked", "unblocked", "free"}).Return(map[model.TaskID][]*model.Task{
+		"blocked":   {{ID: "working", Status: model.Working}, {ID: "done", Status: model.Completed}},
+		"unblocked": {{ID: "done", Status: model.Completed}},
+	}, nil).Times(1)
+
+	blocked, err := usecase.FindBlocked(session, tasks)
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.True(t, blocked["blocked"])
+	assert.False(t, blocked["unblocked"])
+	assert.False(t, blocked["free"])
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import "github.com/pkg/errors"
+
+// Error kinds returned by usecases. Interfaces can classify a returned error
+// with errors.Is and map it to a response, e.g. an HTTP status code.
+var (
+	ErrNotFound        = errors.New("resource is not found")
+	ErrForbidden       = errors.New("operation is not permitted")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("resource conflicts")
+	ErrUnauthenticated = errors.New("authentication failed")
+	// ErrApprovalRequired means the operation cannot be done directly but has to be requested for approval.
+	ErrApprovalRequired = errors.New("approval is required")
+)
+
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Is(target error) bool {
+	return e.kind == target
+}
+
+// withKind marks err as kind without changing its message.
+func withKind(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &kindError{kind: kind, err: err}
+}
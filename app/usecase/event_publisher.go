@@ -0,0 +1,8 @@
+//go:generate mockgen -source=event_publisher.go -destination=../mock/mock_event_publisher.go -package=mock
+package usecase
+
+import "todo-app/domain/model"
+
+type EventPublisher interface {
+	Publish(model.TaskEvent)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=file_storage.go -destination=../mock/mock_file_storage.go -package=mock
+package usecase
+
+import "io"
+
+// FileStorage keeps the contents of files by keys, which are slash-separated paths.
+type FileStorage interface {
+	// Put stores the size bytes read from r under the key, replacing the file stored under it if any.
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get opens the file stored under the key. The caller has to close it.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the file stored under the key. Deleting a missing file is not an error.
+	Delete(key string) error
+}
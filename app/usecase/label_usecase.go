@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type LabelUsecase interface {
+	Create(session Session, name, color string) (*model.Label, error)
+	FindByID(session Session, id model.LabelID) (*model.Label, error)
+	FindByUserID(session Session) ([]*model.Label, error)
+	Update(session Session, id model.LabelID, name, color string) error
+	Delete(session Session, id model.LabelID) error
+}
+
+type labelUsecase struct {
+	labelRepository repository.LabelRepository
+}
+
+func NewLabelUsecase(lr repository.LabelRepository) LabelUsecase {
+	return &labelUsecase{
+		labelRepository: lr,
+	}
+}
+
+func (u *labelUsecase) Create(s Session, name, color string) (*model.Label, error) {
+	id := model.CreateUUID()
+
+	l, err := model.NewLabel(model.LabelID(id), s.UserID, name, color)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create label")
+	}
+
+	if err := u.labelRepository.Create(l); err != nil {
+		return nil, errors.Wrap(err, "failed to store label")
+	}
+
+	return l, nil
+}
+
+func (u *labelUsecase) FindByID(s Session, id model.LabelID) (*model.Label, error) {
+	return findOwnedLabel(u.labelRepository, s, id)
+}
+
+func (u *labelUsecase) FindByUserID(s Session) ([]*model.Label, error) {
+	labels, err := u.labelRepository.FindByUserID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find labels, userID: %s", s.UserID)
+	}
+
+	return labels, nil
+}
+
+func (u *labelUsecase) Update(s Session, id model.LabelID, name, color string) error {
+	fetchedLabel, err := findOwnedLabel(u.labelRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	l, err := model.LabelSet(*fetchedLabel, name, color)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to set label")
+	}
+
+	if err := u.labelRepository.Update(l); err != nil {
+		return errors.Wrap(err, "failed to update label")
+	}
+
+	return nil
+}
+
+// Delete deletes the label and detaches it from the tasks.
+func (u *labelUsecase) Delete(s Session, id model.LabelID) error {
+	if _, err := findOwnedLabel(u.labelRepository, s, id); err != nil {
+		return err
+	}
+
+	if err := u.labelRepository.Delete(id); err != nil {
+		return errors.Wrap(err, "failed to delete label")
+	}
+
+	return nil
+}
+
+// findOwnedLabel finds the label of the session user. Labels of other users are reported as not found.
+func findOwnedLabel(lr repository.LabelRepository, s Session, id model.LabelID) (*model.Label, error) {
+	l, err := lr.FindByID(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find label, labelID: %s", id)
+	} else if l == nil || !l.IsOwnedBy(s.UserID) {
+		return nil, errors.Wrapf(ErrNotFound, "label is not found, labelID: %s", id)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelCreateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	usecase := NewLabelUsecase(labelRepository)
+
+	labelRepository.EXPECT().Create(gomock.Any()).Return(nil).Times(1)
+
+	output, err := usecase.Create(session, "Work", "#ff8800")
+	assert.Nil(t, err)
+	assert.Exactly(t, session.UserID, output.UserID)
+
+	_, err = usecase.Create(session, "Work", "orange")
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "invalid argument error is expected but received: %v", err)
+}
+
+func TestLabelUpdateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.LabelID("5a9b4c1e-2f3d-4e5f-8a9b-0c1d2e3f4a5b")
+
+	tests := []struct {
+		name              string
+		fetchedLabel      *model.Label
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			&model.Label{ID: id, UserID: session.UserID, Name: "Work", Color: "#ff8800"},
+			1,
+			nil,
+		},
+		{
+			"error case: label of other user",
+			&model.Label{ID: id, UserID: model.UserID("other"), Name: "Work", Color: "#ff8800"},
+			0,
+			ErrNotFound,
+		},
+		{
+			"error case: label is not found",
+			nil,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			usecase := NewLabelUsecase(labelRepository)
+
+			gomock.InOrder(
+				labelRepository.EXPECT().FindByID(id).Return(tt.fetchedLabel, nil).Times(1),
+				labelRepository.EXPECT().Update(&model.Label{ID: id, UserID: session.UserID, Name: "Private", Color: "#0088ff"}).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Update(session, id, "Private", "#0088ff"); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
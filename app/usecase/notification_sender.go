@@ -0,0 +1,8 @@
+//go:generate mockgen -source=notification_sender.go -destination=../mock/mock_notification_sender.go -package=mock
+package usecase
+
+import "todo-app/domain/model"
+
+type NotificationSender interface {
+	Send(to model.Email, subject, body string) error
+}
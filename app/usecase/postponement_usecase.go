@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type PostponementUsecase interface {
+	Request(session Session, taskID model.TaskID, due model.Due, reason string) (*model.Postponement, error)
+	FindByTaskID(session Session, taskID model.TaskID) ([]*model.Postponement, error)
+	FindPending(session Session) ([]*model.Postponement, error)
+	Approve(session Session, id model.PostponementID, comment string) (*model.Postponement, error)
+	Reject(session Session, id model.PostponementID, comment string) (*model.Postponement, error)
+}
+
+type postponementUsecase struct {
+	taskRepository         repository.TaskRepository
+	postponementRepository repository.PostponementRepository
+	workspaceRepository    repository.WorkspaceRepository
+	eventPublisher         EventPublisher
+}
+
+func NewPostponementUsecase(tr repository.TaskRepository, pr repository.PostponementRepository, wr repository.WorkspaceRepository, ep EventPublisher) PostponementUsecase {
+	return &postponementUsecase{
+		taskRepository:         tr,
+		postponementRepository: pr,
+		workspaceRepository:    wr,
+		eventPublisher:         ep,
+	}
+}
+
+// Request requests to move the deadline of the task which needs approval to be postponed.
+// The users who can work on the task can request it.
+// A task has at most one pending postponement at a time.
+func (u *postponementUsecase) Request(s Session, taskID model.TaskID, due model.Due, reason string) (*model.Postponement, error) {
+	t, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	postponements, err := u.postponementRepository.FindByTaskID(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find postponements, taskID: %s", taskID)
+	}
+
+	for _, p := range postponements {
+		if p.IsPending() {
+			return nil, errors.Wrapf(ErrConflict, "postponement is already pending, postponementID: %s", p.ID)
+		}
+	}
+
+	var members []*model.Member
+
+	// INFO: the creator is the approver, so the members are needed only to find another approver for the creator
+	if t.UserID == s.UserID {
+		if members, err = u.workspaceRepository.FindMembers(t.WorkspaceID); err != nil {
+			return nil, errors.Wrapf(err, "failed to find members, workspaceID: %s", t.WorkspaceID)
+		}
+	}
+
+	approverID, err := model.PostponementApprover(*t, s.UserID, members)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrForbidden, err), "failed to request postponement")
+	}
+
+	p, err := model.NewPostponement(model.PostponementID(model.CreateUUID()), *t, s.UserID, approverID, due, reason, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to request postponement")
+	}
+
+	if err := u.postponementRepository.Create(p); err != nil {
+		return nil, errors.Wrap(err, "failed to store postponement")
+	}
+
+	return p, nil
+}
+
+func (u *postponementUsecase) FindByTaskID(s Session, taskID model.TaskID) ([]*model.Postponement, error) {
+	if _, err := findVisibleTask(u.taskRepository, u.workspaceRepository, s, taskID); err != nil {
+		return nil, err
+	}
+
+	postponements, err := u.postponementRepository.FindByTaskID(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find postponements, taskID: %s", taskID)
+	}
+
+	return postponements, nil
+}
+
+// FindPending finds the postponements waiting for the decision of the session user.
+func (u *postponementUsecase) FindPending(s Session) ([]*model.Postponement, error) {
+	postponements, err := u.postponementRepository.FindPendingByApproverID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find pending postponements, userID: %s", s.UserID)
+	}
+
+	return postponements, nil
+}
+
+// Approve approves the postponement and moves the deadline of the task to the requested one.
+// The approver or an admin or owner of the workspace of the task decides, unless they requested the postponement.
+func (u *postponementUsecase) Approve(s Session, id model.PostponementID, comment string) (*model.Postponement, error) {
+	return u.decide(s, id, true, comment)
+}
+
+// Reject rejects the postponement. The deadline of the task is left as is.
+func (u *postponementUsecase) Reject(s Session, id model.PostponementID, comment string) (*model.Postponement, error) {
+	return u.decide(s, id, false, comment)
+}
+
+func (u *postponementUsecase) decide(s Session, id model.PostponementID, approved bool, comment string) (*model.Postponement, error) {
+	fetched, err := u.postponementRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find postponement, postponementID: %s", id)
+	} else if fetched == nil {
+		return nil, errors.Wrapf(ErrNotFound, "postponement is not found, postponementID: %s", id)
+	}
+
+	if fetched.RequesterID == s.UserID {
+		return nil, errors.Wrap(ErrForbidden, "requester cannot decide own postponement")
+	}
+
+	fetchedTask, err := u.taskRepository.FindByID(fetched.TaskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find task, taskID: %s", fetched.TaskID)
+	} else if fetchedTask == nil {
+		return nil, errors.Wrapf(ErrNotFound, "task is not found, taskID: %s", fetched.TaskID)
+	}
+
+	// INFO: the admins and the owners of the workspace can decide in place of the approver
+	if fetched.ApproverID != s.UserID {
+		if _, err := findMember(u.workspaceRepository, s, fetchedTask.WorkspaceID, model.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	if !fetched.IsPending() {
+		return nil, errors.Wrapf(ErrConflict, "postponement is already %s, postponementID: %s", fetched.Status, id)
+	}
+
+	p, err := model.PostponementDecide(*fetched, approved, comment, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to decide postponement")
+	}
+
+	var (
+		t *model.Task
+		h *model.TaskHistory
+	)
+
+	if approved {
+		if t, err = model.TaskPostpone(*fetchedTask, *p); err != nil {
+			return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to postpone task")
+		}
+
+		h = newHistory(s.UserID, fetchedTask, *t)
+	}
+
+	// INFO: the decision is only stored while the postponement is pending, so that concurrent decisions cannot both apply
+	if err := u.postponementRepository.Decide(p, t, h); errors.Is(err, repository.ErrVersionConflict) {
+		return nil, errors.Wrap(withKind(ErrConflict, err), "failed to decide postponement")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to decide postponement")
+	}
+
+	if t != nil {
+		publishChange(u.eventPublisher, fetchedTask, *t)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostponementRequestUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+	requested := deadline.AddDate(0, 0, 7)
+
+	adminID := model.UserID("2f1c9a7e-8d3b-4e5a-b6c7-d8e9f0a1b2c3")
+	limitTask := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Deadline: deadline, PostponedCount: model.POSTPONED_COUNT_LIMIT}
+	assignedTask := &model.Task{ID: id, UserID: adminID, AssigneeID: session.UserID, Name: "Venue Reservation", Deadline: deadline, PostponedCount: model.POSTPONED_COUNT_LIMIT}
+	members := []*model.Member{{UserID: session.UserID, Role: model.RoleOwner}, {UserID: adminID, Role: model.RoleAdmin}}
+	pending := &model.Postponement{ID: "pending", TaskID: id, Status: model.Pending}
+	rejected := &model.Postponement{ID: "rejected", TaskID: id, Status: model.Rejected}
+
+	tests := []struct {
+		name                         string
+		fetchedTask                  *model.Task
+		fetchedPostponements         []*model.Postponement
+		fetchedMembers               []*model.Member
+		reason                       string
+		expectedFindCallTimes        int
+		expectedFindMembersCallTimes int
+		expectedCallTimes            int
+		expectedApproverID           model.UserID
+		expectedErr                  error
+	}{
+		{
+			"normal case: creator requests to an admin",
+			limitTask,
+			[]*model.Postponement{rejected},
+			members,
+			"The venue is closed for maintenance",
+			1,
+			1,
+			1,
+			adminID,
+			nil,
+		},
+		{
+			"normal case: assignee requests to creator",
+			assignedTask,
+			nil,
+			nil,
+			"The venue is closed for maintenance",
+			1,
+			0,
+			1,
+			adminID,
+			nil,
+		},
+		{
+			"error case: nobody but creator can approve",
+			limitTask,
+			nil,
+			[]*model.Member{{UserID: session.UserID, Role: model.RoleOwner}},
+			"The venue is closed for maintenance",
+			1,
+			1,
+			0,
+			"",
+			ErrForbidden,
+		},
+		{
+			"error case: postponement is already pending",
+			limitTask,
+			[]*model.Postponement{pending},
+			nil,
+			"The venue is closed for maintenance",
+			1,
+			0,
+			0,
+			"",
+			ErrConflict,
+		},
+		{
+			"error case: reason is empty",
+			limitTask,
+			nil,
+			members,
+			" ",
+			1,
+			1,
+			0,
+			"",
+			ErrInvalidArgument,
+		},
+		{
+			"error case: postponement does not need approval",
+			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Deadline: deadline, PostponedCount: 1},
+			nil,
+			members,
+			"The venue is closed for maintenance",
+			1,
+			1,
+			0,
+			"",
+			ErrInvalidArgument,
+		},
+		{
+			"error case: task of other user",
+			&model.Task{ID: id, UserID: model.UserID("other"), Name: "Venue Reservation", Deadline: deadline, PostponedCount: model.POSTPONED_COUNT_LIMIT},
+			nil,
+			nil,
+			"The venue is closed for maintenance",
+			0,
+			0,
+			0,
+			"",
+			ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			postponementRepository := mock.NewMockPostponementRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			usecase := NewPostponementUsecase(taskRepository, postponementRepository, workspaceRepository, eventPublisher)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(tt.fetchedTask, nil).Times(1),
+				postponementRepository.EXPECT().FindByTaskID(id).Return(tt.fetchedPostponements, nil).Times(tt.expectedFindCallTimes),
+				workspaceRepository.EXPECT().FindMembers(gomock.Any()).Return(tt.fetchedMembers, nil).Times(tt.expectedFindMembersCallTimes),
+				postponementRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(p *model.Postponement) error {
+					assert.Exactly(t, model.Pending, p.Status)
+					assert.Exactly(t, requested, p.Deadline)
+					assert.Exactly(t, session.UserID, p.RequesterID)
+					assert.Exactly(t, tt.expectedApproverID, p.ApproverID)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Request(session, id, model.DueOn(requested), tt.reason)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.reason, output.Reason)
+			}
+		})
+	}
+}
+
+func TestPostponementDecideUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.PostponementID("0b7c6a38-3c3e-4f2a-9d59-3a6f1f0b9f4e")
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+	requested := deadline.AddDate(0, 0, 7)
+
+	requesterID := model.UserID("2f1c9a7e-8d3b-4e5a-b6c7-d8e9f0a1b2c3")
+	fetchedTask := &model.Task{ID: taskID, UserID: session.UserID, Name: "Venue Reservation", Deadline: deadline, PostponedCount: model.POSTPONED_COUNT_LIMIT}
+	pending := &model.Postponement{ID: id, TaskID: taskID, RequesterID: requesterID, ApproverID: session.UserID, Deadline: requested, Reason: "Closed", Status: model.Pending}
+	othersPending := &model.Postponement{ID: id, TaskID: taskID, RequesterID: requesterID, ApproverID: model.UserID("other"), Deadline: requested, Reason: "Closed", Status: model.Pending}
+
+	tests := []struct {
+		name                  string
+		fetchedPostponement   *model.Postponement
+		role                  model.Role
+		approved              bool
+		expectedTaskCallTimes int
+		expectedCallTimes     int
+		decideErr             error
+		expectedStatus        model.PostponementStatus
+		expectedErr           error
+	}{
+		{
+			"normal case: approve",
+			pending,
+			model.RoleMember,
+			true,
+			1,
+			1,
+			nil,
+			model.Approved,
+			nil,
+		},
+		{
+			"normal case: reject",
+			pending,
+			model.RoleMember,
+			false,
+			1,
+			1,
+			nil,
+			model.Rejected,
+			nil,
+		},
+		{
+			"normal case: admin approves in place of approver",
+			othersPending,
+			model.RoleAdmin,
+			true,
+			1,
+			1,
+			nil,
+			model.Approved,
+			nil,
+		},
+		{
+			"error case: session user is not approver",
+			othersPending,
+			model.RoleMember,
+			true,
+			1,
+			0,
+			nil,
+			model.Pending,
+			ErrForbidden,
+		},
+		{
+			"error case: requester approves own postponement",
+			&model.Postponement{ID: id, TaskID: taskID, RequesterID: session.UserID, ApproverID: session.UserID, Deadline: requested, Reason: "Closed", Status: model.Pending},
+			model.RoleOwner,
+			true,
+			0,
+			0,
+			nil,
+			model.Pending,
+			ErrForbidden,
+		},
+		{
+			"error case: postponement is already decided",
+			&model.Postponement{ID: id, TaskID: taskID, RequesterID: requesterID, ApproverID: session.UserID, Deadline: requested, Reason: "Closed", Status: model.Rejected},
+			model.RoleMember,
+			true,
+			1,
+			0,
+			nil,
+			model.Rejected,
+			ErrConflict,
+		},
+		{
+			"error case: postponement is decided concurrently",
+			pending,
+			model.RoleMember,
+			true,
+			1,
+			1,
+			repository.ErrVersionConflict,
+			model.Approved,
+			ErrConflict,
+		},
+		{
+			"error case: postponement is not found",
+			nil,
+			model.RoleMember,
+			true,
+			0,
+			0,
+			nil,
+			model.Pending,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			postponementRepository := mock.NewMockPostponementRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			usecase := NewPostponementUsecase(taskRepository, postponementRepository, workspaceRepository, eventPublisher)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+
+			gomock.InOrder(
+				postponementRepository.EXPECT().FindByID(id).Return(tt.fetchedPostponement, nil).Times(1),
+				taskRepository.EXPECT().FindByID(taskID).Return(fetchedTask, nil).Times(tt.expectedTaskCallTimes),
+				postponementRepository.EXPECT().Decide(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(p *model.Postponement, task *model.Task, h *model.TaskHistory) error {
+					assert.Exactly(t, tt.expectedStatus, p.Status)
+
+					if tt.approved {
+						assert.Exactly(t, requested, task.Deadline)
+						assert.Exactly(t, model.POSTPONED_COUNT_LIMIT, task.PostponedCount)
+						if assert.NotNil(t, h) {
+							assert.Exactly(t, taskID, h.TaskID)
+							assert.Exactly(t, session.UserID, h.UserID)
+						}
+					} else {
+						assert.Nil(t, task)
+						assert.Nil(t, h)
+					}
+
+					return tt.decideErr
+				}).Times(tt.expectedCallTimes),
+			)
+
+			decide := usecase.Reject
+			if tt.approved {
+				decide = usecase.Approve
+			}
+
+			output, err := decide(session, id, "OK")
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedStatus, output.Status)
+				assert.Exactly(t, "OK", output.Comment)
+				assert.Exactly(t, model.Pending, pending.Status, "fetched postponement must not be modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type ReminderUsecase interface {
+	SendReminders(now time.Time) (int, error)
+}
+
+type reminderUsecase struct {
+	taskRepository     repository.TaskRepository
+	userRepository     repository.UserRepository
+	notificationSender NotificationSender
+}
+
+func NewReminderUsecase(tr repository.TaskRepository, ur repository.UserRepository, ns NotificationSender) ReminderUsecase {
+	return &reminderUsecase{
+		taskRepository:     tr,
+		userRepository:     ur,
+		notificationSender: ns,
+	}
+}
+
+// SendReminders notifies the assignees of the tasks whose reminders are due, and returns the number of sent reminders.
+// A failure to send one reminder does not prevent the others from being sent.
+func (u *reminderUsecase) SendReminders(now time.Time) (int, error) {
+	var tasks []*model.Task
+
+	for _, status := range []model.Status{model.Working, model.Behind} {
+		t, err := u.taskRepository.FindByStatus(status)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to find tasks, status: %s", status)
+		}
+
+		tasks = append(tasks, t...)
+	}
+
+	var (
+		count   int
+		failed  int
+		lastErr error
+	)
+
+	for _, fetchedTask := range tasks {
+		if !model.ReminderDue(*fetchedTask, now) {
+			continue
+		}
+
+		if err := u.remind(*fetchedTask, now); errors.Is(err, errSendFailed) {
+			failed++
+			lastErr = err
+
+			continue
+		} else if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if failed > 0 {
+		return count, errors.Wrapf(lastErr, "failed to send %d reminders", failed)
+	}
+
+	return count, nil
+}
+
+var errSendFailed = errors.New("failed to send reminder")
+
+func (u *reminderUsecase) remind(fetchedTask model.Task, now time.Time) error {
+	user, err := u.userRepository.FindByID(fetchedTask.AssigneeID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find user, userID: %s", fetchedTask.AssigneeID)
+	} else if user == nil {
+		return errors.Wrapf(ErrNotFound, "user is not found, userID: %s", fetchedTask.AssigneeID)
+	}
+
+	t, err := model.TaskNotified(fetchedTask, now)
+	if err != nil {
+		return errors.Wrapf(err, "failed to notify task, taskID: %s", fetchedTask.ID)
+	}
+
+	subject, body := reminderMessage(fetchedTask, now)
+
+	if err := u.notificationSender.Send(user.Email, subject, body); err != nil {
+		return withKind(errSendFailed, errors.Wrapf(err, "failed to send reminder, taskID: %s", t.ID))
+	}
+
+	// INFO: the reminder has been sent even if the task was updated in the meantime.
+	// The count is not recorded then, and the reminder may be sent again as if the update had come first.
+	if err := u.taskRepository.Update(t, nil); err != nil && !errors.Is(err, repository.ErrVersionConflict) {
+		return errors.Wrapf(err, "failed to update task, taskID: %s", t.ID)
+	}
+
+	return nil
+}
+
+func reminderMessage(t model.Task, now time.Time) (string, string) {
+	deadline := t.Due().String()
+
+	var subject string
+
+	switch model.ReminderKindAt(t, now) {
+	case model.DueSoon:
+		subject = fmt.Sprintf("[todo] %s is due on %s", t.Name, deadline)
+	case model.DueToday:
+		subject = fmt.Sprintf("[todo] %s is due today", t.Name)
+	case model.Overdue:
+		subject = fmt.Sprintf("[todo] %s is overdue since %s", t.Name, deadline)
+	}
+
+	body := fmt.Sprintf("%s\n\n%s\n\nDeadline: %s\nReminder: %d/%d\n", t.Name, t.Detail, deadline, t.NotificationCount+1, model.NOTIFICATION_COUNT_LIMIT)
+
+	return subject, body
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRemindersUseCase(t *testing.T) {
+	user := &model.User{ID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33"), Email: "abc@example.com"}
+	id1 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	id2 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac")
+	now := time.Date(2022, 1, 26, 10, 10, 10, 0, time.Local)
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+
+	dueTask := &model.Task{ID: id1, UserID: user.ID, AssigneeID: user.ID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 1}
+	notifiedTask := &model.Task{ID: id1, UserID: user.ID, AssigneeID: user.ID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 2, LastNotifiedAt: &now}
+	notDueTask := &model.Task{ID: id2, UserID: user.ID, AssigneeID: user.ID, Name: "Catering", Status: model.Working, Deadline: deadline.AddDate(0, 0, 7), TimeZone: time.Local.String()}
+
+	tests := []struct {
+		name              string
+		expectedSendErr   error
+		expectedOutput    int
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			nil,
+			1,
+			nil,
+			1,
+		},
+		{
+			"send error case",
+			errors.New("send error"),
+			0,
+			errors.New("failed to send 1 reminders"),
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			notificationSender := mock.NewMockNotificationSender(ctrl)
+			usecase := NewReminderUsecase(taskRepository, userRepository, notificationSender)
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByStatus(model.Working).Return([]*model.Task{dueTask, notDueTask}, nil).Times(1),
+				taskRepository.EXPECT().FindByStatus(model.Behind).Return(nil, nil).Times(1),
+				userRepository.EXPECT().FindByID(user.ID).Return(user, nil).Times(1),
+				notificationSender.EXPECT().Send(user.Email, "[todo] Venue Reservation is due today", gomock.Any()).Return(tt.expectedSendErr).Times(1),
+				taskRepository.EXPECT().Update(notifiedTask, nil).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.SendReminders(now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+
+			assert.Exactly(t, tt.expectedOutput, output)
+		})
+	}
+}
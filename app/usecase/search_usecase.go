@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type SearchUsecase interface {
+	Search(session Session, text string, limit int) ([]*model.TaskHit, error)
+}
+
+type searchUsecase struct {
+	taskSearcher repository.TaskSearcher
+}
+
+func NewSearchUsecase(ts repository.TaskSearcher) SearchUsecase {
+	return &searchUsecase{
+		taskSearcher: ts,
+	}
+}
+
+// Search returns the tasks visible to the user which match all the words of the text,
+// with the words highlighted in their names and details.
+func (u *searchUsecase) Search(s Session, text string, limit int) ([]*model.TaskHit, error) {
+	search := model.TaskSearch{ViewerID: s.UserID, Text: text, Limit: limit}
+
+	if err := model.TaskSearchSpecSatisfied(search); err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to satisfy task search spec")
+	}
+
+	hits, err := u.taskSearcher.Search(search)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search tasks, userID: %s", s.UserID)
+	}
+
+	terms := search.Terms()
+
+	highlighted := make([]*model.TaskHit, 0, len(hits))
+	for _, h := range hits {
+		highlighted = append(highlighted, model.TaskHighlight(*h, terms))
+	}
+
+	return highlighted, nil
+}
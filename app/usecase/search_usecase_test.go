@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/infrastructure/search"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	other := model.UserID("8c1a6f2e-3b4d-4e5f-9a0b-1c2d3e4f5a6b")
+	deadline := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	trashedAt := deadline
+
+	searcher := search.NewMemorySearcher()
+	searcher.Add(&model.Task{ID: "report", UserID: session.UserID, Name: "Weekly report", Detail: "Send the report", Deadline: deadline})
+	searcher.Add(&model.Task{ID: "memo", UserID: session.UserID, Name: "Memo", Detail: "Weekly report draft", Deadline: deadline})
+	searcher.Add(&model.Task{ID: "shared", UserID: other, Name: "Weekly report of team", Deadline: deadline, Visibility: model.Shared}, session.UserID)
+	searcher.Add(&model.Task{ID: "private", UserID: other, Name: "Weekly report", Deadline: deadline, Visibility: model.Private}, session.UserID)
+	searcher.Add(&model.Task{ID: "trashed", UserID: session.UserID, Name: "Weekly report", Deadline: deadline, TrashedAt: &trashedAt})
+
+	usecase := NewSearchUsecase(searcher)
+
+	tests := []struct {
+		name           string
+		text           string
+		expectedOutput []model.TaskID
+		expectedErr    error
+	}{
+		{"normal case: ranked by matches", "weekly REPORT", []model.TaskID{"report", "shared", "memo"}, nil},
+		{"normal case: all terms must match", "weekly send", []model.TaskID{"report"}, nil},
+		{"normal case: no match", "invoice", []model.TaskID{}, nil},
+		{"error case: blank text", " ", nil, ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := usecase.Search(session, tt.text, 0)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+
+				ids := []model.TaskID{}
+				for _, h := range output {
+					ids = append(ids, h.Task.ID)
+				}
+
+				assert.Exactly(t, tt.expectedOutput, ids)
+			}
+		})
+	}
+}
+
+func TestSearchHighlightUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+
+	searcher := search.NewMemorySearcher()
+	searcher.Add(&model.Task{ID: "report", UserID: session.UserID, Name: "Weekly report", Detail: "Send it"})
+
+	output, err := NewSearchUsecase(searcher).Search(session, "report", 0)
+	assert.Nil(t, err)
+	assert.Exactly(t, []model.Fragment{{Text: "Weekly ", Match: false}, {Text: "report", Match: true}}, output[0].Name)
+	assert.Exactly(t, []model.Fragment{{Text: "Send it", Match: false}}, output[0].Detail)
+}
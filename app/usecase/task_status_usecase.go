@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type TaskStatusUsecase interface {
+	TransitionOverdue(now time.Time) (int, error)
+}
+
+type taskStatusUsecase struct {
+	taskRepository repository.TaskRepository
+	eventPublisher EventPublisher
+}
+
+func NewTaskStatusUsecase(tr repository.TaskRepository, ep EventPublisher) TaskStatusUsecase {
+	return &taskStatusUsecase{
+		taskRepository: tr,
+		eventPublisher: ep,
+	}
+}
+
+// TransitionOverdue marks working tasks which passed their deadline as behind, and returns the number of transitioned tasks.
+func (u *taskStatusUsecase) TransitionOverdue(now time.Time) (int, error) {
+	tasks, err := u.taskRepository.FindByStatus(model.Working)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find working tasks")
+	}
+
+	count := 0
+
+	for _, fetchedTask := range tasks {
+		t, changed := model.TaskRefresh(*fetchedTask, now)
+		if !changed {
+			continue
+		}
+
+		// INFO: a task updated in the meantime is left to the next run, which sees the update
+		if err := u.taskRepository.Update(t, newHistory(model.SystemUserID, fetchedTask, *t)); errors.Is(err, repository.ErrVersionConflict) {
+			continue
+		} else if err != nil {
+			return count, errors.Wrapf(err, "failed to update task, taskID: %s", t.ID)
+		}
+
+		count++
+
+		if t.Status == model.Behind {
+			u.eventPublisher.Publish(model.NewTaskEvent(model.TaskBehind, *t, now))
+		}
+	}
+
+	return count, nil
+}
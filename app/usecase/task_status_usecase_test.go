@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTransitionOverdueUseCase(t *testing.T) {
+	userID := model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	id1 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	id2 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac")
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.Local)
+
+	overdueTask := &model.Task{ID: id1, UserID: userID, Name: "Venue Reservation1", Status: model.Working, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()}
+	behindTask := &model.Task{ID: id1, UserID: userID, Name: "Venue Reservation1", Status: model.Behind, Deadline: time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()}
+	inTimeTask := &model.Task{ID: id2, UserID: userID, Name: "Venue Reservation2", Status: model.Working, Deadline: time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local), TimeZone: time.Local.String()}
+
+	tests := []struct {
+		name              string
+		expectedUpdateErr error
+		expectedOutput    int
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			nil,
+			1,
+			nil,
+			1,
+		},
+		{
+			"update error case",
+			errors.New("update error"),
+			0,
+			errors.New("failed to update task"),
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			usecase := NewTaskStatusUsecase(taskRepository, eventPublisher)
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByStatus(model.Working).Return([]*model.Task{overdueTask, inTimeTask}, nil).Times(1),
+				taskRepository.EXPECT().Update(behindTask, gomock.Any()).DoAndReturn(func(_ *model.Task, h *model.TaskHistory) error {
+					assert.True(t, h.IsBySystem(), "history by system is expected but received: %+v", h)
+					assert.Exactly(t, []model.FieldChange{{Field: "status", Before: "working", After: "behind"}}, h.Changes)
+
+					return tt.expectedUpdateErr
+				}).Times(1),
+				eventPublisher.EXPECT().Publish(model.TaskEvent{Type: model.TaskBehind, TaskID: id1, UserID: userID, OccurredAt: now}).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.TransitionOverdue(now)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedOutput, output)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"strings"
 	"time"
 	"todo-app/domain/model"
 	"todo-app/domain/repository"
@@ -9,76 +10,1085 @@ import (
 )
 
 type TaskUsecase interface {
-	Create(session Session, name, detail string, deadline time.Time) error
-	FindByID(id model.TaskID) (*model.Task, error)
-	// FindByUserID(session Session) (*model.Task, error)
-	FindAll() ([]*model.Task, error)
-	Update(session Session, id model.TaskID, name, detail string, status model.Status, deadline time.Time) error
+	Create(session Session, workspaceID model.WorkspaceID, name, detail string, due model.Due, recurrence model.Recurrence, priority model.Priority) (*model.Task, error)
+	CreateTodo(session Session, uid, calendarName, name, detail string, status model.Status, completionDate *time.Time, due model.Due, priority model.Priority) (*model.Task, error)
+	CreateSubtask(session Session, parentID model.TaskID, name, detail string, due model.Due, recurrence model.Recurrence, priority model.Priority) (*model.Task, error)
+	FindByID(session Session, id model.TaskID) (*model.Task, error)
+	Find(session Session, query model.TaskQuery) (*model.TaskPage, error)
+	FindByShareToken(token string) (*model.Task, error)
+	FindByCalendarToken(token string) (*model.User, []*model.Task, error)
+	FindByCalendarName(session Session, name string) (*model.Task, error)
+	FindAssigned(session Session) ([]*model.Task, error)
+	FindArchived(session Session) ([]*model.Task, error)
+	FindTrashed(session Session) ([]*model.Task, error)
+	FindSharedUsers(session Session, id model.TaskID) ([]*model.User, error)
+	FindTree(session Session, id model.TaskID) (*model.TaskTree, error)
+	FindLabels(session Session, tasks []*model.Task) (map[model.TaskID][]*model.Label, error)
+	Update(session Session, id model.TaskID, name, detail string, status model.Status, due model.Due, recurrence model.Recurrence, priority model.Priority, cascade bool, version int) error
+	Complete(session Session, id model.TaskID, cascade bool, version *int) (*model.Task, error)
+	Reopen(session Session, id model.TaskID) error
+	Share(session Session, id model.TaskID, visibility model.Visibility, emails []string) (*model.Task, error)
+	Assign(session Session, id model.TaskID, email string) (*model.Task, error)
+	SetLabels(session Session, id model.TaskID, labelIDs []model.LabelID) error
+	Archive(session Session, id model.TaskID) error
+	Unarchive(session Session, id model.TaskID) error
+	Trash(session Session, id model.TaskID) error
+	Restore(session Session, id model.TaskID) error
+	Delete(session Session, id model.TaskID) error
+	FindHistory(session Session, id model.TaskID) (*model.TaskTimeline, error)
+	FindHistoryByUser(session Session, limit int) ([]*model.TaskHistory, error)
 }
 
 type taskUsecase struct {
-	taskRepository repository.TaskRepository
+	taskRepository       repository.TaskRepository
+	userRepository       repository.UserRepository
+	labelRepository      repository.LabelRepository
+	historyRepository    repository.HistoryRepository
+	workspaceRepository  repository.WorkspaceRepository
+	dependencyRepository repository.DependencyRepository
+	eventPublisher       EventPublisher
+	fileStorage          FileStorage
 }
 
-func NewTaskUsecase(tr repository.TaskRepository) TaskUsecase {
-	return &taskUsecase{taskRepository: tr}
+func NewTaskUsecase(tr repository.TaskRepository, ur repository.UserRepository, lr repository.LabelRepository, hr repository.HistoryRepository, wr repository.WorkspaceRepository, dr repository.DependencyRepository, ep EventPublisher, fs FileStorage) TaskUsecase {
+	return &taskUsecase{
+		taskRepository:       tr,
+		userRepository:       ur,
+		labelRepository:      lr,
+		historyRepository:    hr,
+		workspaceRepository:  wr,
+		dependencyRepository: dr,
+		eventPublisher:       ep,
+		fileStorage:          fs,
+	}
 }
 
-func (u *taskUsecase) Create(s Session, name, detail string, deadline time.Time) error {
-	id := model.CreateUUID()
+// Create creates a task in the workspace, or in the personal workspace of the session user when it is empty.
+// Viewers of the workspace cannot create tasks.
+func (u *taskUsecase) Create(s Session, workspaceID model.WorkspaceID, name, detail string, due model.Due, recurrence model.Recurrence, priority model.Priority) (*model.Task, error) {
+	t, err := u.newTask(s, model.TaskID(model.CreateUUID()), workspaceID, name, detail, due, recurrence, priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.store(s, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// CreateTodo creates a task in the personal workspace of the session user from a todo of a client which names it by itself,
+// e.g. a calendar app. The task gets an ID of its own and keeps the UID and the object name of the todo, which must not name
+// another object in the calendar of the user yet. A completed todo is completed on its completion date, or today when it has none.
+func (u *taskUsecase) CreateTodo(s Session, uid, calendarName, name, detail string, status model.Status, completionDate *time.Time, due model.Due, priority model.Priority) (*model.Task, error) {
+	used, err := u.taskRepository.FindByCalendarName(s.UserID, calendarName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find task, calendar name: %s", calendarName)
+	} else if used == nil {
+		// INFO: the objects of the other tasks are named after their IDs
+		if used, err = u.taskRepository.FindByID(model.TaskID(calendarName)); err != nil {
+			return nil, errors.Wrapf(err, "failed to find task, taskID: %s", calendarName)
+		}
+	}
+
+	if used != nil {
+		return nil, errors.Wrapf(ErrConflict, "calendar name is already used, calendar name: %s", calendarName)
+	}
+
+	t, err := u.newTask(s, model.TaskID(model.CreateUUID()), "", name, detail, due, model.NoRecurrence, priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if t, err = model.TaskLinkCalendar(*t, uid, calendarName); err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create task")
+	}
+
+	if status == model.Completed {
+		t = model.TaskCompleteOn(*t, completionDate, getNow())
+	}
+
+	if err := u.store(s, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// newTask makes a new task in the workspace, or in the personal workspace of the session user when it is empty.
+func (u *taskUsecase) newTask(s Session, id model.TaskID, workspaceID model.WorkspaceID, name, detail string, due model.Due, recurrence model.Recurrence, priority model.Priority) (*model.Task, error) {
+	if workspaceID == "" {
+		workspaceID = model.PersonalWorkspaceID(s.UserID)
+	}
+
+	if _, err := findMember(u.workspaceRepository, s, workspaceID, model.RoleMember); err != nil {
+		return nil, err
+	}
+
+	t, err := model.NewTask(id, s.UserID, name, detail, due)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create task")
+	}
+
+	t, err = model.TaskPlace(*t, workspaceID)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create task")
+	}
 
-	t, err := model.NewTask(model.TaskID(id), s.UserID, name, detail, deadline)
+	t, err = model.TaskRepeat(*t, recurrence)
 	if err != nil {
-		return errors.Wrap(err, "failed to create task")
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create task")
 	}
 
-	if err := u.taskRepository.Create(t); err != nil {
+	t, err = model.TaskPrioritize(*t, priority)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create task")
+	}
+
+	return t, nil
+}
+
+// store stores the new task and records its creation.
+func (u *taskUsecase) store(s Session, t *model.Task) error {
+	if err := u.taskRepository.Create(t, newHistory(s.UserID, nil, *t)); err != nil {
 		return errors.Wrap(err, "failed to store task")
 	}
 
+	publishChange(u.eventPublisher, nil, *t)
+
 	return nil
 }
 
-func (u *taskUsecase) FindByID(id model.TaskID) (*model.Task, error) {
-	t, err := u.taskRepository.FindByID(id)
+func (u *taskUsecase) CreateSubtask(s Session, parentID model.TaskID, name, detail string, due model.Due, recurrence model.Recurrence, priority model.Priority) (*model.Task, error) {
+	parent, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := model.CreateUUID()
+
+	t, err := model.NewSubtask(model.TaskID(id), *parent, name, detail, due)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create subtask")
+	}
+
+	t, err = model.TaskRepeat(*t, recurrence)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create subtask")
+	}
+
+	t, err = model.TaskPrioritize(*t, priority)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to find task, taskID: %s", id)
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create subtask")
+	}
+
+	if err := u.taskRepository.Create(t, newHistory(s.UserID, nil, *t)); err != nil {
+		return nil, errors.Wrap(err, "failed to store subtask")
+	}
+
+	publishChange(u.eventPublisher, nil, *t)
+
+	return t, nil
+}
+
+func (u *taskUsecase) FindByID(s Session, id model.TaskID) (*model.Task, error) {
+	return findVisibleTask(u.taskRepository, u.workspaceRepository, s, id)
+}
+
+// FindByCalendarName finds the task of the calendar object with the name in the calendar of the session user.
+// The objects of the tasks created by calendar clients have the names which the clients gave them,
+// and the objects of the other tasks are named after the task IDs.
+func (u *taskUsecase) FindByCalendarName(s Session, name string) (*model.Task, error) {
+	t, err := u.taskRepository.FindByCalendarName(s.UserID, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find task, calendar name: %s", name)
+	} else if t != nil {
+		return t, nil
+	}
+
+	t, err = findVisibleTask(u.taskRepository, u.workspaceRepository, s, model.TaskID(name))
+	if err != nil {
+		return nil, err
+	} else if t.CalendarName != "" {
+		return nil, errors.Wrapf(ErrNotFound, "task is named otherwise in calendar, taskID: %s", t.ID)
 	}
 
 	return t, nil
 }
 
-func (u *taskUsecase) FindAll() ([]*model.Task, error) {
-	tasks, err := u.taskRepository.FindAll()
+// Find finds a page of the tasks which the session user can view.
+// The labels in the query have to be owned by the session user.
+func (u *taskUsecase) Find(s Session, q model.TaskQuery) (*model.TaskPage, error) {
+	q.ViewerID = s.UserID
+
+	if err := model.TaskQuerySpecSatisfied(q); err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to satisfy task query spec")
+	}
+
+	for _, id := range q.LabelIDs {
+		if _, err := findOwnedLabel(u.labelRepository, s, id); err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := u.taskRepository.Find(q)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to find tasks")
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to find tasks, userID: %s", s.UserID)
+	}
+
+	return page, nil
+}
+
+func (u *taskUsecase) FindByShareToken(token string) (*model.Task, error) {
+	if token == "" {
+		return nil, errors.Wrap(ErrNotFound, "task is not found")
+	}
+
+	t, err := u.taskRepository.FindByShareToken(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find task by share token")
+	} else if t == nil || t.Visibility != model.Public {
+		return nil, errors.Wrap(ErrNotFound, "task is not found")
+	}
+
+	return t, nil
+}
+
+// FindByCalendarToken finds the owner of the iCalendar feed and all the tasks assigned to the owner,
+// including completed ones so that calendar apps can tick them off.
+func (u *taskUsecase) FindByCalendarToken(token string) (*model.User, []*model.Task, error) {
+	if token == "" {
+		return nil, nil, errors.Wrap(ErrNotFound, "calendar is not found")
+	}
+
+	user, err := u.userRepository.FindByCalendarToken(token)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find user by calendar token")
+	} else if user == nil {
+		return nil, nil, errors.Wrap(ErrNotFound, "calendar is not found")
+	}
+
+	tasks, err := u.findAssigned(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tasks, nil
+}
+
+// FindAssigned finds all the tasks assigned to the session user, which are the tasks synced with calendar apps.
+func (u *taskUsecase) FindAssigned(s Session) ([]*model.Task, error) {
+	return u.findAssigned(s.UserID)
+}
+
+func (u *taskUsecase) findAssigned(userID model.UserID) ([]*model.Task, error) {
+	q := model.TaskQuery{ViewerID: userID, AssigneeID: userID, Sort: model.DefaultTaskSort, Limit: model.MaxPageSize}
+
+	var tasks []*model.Task
+
+	for {
+		page, err := u.taskRepository.Find(q)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find tasks, userID: %s", userID)
+		}
+
+		tasks = append(tasks, page.Tasks...)
+
+		if page.NextCursor == "" {
+			return tasks, nil
+		}
+
+		q.Cursor = page.NextCursor
+	}
+}
+
+func (u *taskUsecase) FindArchived(s Session) ([]*model.Task, error) {
+	tasks, err := u.taskRepository.FindArchivedByUserID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find archived tasks, userID: %s", s.UserID)
+	}
+
+	return tasks, nil
+}
+
+func (u *taskUsecase) FindTrashed(s Session) ([]*model.Task, error) {
+	tasks, err := u.taskRepository.FindTrashedByUserID(s.UserID)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to find all tasks")
+		return nil, errors.Wrapf(err, "failed to find trashed tasks, userID: %s", s.UserID)
 	}
 
 	return tasks, nil
 }
 
-func (u *taskUsecase) Update(s Session, id model.TaskID, name, detail string, status model.Status, deadline time.Time) error {
-	fetchedTask, err := u.taskRepository.FindByID(id)
+func (u *taskUsecase) FindSharedUsers(s Session, id model.TaskID) ([]*model.User, error) {
+	if _, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id); err != nil {
+		return nil, err
+	}
+
+	sharedUserIDs, err := u.taskRepository.FindSharedUserIDs(id)
 	if err != nil {
-		return errors.Wrapf(err, "failed to find task")
+		return nil, errors.Wrapf(err, "failed to find shared users, taskID: %s", id)
 	}
 
-	if s.UserID != fetchedTask.UserID {
-		return errors.New("session user is not task owner")
+	users := make([]*model.User, 0, len(sharedUserIDs))
+
+	for _, userID := range sharedUserIDs {
+		user, err := u.userRepository.FindByID(userID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find user, userID: %s", userID)
+		} else if user == nil {
+			continue
+		}
+
+		users = append(users, user)
 	}
 
-	t, err := model.TaskSet(*fetchedTask, name, detail, status, deadline)
+	return users, nil
+}
+
+// Update updates the task. When a recurring task is completed, its next occurrence is generated.
+// The creator and the workspace admins can change every field, while the assignee can only change the status,
+// the detail and the deadline.
+// Once the task has been postponed POSTPONED_COUNT_LIMIT times, moving the deadline later fails with ErrApprovalRequired
+// and a postponement has to be requested instead.
+// When the task is completed with cascade, its incomplete subtasks at any depth are completed together.
+// The version is the one of the task which the values are based on. If the task has been updated since then,
+// the update is rejected as a conflict instead of overwriting the other update.
+func (u *taskUsecase) Update(s Session, id model.TaskID, name, detail string, status model.Status, due model.Due, recurrence model.Recurrence, priority model.Priority, cascade bool, version int) error {
+	fetchedTask, access, err := findTask(u.taskRepository, u.workspaceRepository, s, id, model.WorkAccess)
 	if err != nil {
-		return errors.Wrap(err, "failed to set task")
+		return err
+	}
+
+	if err := model.TaskVersionSatisfied(*fetchedTask, version); err != nil {
+		return errors.Wrap(withKind(ErrConflict, err), "failed to update task")
+	}
+
+	// INFO: the deadline cannot be moved freely any more once it has been postponed too often
+	if fetchedTask.NeedsApprovalToPostpone(due) {
+		return errors.Wrapf(ErrApprovalRequired, "postponed counts reach limit, request a postponement instead. taskID: %s", id)
+	}
+
+	t, err := model.TaskSet(*fetchedTask, name, detail, status, due)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to set task")
+	}
+
+	t, err = model.TaskRepeat(*t, recurrence)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to set task")
+	}
+
+	t, err = model.TaskPrioritize(*t, priority)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to set task")
+	}
+
+	if err := model.TaskChangeSatisfied(*fetchedTask, *t, access); err != nil {
+		return errors.Wrap(withKind(ErrForbidden, err), "failed to update task")
 	}
 
 	if err := model.TaskSpecSatisfied(*t); err != nil {
-		return errors.Wrap(err, "failed to satisfy task spec")
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to satisfy task spec")
+	}
+
+	if fetchedTask.Status == model.Completed || t.Status != model.Completed {
+		if err := u.subtaskSpecSatisfied(*t); err != nil {
+			return err
+		}
+
+		return u.update(s, fetchedTask, t)
+	}
+
+	tree := &model.TaskTree{Task: fetchedTask}
+	changed := []*model.Task{t}
+
+	// INFO: the subtasks are completed in the same transaction as the update, so that both are based on the version
+	if cascade {
+		if tree, err = u.findTree(fetchedTask, u.taskRepository.FindByParentID, map[model.TaskID]bool{}); err != nil {
+			return err
+		}
+
+		// INFO: the root is completed already, so only the incomplete subtasks are changed
+		subtasks, err := model.TaskTreeComplete(model.TaskTree{Task: t, Children: tree.Children}, true, getNow())
+		if err != nil {
+			return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to complete subtasks")
+		}
+
+		changed = append(subtasks, t)
+	} else if err := u.subtaskSpecSatisfied(*t); err != nil {
+		return err
+	}
+
+	if err := u.blockersSatisfied(changed); err != nil {
+		return err
 	}
 
-	if err := u.taskRepository.Update(t); err != nil {
-		return errors.Wrap(err, "failed to update task")
+	return u.completeTree(s, tree, changed)
+}
+
+func (u *taskUsecase) FindTree(s Session, id model.TaskID) (*model.TaskTree, error) {
+	t, err := u.FindByID(s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.findTree(t, u.taskRepository.FindByParentID, map[model.TaskID]bool{})
+}
+
+// FindLabels finds the labels of the session user attached to each of the tasks.
+func (u *taskUsecase) FindLabels(s Session, tasks []*model.Task) (map[model.TaskID][]*model.Label, error) {
+	taskIDs := make([]model.TaskID, 0, len(tasks))
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+	}
+
+	labels, err := u.labelRepository.FindByTaskIDs(s.UserID, taskIDs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find labels of tasks, userID: %s", s.UserID)
+	}
+
+	return labels, nil
+}
+
+// Complete completes the task. With cascade, its incomplete subtasks at any depth are completed together.
+// Unless the version is nil, it is the one of the task which the completion is based on, and the completion is rejected
+// as a conflict if the task has been updated since then. It returns the task as completed.
+func (u *taskUsecase) Complete(s Session, id model.TaskID, cascade bool, version *int) (*model.Task, error) {
+	fetchedTask, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// INFO: the tasks are stored with the versions they have been fetched with, so a later update makes the completion fail
+	if version != nil {
+		if err := model.TaskVersionSatisfied(*fetchedTask, *version); err != nil {
+			return nil, errors.Wrap(withKind(ErrConflict, err), "failed to complete task")
+		}
+	}
+
+	tree, err := u.findTree(fetchedTask, u.taskRepository.FindByParentID, map[model.TaskID]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := model.TaskTreeComplete(*tree, cascade, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to complete task")
+	}
+
+	if err := u.blockersSatisfied(changed); err != nil {
+		return nil, err
+	}
+
+	if err := u.completeTree(s, tree, changed); err != nil {
+		return nil, err
+	}
+
+	completed := fetchedTask
+
+	for _, t := range changed {
+		if t.ID == id {
+			completed = t
+		}
+	}
+
+	return completed, nil
+}
+
+func (u *taskUsecase) Reopen(s Session, id model.TaskID) error {
+	fetchedTask, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	t := model.TaskReopen(*fetchedTask, getNow())
+
+	if err := u.subtaskSpecSatisfied(*t); err != nil {
+		return err
+	}
+
+	return u.update(s, fetchedTask, t)
+}
+
+func (u *taskUsecase) Share(s Session, id model.TaskID, visibility model.Visibility, emails []string) (*model.Task, error) {
+	fetchedTask, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := u.findUserIDsByEmails(s, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := model.TaskShare(*fetchedTask, visibility)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to share task")
+	}
+
+	if err := u.taskRepository.Share(t, userIDs, newHistory(s.UserID, fetchedTask, *t)); err != nil {
+		return nil, updateError(err)
+	}
+
+	publishChange(u.eventPublisher, fetchedTask, *t)
+
+	return t, nil
+}
+
+// Assign delegates the task to the registered user of the email, who has to be a member of the workspace of the task
+// but not a viewer. The users who can work on the task can reassign it.
+func (u *taskUsecase) Assign(s Session, id model.TaskID, email string) (*model.Task, error) {
+	fetchedTask, err := findWorkableTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	email = strings.TrimSpace(email)
+
+	user, err := u.userRepository.FindByEmail(model.Email(email))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find user, email: %s", email)
+	} else if user == nil {
+		return nil, errors.Wrapf(ErrInvalidArgument, "user is not registered, email: %s", email)
+	}
+
+	m, err := u.workspaceRepository.FindMember(fetchedTask.WorkspaceID, user.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member, workspaceID: %s", fetchedTask.WorkspaceID)
+	} else if m == nil || m.Role < model.RoleMember {
+		return nil, errors.Wrapf(ErrInvalidArgument, "user cannot work in workspace of task, email: %s", email)
+	}
+
+	t, err := model.TaskAssign(*fetchedTask, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to assign task")
+	}
+
+	if err := u.update(s, fetchedTask, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// SetLabels replaces the labels of the session user attached to the task.
+// Any user who can view the task can label it with their own labels.
+func (u *taskUsecase) SetLabels(s Session, id model.TaskID, labelIDs []model.LabelID) error {
+	if _, err := u.FindByID(s, id); err != nil {
+		return err
+	}
+
+	seen := make(map[model.LabelID]bool, len(labelIDs))
+	unique := make([]model.LabelID, 0, len(labelIDs))
+
+	for _, labelID := range labelIDs {
+		if seen[labelID] {
+			continue
+		}
+
+		if _, err := findOwnedLabel(u.labelRepository, s, labelID); err != nil {
+			return err
+		}
+
+		seen[labelID] = true
+		unique = append(unique, labelID)
+	}
+
+	if err := u.labelRepository.UpdateTaskLabels(id, s.UserID, unique); err != nil {
+		return errors.Wrap(err, "failed to update labels of task")
 	}
 
 	return nil
 }
+
+func (u *taskUsecase) Archive(s Session, id model.TaskID) error {
+	return u.change(s, id, "archive", func(t model.Task) (*model.Task, error) {
+		return model.TaskArchive(t, getNow())
+	})
+}
+
+func (u *taskUsecase) Unarchive(s Session, id model.TaskID) error {
+	return u.change(s, id, "unarchive", model.TaskUnarchive)
+}
+
+// Trash moves the task to the trash together with its subtasks at any depth.
+func (u *taskUsecase) Trash(s Session, id model.TaskID) error {
+	fetchedTask, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	tree, err := u.findTree(fetchedTask, u.taskRepository.FindByParentID, map[model.TaskID]bool{})
+	if err != nil {
+		return err
+	}
+
+	changed, err := model.TaskTreeTrash(*tree, getNow())
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to trash task")
+	}
+
+	return u.updateTree(s, tree, changed)
+}
+
+// Restore restores the task together with the subtasks which were trashed with it.
+// A subtask cannot be restored while its parent is in the trash.
+func (u *taskUsecase) Restore(s Session, id model.TaskID) error {
+	fetchedTask, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	if fetchedTask.ParentID != nil {
+		parent, err := u.taskRepository.FindByID(*fetchedTask.ParentID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find parent task, taskID: %s", *fetchedTask.ParentID)
+		}
+
+		if parent.IsTrashed() {
+			return errors.Wrapf(ErrInvalidArgument, "parent task must be restored first, taskID: %s", parent.ID)
+		}
+	}
+
+	tree, err := u.findTree(fetchedTask, u.taskRepository.FindTrashedByParentID, map[model.TaskID]bool{})
+	if err != nil {
+		return err
+	}
+
+	changed, err := model.TaskTreeRestore(*tree)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to restore task")
+	}
+
+	return u.updateTree(s, tree, changed)
+}
+
+// Delete permanently deletes the task with its subtasks. Only trashed tasks can be deleted, and their subtasks must be trashed too.
+func (u *taskUsecase) Delete(s Session, id model.TaskID) error {
+	t, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	if !t.IsTrashed() {
+		return errors.Wrapf(ErrInvalidArgument, "task must be trashed before deletion, taskID: %s", id)
+	}
+
+	children, err := u.taskRepository.FindByParentID(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find subtasks, taskID: %s", id)
+	}
+
+	if len(children) > 0 {
+		return errors.Wrapf(ErrInvalidArgument, "task with subtasks out of the trash cannot be deleted, taskID: %s", id)
+	}
+
+	attachments, err := u.taskRepository.Delete(id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete task")
+	}
+
+	deleteFiles(u.fileStorage, attachments)
+
+	return nil
+}
+
+// FindHistory returns the history of the task, the oldest first, with the users who made the changes and the assignee.
+// Any user who can view the task can view its history.
+func (u *taskUsecase) FindHistory(s Session, id model.TaskID) (*model.TaskTimeline, error) {
+	t, err := u.FindByID(s, id)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := u.historyRepository.FindByTaskID(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find history, taskID: %s", id)
+	}
+
+	userIDs := make([]model.UserID, 0, len(histories)+1)
+	for _, h := range histories {
+		if !h.IsBySystem() {
+			userIDs = append(userIDs, h.UserID)
+		}
+	}
+
+	if t.AssigneeID != "" {
+		userIDs = append(userIDs, t.AssigneeID)
+	}
+
+	users, err := findUsers(u.userRepository, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TaskTimeline{Histories: histories, Users: users}, nil
+}
+
+// FindHistoryByUser returns the latest changes made by the session user, the newest first.
+func (u *taskUsecase) FindHistoryByUser(s Session, limit int) ([]*model.TaskHistory, error) {
+	limit = model.TaskQuery{Limit: limit}.PageSize()
+
+	histories, err := u.historyRepository.FindByUserID(s.UserID, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find history, userID: %s", s.UserID)
+	}
+
+	return histories, nil
+}
+
+// change applies f to the task managed by the session user and stores the result.
+func (u *taskUsecase) change(s Session, id model.TaskID, action string, f func(model.Task) (*model.Task, error)) error {
+	fetchedTask, err := findManagedTask(u.taskRepository, u.workspaceRepository, s, id)
+	if err != nil {
+		return err
+	}
+
+	t, err := f(*fetchedTask)
+	if err != nil {
+		return errors.Wrapf(withKind(ErrInvalidArgument, err), "failed to %s task", action)
+	}
+
+	return u.update(s, fetchedTask, t)
+}
+
+// update stores the task together with the history of its change, and publishes the events of the change.
+func (u *taskUsecase) update(s Session, fetchedTask, t *model.Task) error {
+	if err := u.taskRepository.Update(t, newHistory(s.UserID, fetchedTask, *t)); err != nil {
+		return updateError(err)
+	}
+
+	publishChange(u.eventPublisher, fetchedTask, *t)
+
+	return nil
+}
+
+// updateTree stores the changed tasks of the tree at once together with the history of each of them.
+func (u *taskUsecase) updateTree(s Session, tree *model.TaskTree, changed []*model.Task) error {
+	before := treeTasks(tree)
+
+	histories := make([]*model.TaskHistory, 0, len(changed))
+	for _, t := range changed {
+		histories = append(histories, newHistory(s.UserID, before[t.ID], *t))
+	}
+
+	if err := u.taskRepository.UpdateAll(changed, histories); err != nil {
+		return updateError(err)
+	}
+
+	for _, t := range changed {
+		publishChange(u.eventPublisher, before[t.ID], *t)
+	}
+
+	return nil
+}
+
+// treeTasks returns the tasks of the tree by their IDs.
+func treeTasks(tree *model.TaskTree) map[model.TaskID]*model.Task {
+	tasks := map[model.TaskID]*model.Task{tree.Task.ID: tree.Task}
+	for _, d := range tree.Descendants() {
+		tasks[d.ID] = d
+	}
+
+	return tasks
+}
+
+// completeTree stores the tasks of the tree completed at once together with the next occurrences of the recurring ones
+// and the history of each of them. The changed tasks are replaced with the ones as stored.
+func (u *taskUsecase) completeTree(s Session, tree *model.TaskTree, changed []*model.Task) error {
+	completedIDs := make(map[model.TaskID]bool, len(changed))
+	for _, t := range changed {
+		completedIDs[t.ID] = true
+	}
+
+	nexts := make(map[model.TaskID]*model.Task)
+
+	for i, t := range changed {
+		done, next, err := nextOccurrence(*t)
+		if err != nil {
+			return err
+		} else if next == nil {
+			continue
+		}
+
+		// INFO: a subtask cannot be working under a completed parent, so the next occurrence of a subtask
+		// whose parent is completed together becomes a top-level task
+		if next.ParentID != nil && completedIDs[*next.ParentID] {
+			next.ParentID = nil
+		}
+
+		changed[i] = done
+		nexts[done.ID] = next
+	}
+
+	before := treeTasks(tree)
+
+	var histories []*model.TaskHistory
+
+	for _, t := range changed {
+		histories = append(histories, newHistory(s.UserID, before[t.ID], *t))
+
+		if next, ok := nexts[t.ID]; ok {
+			histories = append(histories, newHistory(s.UserID, nil, *next))
+		}
+	}
+
+	if err := u.taskRepository.Complete(changed, nexts, histories); err != nil {
+		return updateError(err)
+	}
+
+	for _, t := range changed {
+		publishChange(u.eventPublisher, before[t.ID], *t)
+
+		if next, ok := nexts[t.ID]; ok {
+			publishChange(u.eventPublisher, nil, *next)
+		}
+	}
+
+	return nil
+}
+
+// nextOccurrence hands the recurrence rule of the task over to its next occurrence if the task is a completed recurring one.
+// Otherwise it returns the task as it is and nil.
+func nextOccurrence(t model.Task) (*model.Task, *model.Task, error) {
+	if t.Status != model.Completed || !t.Recurrence.IsRecurring() {
+		return &t, nil, nil
+	}
+
+	done, next, err := model.NextOccurrence(model.TaskID(model.CreateUUID()), t, getNow())
+	if err != nil {
+		return nil, nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to generate next occurrence")
+	}
+
+	return done, next, nil
+}
+
+// subtaskSpecSatisfied checks the task against its parent and subtasks.
+// They are fetched only when the status of the task can violate the spec.
+func (u *taskUsecase) subtaskSpecSatisfied(t model.Task) error {
+	var (
+		parent   *model.Task
+		children []*model.Task
+		err      error
+	)
+
+	if t.Status == model.Completed {
+		children, err = u.taskRepository.FindByParentID(t.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find subtasks, taskID: %s", t.ID)
+		}
+	} else if t.ParentID != nil {
+		parent, err = u.taskRepository.FindByID(*t.ParentID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find parent task, taskID: %s", *t.ParentID)
+		}
+	}
+
+	if err := model.SubtaskSpecSatisfied(t, parent, children); err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to satisfy subtask spec")
+	}
+
+	return nil
+}
+
+// blockersSatisfied checks that none of the tasks is completed while it is blocked by incomplete tasks.
+// The blockers among the tasks are taken in their new state, so that tasks completed together do not block each other.
+func (u *taskUsecase) blockersSatisfied(tasks []*model.Task) error {
+	changed := make(map[model.TaskID]*model.Task)
+
+	var completedIDs []model.TaskID
+
+	for _, t := range tasks {
+		changed[t.ID] = t
+		if t.Status == model.Completed {
+			completedIDs = append(completedIDs, t.ID)
+		}
+	}
+
+	if len(completedIDs) == 0 {
+		return nil
+	}
+
+	blockers, err := u.dependencyRepository.FindBlockers(completedIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to find blockers of tasks")
+	}
+
+	for _, t := range tasks {
+		current := make([]*model.Task, 0, len(blockers[t.ID]))
+		for _, b := range blockers[t.ID] {
+			if c, ok := changed[b.ID]; ok {
+				b = c
+			}
+
+			current = append(current, b)
+		}
+
+		if err := model.BlockersSatisfied(*t, current); err != nil {
+			return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to satisfy dependencies")
+		}
+	}
+
+	return nil
+}
+
+func (u *taskUsecase) findTree(t *model.Task, find func(model.TaskID) ([]*model.Task, error), visited map[model.TaskID]bool) (*model.TaskTree, error) {
+	visited[t.ID] = true
+
+	children, err := find(t.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find subtasks, taskID: %s", t.ID)
+	}
+
+	tree := &model.TaskTree{Task: t}
+
+	for _, c := range children {
+		if visited[c.ID] {
+			continue
+		}
+
+		subtree, err := u.findTree(c, find, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		tree.Children = append(tree.Children, subtree)
+	}
+
+	return tree, nil
+}
+
+// findTask finds the task which the session user has at least the required access to, see model.TaskAccessOf.
+// When only the view is required, the tasks which the user cannot view are reported as not found to hide their existence.
+// Otherwise they are forbidden.
+func findTask(tr repository.TaskRepository, wr repository.WorkspaceRepository, s Session, id model.TaskID, required model.TaskAccess) (*model.Task, model.TaskAccess, error) {
+	t, err := tr.FindByID(id)
+	if err != nil {
+		return nil, model.NoAccess, errors.Wrapf(err, "failed to find task, taskID: %s", id)
+	} else if t == nil {
+		return nil, model.NoAccess, errors.Wrapf(ErrNotFound, "task is not found, taskID: %s", id)
+	}
+
+	m, err := wr.FindMember(t.WorkspaceID, s.UserID)
+	if err != nil {
+		return nil, model.NoAccess, errors.Wrapf(err, "failed to find member, workspaceID: %s", t.WorkspaceID)
+	}
+
+	var sharedUserIDs []model.UserID
+
+	// INFO: sharing only allows to view the task
+	if m == nil && required == model.ViewAccess && !t.IsOwnedBy(s.UserID) && !t.IsAssignedTo(s.UserID) {
+		if sharedUserIDs, err = tr.FindSharedUserIDs(id); err != nil {
+			return nil, model.NoAccess, errors.Wrapf(err, "failed to find shared users, taskID: %s", id)
+		}
+	}
+
+	access := model.TaskAccessOf(*t, s.UserID, m, sharedUserIDs)
+
+	switch {
+	case access >= required:
+		return t, access, nil
+	case required == model.ViewAccess:
+		return nil, access, errors.Wrapf(ErrNotFound, "task is not found, taskID: %s", id)
+	case required == model.WorkAccess:
+		return nil, access, errors.Wrap(ErrForbidden, "session user is not task owner, assignee or workspace admin")
+	default:
+		return nil, access, errors.Wrap(ErrForbidden, "session user is not task owner or workspace admin")
+	}
+}
+
+// findUsers finds the users by their IDs, which may be duplicated. Deleted users are mapped to nil.
+func findUsers(ur repository.UserRepository, userIDs []model.UserID) (map[model.UserID]*model.User, error) {
+	users := map[model.UserID]*model.User{}
+
+	for _, userID := range userIDs {
+		if _, ok := users[userID]; ok {
+			continue
+		}
+
+		user, err := ur.FindByID(userID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find user, userID: %s", userID)
+		}
+
+		// INFO: a deleted user is cached as nil as well, so that it is looked up only once
+		users[userID] = user
+	}
+
+	return users, nil
+}
+
+// findVisibleTask finds the task which the session user can view. Other tasks are reported as not found.
+func findVisibleTask(tr repository.TaskRepository, wr repository.WorkspaceRepository, s Session, id model.TaskID) (*model.Task, error) {
+	t, _, err := findTask(tr, wr, s, id, model.ViewAccess)
+
+	return t, err
+}
+
+// findWorkableTask finds the task which the session user can work on, i.e. manages or is assigned to. Other tasks are forbidden.
+func findWorkableTask(tr repository.TaskRepository, wr repository.WorkspaceRepository, s Session, id model.TaskID) (*model.Task, error) {
+	t, _, err := findTask(tr, wr, s, id, model.WorkAccess)
+
+	return t, err
+}
+
+// findManagedTask finds the task which the session user manages as its creator or a workspace admin. Other tasks are forbidden.
+func findManagedTask(tr repository.TaskRepository, wr repository.WorkspaceRepository, s Session, id model.TaskID) (*model.Task, error) {
+	t, _, err := findTask(tr, wr, s, id, model.ManageAccess)
+
+	return t, err
+}
+
+func (u *taskUsecase) findUserIDsByEmails(s Session, emails []string) ([]model.UserID, error) {
+	userIDs := make([]model.UserID, 0, len(emails))
+	seen := make(map[model.UserID]bool, len(emails))
+
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+
+		user, err := u.userRepository.FindByEmail(model.Email(email))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find user, email: %s", email)
+		} else if user == nil {
+			return nil, errors.Wrapf(ErrInvalidArgument, "user is not registered, email: %s", email)
+		}
+
+		if user.ID == s.UserID || seen[user.ID] {
+			continue
+		}
+
+		seen[user.ID] = true
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs, nil
+}
+
+// updateError wraps the error of storing tasks. A concurrent update of the tasks is reported as a conflict.
+func updateError(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return errors.Wrap(withKind(ErrConflict, err), "failed to update task")
+	}
+
+	return errors.Wrap(err, "failed to update task")
+}
+
+// newHistory returns the history of the change of the task by the user. A nil before means the task is created.
+// It is nil when none of the recorded fields changed.
+func newHistory(userID model.UserID, before *model.Task, after model.Task) *model.TaskHistory {
+	return model.NewTaskHistory(model.HistoryID(model.CreateUUID()), userID, before, after, getNow())
+}
+
+// publishChange publishes the events of the change of the task, once the change has been stored.
+func publishChange(ep EventPublisher, before *model.Task, after model.Task) {
+	for _, e := range model.TaskChangeEvents(before, after, getNow()) {
+		ep.Publish(e)
+	}
+}
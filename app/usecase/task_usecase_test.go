@@ -1,9 +1,11 @@
 package usecase
 
 import (
+	"strings"
 	"testing"
 	"time"
 	"todo-app/domain/model"
+	"todo-app/domain/repository"
 	"todo-app/mock"
 
 	"github.com/golang/mock/gomock"
@@ -22,6 +24,8 @@ func TestTaskCreateUseCase(t *testing.T) {
 		expectedOutput    error
 		expectedErr       error
 		expectedCallTimes int
+		// INFO: the event is published only when the task is stored
+		expectedPublishTimes int
 	}{
 		{
 			"normal case",
@@ -31,6 +35,7 @@ func TestTaskCreateUseCase(t *testing.T) {
 			nil,
 			nil,
 			1,
+			1,
 		},
 		{
 			"error case(fail to store)",
@@ -40,6 +45,7 @@ func TestTaskCreateUseCase(t *testing.T) {
 			errors.New("failed to create"),
 			errors.New("failed to store"),
 			1,
+			0,
 		},
 	}
 
@@ -50,11 +56,24 @@ func TestTaskCreateUseCase(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepository := mock.NewMockTaskRepository(ctrl)
-			usecase := NewTaskUsecase(taskRepository)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).Do(func(e model.TaskEvent) {
+				assert.Exactly(t, model.TaskCreated, e.Type)
+				assert.Exactly(t, session.UserID, e.UserID)
+			}).Times(tt.expectedPublishTimes)
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
 
-			taskRepository.EXPECT().Create(gomock.Any()).Return(tt.expectedOutput).Times(tt.expectedCallTimes)
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
-			if err := usecase.Create(session, tt.taskName, tt.detail, tt.deadline); err != nil {
+			taskRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.expectedOutput).Times(tt.expectedCallTimes)
+
+			if _, err := usecase.Create(session, "", tt.taskName, tt.detail, model.DueOn(tt.deadline), model.NoRecurrence, model.DefaultPriority); err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
 				} else {
@@ -67,7 +86,204 @@ func TestTaskCreateUseCase(t *testing.T) {
 	}
 }
 
+func TestTaskCreateTodoUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("0c9e0d6e-5b3a-4f4e-9b1d-2f6a8c7e4d21")
+	uid := "6f1a2b3c4d@google.com"
+	deadline := time.Now().AddDate(0, 0, 2)
+	completedAt := time.Now().AddDate(0, 0, -1)
+
+	tests := []struct {
+		name                  string
+		uid                   string
+		calendarName          string
+		status                model.Status
+		usedName              *model.Task
+		usedID                *model.Task
+		expectedErr           error
+		expectedFindByIDTimes int
+		expectedCallTimes     int
+	}{
+		{
+			"normal case",
+			uid,
+			"6f1a2b3c4d",
+			model.Completed,
+			nil,
+			nil,
+			nil,
+			1,
+			1,
+		},
+		{
+			"empty UID case",
+			"",
+			"6f1a2b3c4d",
+			model.Working,
+			nil,
+			nil,
+			ErrInvalidArgument,
+			1,
+			0,
+		},
+		{
+			"used calendar name case",
+			uid,
+			"6f1a2b3c4d",
+			model.Working,
+			&model.Task{ID: id, AssigneeID: session.UserID, CalendarName: "6f1a2b3c4d"},
+			nil,
+			ErrConflict,
+			0,
+			0,
+		},
+		{
+			"used ID case",
+			string(id),
+			string(id),
+			model.Working,
+			nil,
+			&model.Task{ID: id, UserID: "other"},
+			ErrConflict,
+			1,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleOwner}, nil).AnyTimes()
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByCalendarName(session.UserID, tt.calendarName).Return(tt.usedName, nil).Times(1),
+				taskRepository.EXPECT().FindByID(model.TaskID(tt.calendarName)).Return(tt.usedID, nil).Times(tt.expectedFindByIDTimes),
+				taskRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(task *model.Task, _ *model.TaskHistory) error {
+					assert.Nil(t, model.UUIDSpecSatisfied(string(task.ID)))
+					assert.Exactly(t, tt.uid, task.CalendarUID)
+					assert.Exactly(t, tt.calendarName, task.CalendarName)
+					assert.Exactly(t, model.PersonalWorkspaceID(session.UserID), task.WorkspaceID)
+					assert.Exactly(t, model.Completed, task.Status)
+					assert.Exactly(t, completedAt.Format("2006-01-02"), task.CompletionDate.Format("2006-01-02"))
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if _, err := usecase.CreateTodo(session, tt.uid, tt.calendarName, "Venue Reservation", "", tt.status, &completedAt, model.DueOn(deadline), model.DefaultPriority); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskFindByCalendarNameUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+	named := &model.Task{ID: id, UserID: session.UserID, AssigneeID: session.UserID, WorkspaceID: model.PersonalWorkspaceID(session.UserID), Name: "Venue Reservation", CalendarUID: "6f1a2b3c4d@google.com", CalendarName: "6f1a2b3c4d"}
+	unnamed := &model.Task{ID: id, UserID: session.UserID, AssigneeID: session.UserID, WorkspaceID: model.PersonalWorkspaceID(session.UserID), Name: "Venue Reservation"}
+
+	tests := []struct {
+		name                  string
+		calendarName          string
+		byName                *model.Task
+		byID                  *model.Task
+		expectedOutput        *model.Task
+		expectedErr           error
+		expectedFindByIDTimes int
+	}{
+		{
+			"named by client case",
+			"6f1a2b3c4d",
+			named,
+			nil,
+			named,
+			nil,
+			0,
+		},
+		{
+			"named after ID case",
+			string(id),
+			nil,
+			unnamed,
+			unnamed,
+			nil,
+			1,
+		},
+		{
+			"ID of task named by client case",
+			string(id),
+			nil,
+			named,
+			nil,
+			ErrNotFound,
+			1,
+		},
+		{
+			"not found case",
+			"6f1a2b3c4d",
+			nil,
+			nil,
+			nil,
+			ErrNotFound,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleOwner}, nil).AnyTimes()
+			taskRepository.EXPECT().FindByCalendarName(session.UserID, tt.calendarName).Return(tt.byName, nil).Times(1)
+			taskRepository.EXPECT().FindByID(model.TaskID(tt.calendarName)).Return(tt.byID, nil).Times(tt.expectedFindByIDTimes)
+
+			output, err := usecase.FindByCalendarName(session, tt.calendarName)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+			} else if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			assert.Exactly(t, tt.expectedOutput, output)
+		})
+	}
+}
+
 func TestTaskFindByIDUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
 	id := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
 
 	tests := []struct {
@@ -79,7 +295,7 @@ func TestTaskFindByIDUseCase(t *testing.T) {
 		{
 			"normal case",
 			id,
-			&model.Task{ID: id, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), NotificationCount: 0, PostponedCount: 0},
+			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 		},
 	}
@@ -91,11 +307,21 @@ func TestTaskFindByIDUseCase(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepository := mock.NewMockTaskRepository(ctrl)
-			usecase := NewTaskUsecase(taskRepository)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
 			taskRepository.EXPECT().FindByID(tt.taskID).Return(tt.expectedOutput, tt.expectedErr).Times(1)
 
-			output, err := usecase.FindByID(tt.taskID)
+			output, err := usecase.FindByID(session, tt.taskID)
 			if err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr)
@@ -110,30 +336,58 @@ func TestTaskFindByIDUseCase(t *testing.T) {
 	}
 }
 
-func TestTaskFindAllUseCase(t *testing.T) {
+func TestTaskFindByIDNotFoundUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	taskRepository.EXPECT().FindByID(id).Return(nil, nil).Times(1)
+
+	output, err := usecase.FindByID(session, id)
+	assert.Nil(t, output)
+	assert.True(t, errors.Is(err, ErrNotFound), "not found error is expected but received: %v", err)
+}
+
+func TestTaskFindUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
 	id1 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab")
 	id2 := model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac")
 
 	tests := []struct {
-		name               string
-		expectedOutput     []*model.Task
-		expectedFindAllErr error
-		expectedErr        error
+		name            string
+		expectedOutput  *model.TaskPage
+		expectedFindErr error
+		expectedErr     error
 	}{
 		{
 			"normal case",
-			[]*model.Task{
-				{ID: id1, Name: "Venue Reservation1", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), NotificationCount: 0, PostponedCount: 0},
-				{ID: id2, Name: "Venue Reservation2", Detail: "Reserve venue for conference2", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), NotificationCount: 1, PostponedCount: 1},
-			},
+			&model.TaskPage{Tasks: []*model.Task{
+				{ID: id1, UserID: session.UserID, Name: "Venue Reservation1", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
+				{ID: id2, UserID: session.UserID, Name: "Venue Reservation2", Detail: "Reserve venue for conference2", Status: model.Working, CompletionDate: nil, Deadline: time.Date(2022, 1, 26, 0o0, 0o0, 0o0, 0o00000000, time.Local), TimeZone: time.Local.String(), NotificationCount: 1, PostponedCount: 1},
+			}, NextCursor: "cursor"},
 			nil,
 			nil,
 		},
 		{
 			"error case",
 			nil,
-			errors.New("find all error"),
-			errors.New("failed to find all tasks"),
+			errors.New("find by user id error"),
+			errors.New("failed to find tasks"),
 		},
 	}
 
@@ -144,11 +398,21 @@ func TestTaskFindAllUseCase(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepository := mock.NewMockTaskRepository(ctrl)
-			usecase := NewTaskUsecase(taskRepository)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
-			taskRepository.EXPECT().FindAll().Return(tt.expectedOutput, tt.expectedFindAllErr).Times(1)
+			taskRepository.EXPECT().Find(model.TaskQuery{ViewerID: session.UserID, Limit: 2}).Return(tt.expectedOutput, tt.expectedFindErr).Times(1)
 
-			output, err := usecase.FindAll()
+			output, err := usecase.Find(session, model.TaskQuery{ViewerID: model.UserID("other"), Limit: 2})
 			if err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -169,8 +433,8 @@ func TestTaskUpdateUseCase(t *testing.T) {
 	dl := time.Now().AddDate(0, 0, 2)
 	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
 
-	normalTask := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, NotificationCount: 0, PostponedCount: 0}
-	updatedTask := &model.Task{ID: id, UserID: session.UserID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, NotificationCount: 0, PostponedCount: 0}
+	normalTask := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0, Version: 2}
+	updatedTask := &model.Task{ID: id, UserID: session.UserID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: model.Working, Priority: model.DefaultPriority, CompletionDate: nil, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0, Version: 2}
 
 	updatedTaskName := "Updated Venue Reservation"
 	updatedTaskDetail := "Updated Reserve venue for conference"
@@ -178,6 +442,7 @@ func TestTaskUpdateUseCase(t *testing.T) {
 
 	tests := []struct {
 		name                string
+		version             int
 		expectedFindByIDErr error
 		expectedUpdateErr   error
 		expectedErr         error
@@ -185,6 +450,7 @@ func TestTaskUpdateUseCase(t *testing.T) {
 	}{
 		{
 			"normal case",
+			2,
 			nil,
 			nil,
 			nil,
@@ -192,6 +458,7 @@ func TestTaskUpdateUseCase(t *testing.T) {
 		},
 		{
 			"find by id error case",
+			2,
 			errors.New("find by id error"),
 			nil,
 			errors.New("failed to find task"),
@@ -199,11 +466,28 @@ func TestTaskUpdateUseCase(t *testing.T) {
 		},
 		{
 			"update error case",
+			2,
 			nil,
 			errors.New("update error"),
 			errors.New("failed to update task"),
 			1,
 		},
+		{
+			"stale version error case",
+			1,
+			nil,
+			nil,
+			ErrConflict,
+			0,
+		},
+		{
+			"version conflict error case",
+			2,
+			nil,
+			errors.Wrap(repository.ErrVersionConflict, "failed to update task"),
+			ErrConflict,
+			1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -213,15 +497,34 @@ func TestTaskUpdateUseCase(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepository := mock.NewMockTaskRepository(ctrl)
-			usecase := NewTaskUsecase(taskRepository)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
 			gomock.InOrder(
 				taskRepository.EXPECT().FindByID(id).Return(normalTask, tt.expectedFindByIDErr).Times(1),
-				taskRepository.EXPECT().Update(updatedTask).Return(tt.expectedUpdateErr).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().Update(updatedTask, gomock.Any()).DoAndReturn(func(_ *model.Task, h *model.TaskHistory) error {
+					if assert.NotNil(t, h) {
+						assert.Exactly(t, model.HistoryUpdate, h.Action)
+						assert.Exactly(t, id, h.TaskID)
+					}
+
+					return tt.expectedUpdateErr
+				}).Times(tt.expectedCallTimes),
 			)
 
-			if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, deadline); err != nil {
-				if tt.expectedErr != nil {
+			if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, tt.version); err != nil {
+				if tt.expectedErr == ErrConflict {
+					assert.True(t, errors.Is(err, ErrConflict), "conflict error is expected but received: %v", err)
+				} else if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
 				} else {
 					t.Fatalf("error is not expected but received: %v", err)
@@ -240,7 +543,7 @@ func TestTaskUpdatePostoneUseCase(t *testing.T) {
 	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
 	updatedDeadline := deadline.Add(24 * time.Hour)
 
-	updatedTask := &model.Task{ID: id, UserID: session.UserID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: updatedDeadline, NotificationCount: 0, PostponedCount: 1}
+	updatedTask := &model.Task{ID: id, UserID: session.UserID, Name: "Updated Venue Reservation", Detail: "Updated Reserve venue for conference", Status: model.Working, Priority: model.DefaultPriority, CompletionDate: nil, Deadline: updatedDeadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 1}
 
 	updatedTaskName := "Updated Venue Reservation"
 	updatedTaskDetail := "Updated Reserve venue for conference"
@@ -254,14 +557,14 @@ func TestTaskUpdatePostoneUseCase(t *testing.T) {
 	}{
 		{
 			"normal case",
-			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, NotificationCount: 0, PostponedCount: 0},
+			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0},
 			nil,
 			1,
 		},
 		{
-			"postponed count limit over erro case",
-			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, NotificationCount: 0, PostponedCount: 3},
-			errors.New("failed to satisfy task spec"),
+			"postponed count limit approval required case",
+			&model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 3},
+			ErrApprovalRequired,
 			0,
 		},
 	}
@@ -273,14 +576,24 @@ func TestTaskUpdatePostoneUseCase(t *testing.T) {
 			defer ctrl.Finish()
 
 			taskRepository := mock.NewMockTaskRepository(ctrl)
-			usecase := NewTaskUsecase(taskRepository)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
 			gomock.InOrder(
 				taskRepository.EXPECT().FindByID(id).Return(tt.fetchedTask, nil).Times(1),
-				taskRepository.EXPECT().Update(updatedTask).Return(nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().Update(updatedTask, gomock.Any()).Return(nil).Times(tt.expectedCallTimes),
 			)
 
-			if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, updatedDeadline); err != nil {
+			if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, model.DueOn(updatedDeadline), model.NoRecurrence, model.DefaultPriority, false, 0); err != nil {
 				if tt.expectedErr != nil {
 					assert.Contains(t, err.Error(), tt.expectedErr.Error())
 				} else {
@@ -299,7 +612,7 @@ func TestOtherUsersTaskUpdateUseCase(t *testing.T) {
 	dl := time.Now().AddDate(0, 0, 2)
 	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
 
-	otherUsersTask := &model.Task{ID: id, UserID: model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33"), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, NotificationCount: 0, PostponedCount: 0}
+	otherUsersTask := &model.Task{ID: id, UserID: model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33"), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, CompletionDate: nil, Deadline: deadline, TimeZone: time.Local.String(), NotificationCount: 0, PostponedCount: 0}
 
 	updatedTaskName := "Updated Venue Reservation"
 	updatedTaskDetail := "Updated Reserve venue for conference"
@@ -310,13 +623,24 @@ func TestOtherUsersTaskUpdateUseCase(t *testing.T) {
 	defer ctrl.Finish()
 
 	taskRepository := mock.NewMockTaskRepository(ctrl)
-	usecase := NewTaskUsecase(taskRepository)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
 
 	taskRepository.EXPECT().FindByID(id).Return(otherUsersTask, nil).Times(1)
 
-	if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, deadline); err != nil {
+	if err := usecase.Update(session, id, updatedTaskName, updatedTaskDetail, status, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, 0); err != nil {
 		if expectedErr != nil {
 			assert.Contains(t, err.Error(), expectedErr.Error())
+			assert.True(t, errors.Is(err, ErrForbidden), "forbidden error is expected but received: %v", err)
 		} else {
 			t.Fatalf("error is not expected but received: %v", err)
 		}
@@ -324,3 +648,1505 @@ func TestOtherUsersTaskUpdateUseCase(t *testing.T) {
 		assert.Exactly(t, expectedErr, nil, "error is expected but received nil")
 	}
 }
+
+func TestAssigneeTaskUpdateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	creatorID := model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	assignedTask := &model.Task{ID: id, UserID: creatorID, AssigneeID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, Priority: model.DefaultPriority, Deadline: deadline, TimeZone: time.Local.String()}
+
+	tests := []struct {
+		name              string
+		taskName          string
+		detail            string
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"detail change case",
+			"Venue Reservation",
+			"Updated Reserve venue for conference",
+			nil,
+			1,
+		},
+		{
+			"name change forbidden case",
+			"Updated Venue Reservation",
+			"Reserve venue for conference",
+			ErrForbidden,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			updatedTask := *assignedTask
+			updatedTask.Name = tt.taskName
+			updatedTask.Detail = tt.detail
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(assignedTask, nil).Times(1),
+				taskRepository.EXPECT().Update(&updatedTask, gomock.Any()).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Update(session, id, tt.taskName, tt.detail, model.Working, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, 0); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestWorkspaceTaskUpdateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("team")
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	workspaceTask := &model.Task{ID: id, UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33"), WorkspaceID: workspaceID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, Priority: model.DefaultPriority, Deadline: deadline, TimeZone: time.Local.String()}
+
+	tests := []struct {
+		name              string
+		member            *model.Member
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"admin case",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleAdmin},
+			nil,
+			1,
+		},
+		{
+			"member case",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			ErrForbidden,
+			0,
+		},
+		{
+			"viewer case",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleViewer},
+			ErrForbidden,
+			0,
+		},
+		{
+			"not member case",
+			nil,
+			ErrForbidden,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			updatedTask := *workspaceTask
+			updatedTask.Name = "Updated Venue Reservation"
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(workspaceTask, nil).Times(1),
+				workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(tt.member, nil).Times(1),
+				taskRepository.EXPECT().Update(&updatedTask, gomock.Any()).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Update(session, id, updatedTask.Name, updatedTask.Detail, model.Working, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, 0); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestOtherUsersTaskFindByIDUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	ownerID := model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		visibility    model.Visibility
+		sharedUserIDs []model.UserID
+		expectedErr   error
+	}{
+		{
+			"shared with session user case",
+			model.Shared,
+			[]model.UserID{session.UserID},
+			nil,
+		},
+		{
+			"not shared with session user case",
+			model.Shared,
+			[]model.UserID{"yyyecd7f-48fe-6b1c-499a-ec9f52b15a33"},
+			ErrNotFound,
+		},
+		{
+			"private task case",
+			model.Private,
+			[]model.UserID{session.UserID},
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(model.PersonalWorkspaceID(ownerID), session.UserID).Return(nil, nil).Times(1)
+
+			task := &model.Task{ID: id, UserID: ownerID, WorkspaceID: model.PersonalWorkspaceID(ownerID), Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Visibility: tt.visibility}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().FindSharedUserIDs(id).Return(tt.sharedUserIDs, nil).Times(1),
+			)
+
+			output, err := usecase.FindByID(session, id)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, task, output)
+			}
+		})
+	}
+}
+
+func TestTaskShareUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	member := &model.User{ID: model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33"), Email: "member@example.com"}
+
+	tests := []struct {
+		name               string
+		emails             []string
+		findByEmailOutput  *model.User
+		expectedUserIDs    []model.UserID
+		expectedErr        error
+		expectedCallTimes  int
+		expectedVisibility model.Visibility
+	}{
+		{
+			"normal case",
+			[]string{" member@example.com ", ""},
+			member,
+			[]model.UserID{member.ID},
+			nil,
+			1,
+			model.Shared,
+		},
+		{
+			"unregistered user case",
+			[]string{"unknown@example.com"},
+			nil,
+			nil,
+			ErrInvalidArgument,
+			0,
+			model.Shared,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Detail: "Reserve venue for conference", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+			sharedTask := *task
+			sharedTask.Visibility = tt.expectedVisibility
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				userRepository.EXPECT().FindByEmail(gomock.Any()).Return(tt.findByEmailOutput, nil).Times(1),
+				taskRepository.EXPECT().Share(&sharedTask, tt.expectedUserIDs, gomock.Any()).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Share(session, id, tt.expectedVisibility, tt.emails)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, &sharedTask, output)
+			}
+		})
+	}
+}
+
+func TestTaskAssignUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	member := &model.User{ID: model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33"), Email: "member@example.com"}
+
+	tests := []struct {
+		name              string
+		email             string
+		findByEmailOutput *model.User
+		findMemberOutput  *model.Member
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			" member@example.com ",
+			member,
+			&model.Member{UserID: member.ID, Role: model.RoleMember},
+			nil,
+			1,
+		},
+		{
+			"unregistered user case",
+			"unknown@example.com",
+			nil,
+			nil,
+			ErrInvalidArgument,
+			0,
+		},
+		{
+			"assignee is not member of workspace case",
+			"member@example.com",
+			member,
+			nil,
+			ErrInvalidArgument,
+			0,
+		},
+		{
+			"assignee is viewer of workspace case",
+			"member@example.com",
+			member,
+			&model.Member{UserID: member.ID, Role: model.RoleViewer},
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), member.ID).Return(tt.findMemberOutput, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, AssigneeID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+			assignedTask := *task
+			assignedTask.AssigneeID = member.ID
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				userRepository.EXPECT().FindByEmail(model.Email(strings.TrimSpace(tt.email))).Return(tt.findByEmailOutput, nil).Times(1),
+				taskRepository.EXPECT().Update(&assignedTask, gomock.Any()).DoAndReturn(func(_ *model.Task, h *model.TaskHistory) error {
+					if assert.NotNil(t, h) {
+						assert.Exactly(t, []model.FieldChange{{Field: "assignee_id", Before: string(session.UserID), After: string(member.ID)}}, h.Changes)
+					}
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Assign(session, id, tt.email)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, &assignedTask, output)
+			}
+		})
+	}
+}
+
+func TestTaskArchiveUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		status            model.Status
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			model.Completed,
+			nil,
+			1,
+		},
+		{
+			"working task case",
+			model.Working,
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: tt.status, Deadline: deadline, TimeZone: time.Local.String()}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(t *model.Task, _ *model.TaskHistory) error {
+					if !t.IsArchived() {
+						return errors.New("task is not archived")
+					}
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Archive(session, id); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskTrashUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	trashedAt := time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		trashedAt         *time.Time
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			nil,
+			nil,
+			1,
+		},
+		{
+			"trashed task case",
+			&trashedAt,
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), TrashedAt: tt.trashedAt}
+			child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Compare venues", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(id).Return([]*model.Task{child}, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(childID).Return(nil, nil).Times(1),
+				taskRepository.EXPECT().UpdateAll(gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ []*model.TaskHistory) error {
+					assert.Len(t, tasks, 2)
+
+					for _, task := range tasks {
+						assert.True(t, task.IsTrashed())
+					}
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Trash(session, id); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskRestoreUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	parentID := model.TaskID("09742914-f296-4855-aa8d-f099727e288f")
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	trashedAt := time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		parentTrashedAt   *time.Time
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			nil,
+			nil,
+			1,
+		},
+		{
+			"trashed parent case",
+			&trashedAt,
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			parent := &model.Task{ID: parentID, UserID: session.UserID, Name: "Party", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), TrashedAt: tt.parentTrashedAt}
+			task := &model.Task{ID: id, UserID: session.UserID, ParentID: &parentID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), TrashedAt: &trashedAt}
+			child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Compare venues", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), TrashedAt: &trashedAt}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().FindByID(parentID).Return(parent, nil).Times(1),
+				taskRepository.EXPECT().FindTrashedByParentID(id).Return([]*model.Task{child}, nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().FindTrashedByParentID(childID).Return(nil, nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().UpdateAll(gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ []*model.TaskHistory) error {
+					assert.Len(t, tasks, 2)
+
+					for _, task := range tasks {
+						assert.False(t, task.IsTrashed())
+					}
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Restore(session, id); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskDeleteUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Date(2022, 1, 26, 0, 0, 0, 0, time.Local)
+	trashedAt := time.Date(2022, 1, 27, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		trashedAt         *time.Time
+		children          []*model.Task
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			&trashedAt,
+			nil,
+			nil,
+			1,
+		},
+		{
+			"not trashed task case",
+			nil,
+			nil,
+			ErrInvalidArgument,
+			0,
+		},
+		{
+			"live subtask case",
+			&trashedAt,
+			[]*model.Task{{ID: model.TaskID("29742914-f296-4855-aa8d-f099727e288f"), ParentID: &id, Status: model.Working}},
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), TrashedAt: tt.trashedAt}
+
+			taskRepository.EXPECT().FindByParentID(id).Return(tt.children, nil).MaxTimes(1)
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().Delete(id).Return([]*model.Attachment{{ID: "a1", TaskID: id, StorageKey: "attachments/a1"}}, nil).Times(tt.expectedCallTimes),
+				fileStorage.EXPECT().Delete("attachments/a1").Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.Delete(session, id); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskCompleteUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Now().AddDate(0, 0, 2)
+
+	blockerID := model.TaskID("39742914-f296-4855-aa8d-f099727e288f")
+	blocker := &model.Task{ID: blockerID, UserID: session.UserID, Name: "Decide date", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+
+	tests := []struct {
+		name                          string
+		cascade                       bool
+		blockers                      map[model.TaskID][]*model.Task
+		expectedErr                   error
+		expectedFindBlockersCallTimes int
+		expectedCallTimes             int
+	}{
+		{
+			"cascade case",
+			true,
+			nil,
+			nil,
+			1,
+			1,
+		},
+		{
+			"incomplete subtask case",
+			false,
+			nil,
+			ErrInvalidArgument,
+			0,
+			0,
+		},
+		{
+			"blocked by subtask completed together case",
+			true,
+			map[model.TaskID][]*model.Task{id: {{ID: childID, Status: model.Working}}},
+			nil,
+			1,
+			1,
+		},
+		{
+			"error case: subtask is blocked by incomplete task",
+			true,
+			map[model.TaskID][]*model.Task{childID: {blocker}},
+			ErrInvalidArgument,
+			1,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			parent := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+			child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Book hall", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(parent, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(id).Return([]*model.Task{child}, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(childID).Return(nil, nil).Times(1),
+				dependencyRepository.EXPECT().FindBlockers([]model.TaskID{childID, id}).Return(tt.blockers, nil).Times(tt.expectedFindBlockersCallTimes),
+				taskRepository.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, nexts map[model.TaskID]*model.Task, _ []*model.TaskHistory) error {
+					assert.Len(t, tasks, 2)
+					for _, task := range tasks {
+						assert.Exactly(t, model.Completed, task.Status)
+					}
+					assert.Empty(t, nexts)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			if _, err := usecase.Complete(session, id, tt.cascade, nil); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskCompleteVersionUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Now().AddDate(0, 0, 2)
+
+	tests := []struct {
+		name              string
+		version           int
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			3,
+			nil,
+			1,
+		},
+		{
+			"stale version case",
+			2,
+			ErrConflict,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Version: 3}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(id).Return(nil, nil).Times(tt.expectedCallTimes),
+				dependencyRepository.EXPECT().FindBlockers([]model.TaskID{id}).Return(nil, nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ map[model.TaskID]*model.Task, _ []*model.TaskHistory) error {
+					if assert.Len(t, tasks, 1) {
+						assert.Exactly(t, tt.version, tasks[0].Version)
+						tasks[0].Version++
+					}
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Complete(session, id, true, &tt.version)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, model.Completed, output.Status)
+				assert.Exactly(t, tt.version+1, output.Version)
+			}
+		})
+	}
+}
+
+func TestTaskCompleteRecurringSubtaskUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	parent := &model.Task{ID: id, UserID: session.UserID, Name: "Conference", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+	child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Weekly report", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Recurrence: model.Recurrence("FREQ=DAILY;INTERVAL=7")}
+
+	gomock.InOrder(
+		taskRepository.EXPECT().FindByID(id).Return(parent, nil).Times(1),
+		taskRepository.EXPECT().FindByParentID(id).Return([]*model.Task{child}, nil).Times(1),
+		taskRepository.EXPECT().FindByParentID(childID).Return(nil, nil).Times(1),
+		dependencyRepository.EXPECT().FindBlockers([]model.TaskID{childID, id}).Return(nil, nil).Times(1),
+		taskRepository.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, nexts map[model.TaskID]*model.Task, _ []*model.TaskHistory) error {
+			assert.Len(t, tasks, 2)
+			assert.Len(t, nexts, 1)
+
+			if next := nexts[childID]; assert.NotNil(t, next) {
+				assert.Exactly(t, model.Working, next.Status)
+				assert.Nil(t, next.ParentID)
+			}
+
+			return nil
+		}).Times(1),
+	)
+
+	_, err := usecase.Complete(session, id, true, nil)
+	assert.Nil(t, err)
+}
+
+func TestTaskUpdateWithIncompleteSubtaskUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	parent := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+	child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Book hall", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+
+	gomock.InOrder(
+		taskRepository.EXPECT().FindByID(id).Return(parent, nil).Times(1),
+		taskRepository.EXPECT().FindByParentID(id).Return([]*model.Task{child}, nil).Times(1),
+	)
+
+	err := usecase.Update(session, id, parent.Name, parent.Detail, model.Completed, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "invalid argument error is expected but received: %v", err)
+	assert.Contains(t, err.Error(), "subtasks are not completed")
+}
+
+func TestTaskUpdateCascadeUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	childID := model.TaskID("29742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name              string
+		version           int
+		completeErr       error
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			3,
+			nil,
+			nil,
+			1,
+		},
+		{
+			"stale version case",
+			2,
+			nil,
+			ErrConflict,
+			0,
+		},
+		{
+			"updated concurrently case",
+			3,
+			repository.ErrVersionConflict,
+			ErrConflict,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			parent := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Version: 3}
+			child := &model.Task{ID: childID, UserID: session.UserID, ParentID: &id, Name: "Book hall", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Version: 7}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(parent, nil).Times(1),
+				taskRepository.EXPECT().FindByParentID(id).Return([]*model.Task{child}, nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().FindByParentID(childID).Return(nil, nil).Times(tt.expectedCallTimes),
+				dependencyRepository.EXPECT().FindBlockers([]model.TaskID{childID, id}).Return(nil, nil).Times(tt.expectedCallTimes),
+				taskRepository.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, _ map[model.TaskID]*model.Task, _ []*model.TaskHistory) error {
+					if assert.Len(t, tasks, 2) {
+						assert.Exactly(t, childID, tasks[0].ID)
+						assert.Exactly(t, 7, tasks[0].Version)
+						assert.Exactly(t, id, tasks[1].ID)
+						assert.Exactly(t, "Venue Reservation in Tokyo", tasks[1].Name)
+						assert.Exactly(t, 3, tasks[1].Version)
+
+						for _, task := range tasks {
+							assert.Exactly(t, model.Completed, task.Status)
+						}
+					}
+
+					return tt.completeErr
+				}).Times(tt.expectedCallTimes),
+			)
+
+			err := usecase.Update(session, id, "Venue Reservation in Tokyo", parent.Detail, model.Completed, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, true, tt.version)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskUpdateBlockedUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	blockerID := model.TaskID("39742914-f296-4855-aa8d-f099727e288f")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	task := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+	blocker := &model.Task{ID: blockerID, UserID: session.UserID, Name: "Decide date", Status: model.Behind, Deadline: deadline, TimeZone: time.Local.String()}
+
+	gomock.InOrder(
+		taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+		taskRepository.EXPECT().FindByParentID(id).Return(nil, nil).Times(1),
+		dependencyRepository.EXPECT().FindBlockers([]model.TaskID{id}).Return(map[model.TaskID][]*model.Task{id: {blocker}}, nil).Times(1),
+	)
+
+	err := usecase.Update(session, id, task.Name, task.Detail, model.Completed, model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority, false, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "invalid argument error is expected but received: %v", err)
+	assert.Contains(t, err.Error(), "task is blocked by incomplete tasks")
+}
+
+func TestTaskCreateSubtaskUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	deadline := time.Now().AddDate(0, 0, 2)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	parent := &model.Task{ID: id, UserID: session.UserID, Name: "Venue Reservation", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String()}
+
+	gomock.InOrder(
+		taskRepository.EXPECT().FindByID(id).Return(parent, nil).Times(1),
+		taskRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1),
+	)
+
+	output, err := usecase.CreateSubtask(session, id, "Book hall", "", model.DueOn(deadline), model.NoRecurrence, model.DefaultPriority)
+	assert.Nil(t, err)
+	assert.Exactly(t, id, *output.ParentID)
+}
+
+func TestTaskUpdateRecurringUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+	recurrence := model.Recurrence("FREQ=DAILY;INTERVAL=7")
+	dl := time.Now().AddDate(0, 0, 2)
+	deadline := time.Date(dl.Year(), dl.Month(), dl.Day(), 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	fetchedTask := &model.Task{ID: id, UserID: session.UserID, Name: "Weekly report", Status: model.Working, Deadline: deadline, TimeZone: time.Local.String(), Recurrence: recurrence, NotificationCount: 1}
+
+	gomock.InOrder(
+		taskRepository.EXPECT().FindByID(id).Return(fetchedTask, nil).Times(1),
+		taskRepository.EXPECT().FindByParentID(id).Return(nil, nil).Times(1),
+		dependencyRepository.EXPECT().FindBlockers([]model.TaskID{id}).Return(nil, nil).Times(1),
+		taskRepository.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, nexts map[model.TaskID]*model.Task, histories []*model.TaskHistory) error {
+			if assert.Len(t, tasks, 1) {
+				assert.Exactly(t, model.Completed, tasks[0].Status)
+				assert.Exactly(t, model.NoRecurrence, tasks[0].Recurrence)
+			}
+
+			if assert.Len(t, histories, 2) {
+				assert.Exactly(t, model.HistoryUpdate, histories[0].Action)
+				assert.Exactly(t, id, histories[0].TaskID)
+				assert.Exactly(t, model.HistoryCreate, histories[1].Action)
+				assert.Exactly(t, nexts[id].ID, histories[1].TaskID)
+			}
+
+			if next := nexts[id]; assert.NotNil(t, next) {
+				assert.NotEqual(t, id, next.ID)
+				assert.Exactly(t, model.Working, next.Status)
+				assert.Exactly(t, deadline.AddDate(0, 0, 7), next.Deadline)
+				assert.Exactly(t, recurrence, next.Recurrence)
+				assert.Exactly(t, 0, next.NotificationCount)
+			}
+
+			return nil
+		}).Times(1),
+	)
+
+	err := usecase.Update(session, id, fetchedTask.Name, fetchedTask.Detail, model.Completed, model.DueOn(deadline), recurrence, model.DefaultPriority, false, 0)
+	assert.Nil(t, err)
+}
+
+func TestTaskFindByLabelsUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	query := model.TaskQuery{LabelIDs: []model.LabelID{"work", "urgent"}, LabelMatch: model.MatchAny}
+
+	tests := []struct {
+		name              string
+		urgentLabel       *model.Label
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			&model.Label{ID: "urgent", UserID: session.UserID},
+			1,
+			nil,
+		},
+		{
+			"error case: label of other user",
+			&model.Label{ID: "urgent", UserID: model.UserID("other")},
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			expectedQuery := query
+			expectedQuery.ViewerID = session.UserID
+
+			gomock.InOrder(
+				labelRepository.EXPECT().FindByID(model.LabelID("work")).Return(&model.Label{ID: "work", UserID: session.UserID}, nil).Times(1),
+				labelRepository.EXPECT().FindByID(model.LabelID("urgent")).Return(tt.urgentLabel, nil).Times(1),
+				taskRepository.EXPECT().Find(expectedQuery).Return(&model.TaskPage{}, nil).Times(tt.expectedCallTimes),
+			)
+
+			if _, err := usecase.Find(session, query); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskFindWithInvalidCursorUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	userRepository := mock.NewMockUserRepository(ctrl)
+	labelRepository := mock.NewMockLabelRepository(ctrl)
+	historyRepository := mock.NewMockHistoryRepository(ctrl)
+	workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+	dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+	eventPublisher := mock.NewMockEventPublisher(ctrl)
+	eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+	workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+	query := model.TaskQuery{ViewerID: session.UserID, Cursor: "broken"}
+
+	taskRepository.EXPECT().Find(query).Return(nil, errors.Wrap(repository.ErrInvalidCursor, "cursor is not base64")).Times(1)
+
+	_, err := usecase.Find(session, query)
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "invalid argument error is expected but received: %v", err)
+}
+
+func TestTaskFindByCalendarTokenUseCase(t *testing.T) {
+	user := &model.User{ID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33"), CalendarToken: "1b0a3a34-4a4e-4d4b-9d8f-0c8e2f1d6a11"}
+	task1 := &model.Task{ID: model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab"), UserID: user.ID, AssigneeID: user.ID, Status: model.Working}
+	task2 := &model.Task{ID: model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac"), UserID: user.ID, AssigneeID: user.ID, Status: model.Completed}
+
+	tests := []struct {
+		name           string
+		token          string
+		user           *model.User
+		expectedOutput []*model.Task
+		expectedErr    error
+	}{
+		{
+			"normal case: all the pages",
+			user.CalendarToken,
+			user,
+			[]*model.Task{task1, task2},
+			nil,
+		},
+		{
+			"error case: unknown token",
+			"unknown",
+			nil,
+			nil,
+			ErrNotFound,
+		},
+		{
+			"error case: empty token",
+			"",
+			nil,
+			nil,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			userRepository.EXPECT().FindByCalendarToken(tt.token).Return(tt.user, nil).MaxTimes(1)
+
+			query := model.TaskQuery{ViewerID: user.ID, AssigneeID: user.ID, Sort: model.DefaultTaskSort, Limit: model.MaxPageSize}
+			next := query
+			next.Cursor = "cursor"
+
+			gomock.InOrder(
+				taskRepository.EXPECT().Find(query).Return(&model.TaskPage{Tasks: []*model.Task{task1}, NextCursor: "cursor"}, nil).MaxTimes(1),
+				taskRepository.EXPECT().Find(next).Return(&model.TaskPage{Tasks: []*model.Task{task2}}, nil).MaxTimes(1),
+			)
+
+			owner, output, err := usecase.FindByCalendarToken(tt.token)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.user, owner)
+				assert.Exactly(t, tt.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestTaskSetLabelsUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+
+	tests := []struct {
+		name              string
+		label             *model.Label
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			&model.Label{ID: "work", UserID: session.UserID},
+			1,
+			nil,
+		},
+		{
+			"error case: label of other user",
+			&model.Label{ID: "work", UserID: model.UserID("other")},
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(gomock.Any(), session.UserID).Return(&model.Member{UserID: session.UserID, Role: model.RoleMember}, nil).AnyTimes()
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(&model.Task{ID: id, UserID: session.UserID}, nil).Times(1),
+				labelRepository.EXPECT().FindByID(model.LabelID("work")).Return(tt.label, nil).Times(1),
+				labelRepository.EXPECT().UpdateTaskLabels(id, session.UserID, []model.LabelID{"work"}).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.SetLabels(session, id, []model.LabelID{"work", "work"}); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestTaskFindHistoryUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	ownerID := model.UserID("xxxecd7f-48fe-6b1c-499a-ec9f52b15a33")
+	id := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+
+	tests := []struct {
+		name              string
+		visibility        model.Visibility
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			model.Shared,
+			1,
+			nil,
+		},
+		{
+			"error case: private task of other user",
+			model.Private,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			historyRepository := mock.NewMockHistoryRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			dependencyRepository := mock.NewMockDependencyRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			fileStorage := mock.NewMockFileStorage(ctrl)
+			usecase := NewTaskUsecase(taskRepository, userRepository, labelRepository, historyRepository, workspaceRepository, dependencyRepository, eventPublisher, fileStorage)
+
+			workspaceRepository.EXPECT().FindMember(model.PersonalWorkspaceID(ownerID), session.UserID).Return(nil, nil).Times(1)
+
+			task := &model.Task{ID: id, UserID: ownerID, WorkspaceID: model.PersonalWorkspaceID(ownerID), Name: "Venue Reservation", Visibility: tt.visibility}
+			owner := &model.User{ID: ownerID, Email: "owner@example.com"}
+			histories := []*model.TaskHistory{
+				{ID: "1", TaskID: id, UserID: ownerID, Action: model.HistoryCreate},
+				{ID: "2", TaskID: id, UserID: model.SystemUserID, Action: model.HistoryUpdate},
+				{ID: "3", TaskID: id, UserID: ownerID, Action: model.HistoryUpdate},
+			}
+
+			gomock.InOrder(
+				taskRepository.EXPECT().FindByID(id).Return(task, nil).Times(1),
+				taskRepository.EXPECT().FindSharedUserIDs(id).Return([]model.UserID{session.UserID}, nil).Times(1),
+				historyRepository.EXPECT().FindByTaskID(id).Return(histories, nil).Times(tt.expectedCallTimes),
+				userRepository.EXPECT().FindByID(ownerID).Return(owner, nil).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.FindHistory(session, id)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, &model.TaskTimeline{Histories: histories, Users: map[model.UserID]*model.User{ownerID: owner}}, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"strings"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+// TransferUsecase moves tasks in and out of the app in bulk. The file formats are left to the interfaces,
+// which exchange the tasks as records.
+type TransferUsecase interface {
+	Export(session Session) ([]model.TaskRecord, error)
+	Preview(session Session, workspaceID model.WorkspaceID, records []model.TaskRecord) (*model.TaskImport, error)
+	Import(session Session, workspaceID model.WorkspaceID, records []model.TaskRecord) (*model.TaskImport, error)
+}
+
+type transferUsecase struct {
+	taskRepository      repository.TaskRepository
+	userRepository      repository.UserRepository
+	labelRepository     repository.LabelRepository
+	workspaceRepository repository.WorkspaceRepository
+	eventPublisher      EventPublisher
+}
+
+func NewTransferUsecase(tr repository.TaskRepository, ur repository.UserRepository, lr repository.LabelRepository, wr repository.WorkspaceRepository, ep EventPublisher) TransferUsecase {
+	return &transferUsecase{
+		taskRepository:      tr,
+		userRepository:      ur,
+		labelRepository:     lr,
+		workspaceRepository: wr,
+		eventPublisher:      ep,
+	}
+}
+
+// Export returns the records of all the tasks owned by the session user in any workspace, the archived ones last,
+// with the labels of the user. Trashed tasks are not exported.
+func (u *transferUsecase) Export(s Session) ([]model.TaskRecord, error) {
+	q := model.TaskQuery{ViewerID: s.UserID, OwnerID: s.UserID, Sort: model.DefaultTaskSort, Limit: model.MaxPageSize}
+
+	var tasks []*model.Task
+
+	for {
+		page, err := u.taskRepository.Find(q)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find tasks, userID: %s", s.UserID)
+		}
+
+		tasks = append(tasks, page.Tasks...)
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		q.Cursor = page.NextCursor
+	}
+
+	archived, err := u.taskRepository.FindArchivedByUserID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find archived tasks, userID: %s", s.UserID)
+	}
+
+	tasks = append(tasks, archived...)
+
+	ids := make([]model.TaskID, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	labels, err := u.labelRepository.FindByTaskIDs(s.UserID, ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find labels of tasks, userID: %s", s.UserID)
+	}
+
+	return model.TaskRecordsOf(tasks, labels), nil
+}
+
+// Preview checks the records as tasks of the session user in the workspace, or in the personal workspace when it is empty,
+// without storing them. The errors of the records are reported in their rows.
+func (u *transferUsecase) Preview(s Session, workspaceID model.WorkspaceID, records []model.TaskRecord) (*model.TaskImport, error) {
+	if workspaceID == "" {
+		workspaceID = model.PersonalWorkspaceID(s.UserID)
+	}
+
+	if _, err := findMember(u.workspaceRepository, s, workspaceID, model.RoleMember); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepository.FindByID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find user, userID: %s", s.UserID)
+	} else if user == nil {
+		return nil, errors.Wrapf(ErrNotFound, "user is not found, userID: %s", s.UserID)
+	}
+
+	imp, err := model.NewTaskImport(s.UserID, workspaceID, records, user.Location(), getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to check import")
+	}
+
+	return imp, nil
+}
+
+// Import stores the tasks of the records in a single transaction, only when all of them are valid.
+// Otherwise nothing is stored, and the import is returned with the errors of its rows along with ErrInvalidArgument.
+// The labels are matched by name with the labels of the session user, and the missing ones are created.
+func (u *transferUsecase) Import(s Session, workspaceID model.WorkspaceID, records []model.TaskRecord) (*model.TaskImport, error) {
+	imp, err := u.Preview(s, workspaceID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	if !imp.Valid() {
+		return imp, errors.Wrapf(ErrInvalidArgument, "rows cannot be imported, count: %d", imp.ErrorCount())
+	}
+
+	tasks := imp.Tasks()
+
+	labels, taskLabels, err := u.labelImport(s, imp)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]*model.TaskHistory, 0, len(tasks))
+	for _, t := range tasks {
+		histories = append(histories, newHistory(s.UserID, nil, *t))
+	}
+
+	if err := u.taskRepository.Import(tasks, labels, taskLabels, histories); err != nil {
+		return nil, errors.Wrap(err, "failed to store imported tasks")
+	}
+
+	for _, t := range tasks {
+		publishChange(u.eventPublisher, nil, *t)
+	}
+
+	return imp, nil
+}
+
+// labelImport returns the labels of the records which the session user does not have yet, and the labels of each imported task.
+func (u *transferUsecase) labelImport(s Session, imp *model.TaskImport) ([]*model.Label, map[model.TaskID][]model.LabelID, error) {
+	names := imp.LabelNames()
+	if len(names) == 0 {
+		return nil, nil, nil
+	}
+
+	labels, err := u.labelRepository.FindByUserID(s.UserID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to find labels, userID: %s", s.UserID)
+	}
+
+	byName := make(map[string]model.LabelID, len(labels)+len(names))
+	for _, l := range labels {
+		byName[strings.ToLower(l.Name)] = l.ID
+	}
+
+	var created []*model.Label
+
+	for _, name := range names {
+		if _, ok := byName[strings.ToLower(name)]; ok {
+			continue
+		}
+
+		l, err := model.NewLabel(model.LabelID(model.CreateUUID()), s.UserID, name, model.DefaultLabelColor)
+		if err != nil {
+			return nil, nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create label")
+		}
+
+		created = append(created, l)
+		byName[strings.ToLower(name)] = l.ID
+	}
+
+	taskLabels := make(map[model.TaskID][]model.LabelID)
+
+	for _, row := range imp.Rows {
+		if len(row.Record.Labels) == 0 {
+			continue
+		}
+
+		seen := make(map[model.LabelID]bool, len(row.Record.Labels))
+		ids := make([]model.LabelID, 0, len(row.Record.Labels))
+
+		for _, name := range row.Record.Labels {
+			if id := byName[strings.ToLower(strings.TrimSpace(name))]; !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		taskLabels[row.Task.ID] = ids
+	}
+
+	return created, taskLabels, nil
+}
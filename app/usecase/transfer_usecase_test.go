@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"testing"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferExportUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	task1 := &model.Task{ID: model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ab"), UserID: session.UserID, Status: model.Working}
+	task2 := &model.Task{ID: model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ac"), UserID: session.UserID, Status: model.Behind}
+	task3 := &model.Task{ID: model.TaskID("72c24944-f532-4c5d-a695-70fa3e72f3ad"), UserID: session.UserID, Status: model.Completed}
+	labels := map[model.TaskID][]*model.Label{task2.ID: {{ID: "a3c5b3e6-2f5c-4c8e-9e0c-1d7f8a6b5c4d", UserID: session.UserID, Name: "Work"}}}
+
+	tests := []struct {
+		name           string
+		archivedErr    error
+		expectedOutput []model.TaskRecord
+		expectedErr    error
+	}{
+		{
+			"normal case: all the pages and the archive with labels",
+			nil,
+			model.TaskRecordsOf([]*model.Task{task1, task2, task3}, labels),
+			nil,
+		},
+		{
+			"error case: archive is not found",
+			errors.New("connection refused"),
+			nil,
+			errors.New("failed to find archived tasks"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			usecase := NewTransferUsecase(taskRepository, userRepository, labelRepository, workspaceRepository, eventPublisher)
+
+			query := model.TaskQuery{ViewerID: session.UserID, OwnerID: session.UserID, Sort: model.DefaultTaskSort, Limit: model.MaxPageSize}
+			next := query
+			next.Cursor = "cursor"
+
+			gomock.InOrder(
+				taskRepository.EXPECT().Find(query).Return(&model.TaskPage{Tasks: []*model.Task{task1}, NextCursor: "cursor"}, nil).Times(1),
+				taskRepository.EXPECT().Find(next).Return(&model.TaskPage{Tasks: []*model.Task{task2}}, nil).Times(1),
+			)
+			taskRepository.EXPECT().FindArchivedByUserID(session.UserID).Return([]*model.Task{task3}, tt.archivedErr).Times(1)
+			labelRepository.EXPECT().FindByTaskIDs(session.UserID, []model.TaskID{task1.ID, task2.ID, task3.ID}).Return(labels, nil).MaxTimes(1)
+
+			output, err := usecase.Export(session)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestTransferImportUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	user := &model.User{ID: session.UserID, TimeZone: "Asia/Tokyo"}
+	workspaceID := model.WorkspaceID("3e0a2c1b-7fd2-4e4b-a8f6-52a4b2c3d9e1")
+	records := []model.TaskRecord{
+		{ID: "2", ParentID: "1", Name: "Venue Reservation", Deadline: "2099-01-26", Priority: "P2", Labels: []string{"Work", "Calls", "work"}},
+		{ID: "1", Name: "Conference", Deadline: "2099-01-28", Labels: []string{"WORK"}},
+	}
+	work := &model.Label{ID: model.LabelID("a3c5b3e6-2f5c-4c8e-9e0c-1d7f8a6b5c4d"), UserID: session.UserID, Name: "work", Color: "#0d6efd"}
+
+	tests := []struct {
+		name                string
+		preview             bool
+		workspaceID         model.WorkspaceID
+		records             []model.TaskRecord
+		member              *model.Member
+		expectedCreateTimes int
+		expectedErrorCount  int
+		expectedErr         error
+	}{
+		{
+			"normal case: parents are created before subtasks",
+			false,
+			workspaceID,
+			records,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			1,
+			0,
+			nil,
+		},
+		{
+			"normal case: personal workspace",
+			false,
+			"",
+			records,
+			&model.Member{WorkspaceID: model.PersonalWorkspaceID(session.UserID), UserID: session.UserID, Role: model.RoleOwner},
+			1,
+			0,
+			nil,
+		},
+		{
+			"normal case: preview stores nothing",
+			true,
+			workspaceID,
+			append([]model.TaskRecord{{Name: "Catering", Priority: "P9"}}, records...),
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			0,
+			1,
+			nil,
+		},
+		{
+			"error case: any invalid row stores nothing",
+			false,
+			workspaceID,
+			append([]model.TaskRecord{{Name: "Catering", Priority: "P9"}}, records...),
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			0,
+			1,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: no records",
+			false,
+			workspaceID,
+			nil,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			0,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: viewer of workspace",
+			false,
+			workspaceID,
+			records,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleViewer},
+			0,
+			0,
+			ErrForbidden,
+		},
+		{
+			"error case: not member of workspace",
+			false,
+			workspaceID,
+			records,
+			nil,
+			0,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			labelRepository := mock.NewMockLabelRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			eventPublisher := mock.NewMockEventPublisher(ctrl)
+			eventPublisher.EXPECT().Publish(gomock.Any()).AnyTimes()
+			usecase := NewTransferUsecase(taskRepository, userRepository, labelRepository, workspaceRepository, eventPublisher)
+
+			expectedWorkspaceID := tt.workspaceID
+			if expectedWorkspaceID == "" {
+				expectedWorkspaceID = model.PersonalWorkspaceID(session.UserID)
+			}
+
+			workspaceRepository.EXPECT().FindMember(expectedWorkspaceID, session.UserID).Return(tt.member, nil).Times(1)
+			userRepository.EXPECT().FindByID(session.UserID).Return(user, nil).MaxTimes(1)
+			labelRepository.EXPECT().FindByUserID(session.UserID).Return([]*model.Label{work}, nil).Times(tt.expectedCreateTimes)
+			taskRepository.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(tasks []*model.Task, labels []*model.Label, taskLabels map[model.TaskID][]model.LabelID, histories []*model.TaskHistory) error {
+				if assert.Len(t, tasks, 2) {
+					assert.Exactly(t, "Conference", tasks[0].Name)
+					assert.Exactly(t, "Venue Reservation", tasks[1].Name)
+					assert.Exactly(t, &tasks[0].ID, tasks[1].ParentID)
+					assert.Exactly(t, expectedWorkspaceID, tasks[1].WorkspaceID)
+					assert.Exactly(t, "Asia/Tokyo", tasks[1].TimeZone)
+				}
+
+				if assert.Len(t, labels, 1) {
+					assert.Exactly(t, "Calls", labels[0].Name)
+					assert.Exactly(t, model.DefaultLabelColor, labels[0].Color)
+					assert.Exactly(t, session.UserID, labels[0].UserID)
+
+					assert.Exactly(t, []model.LabelID{work.ID}, taskLabels[tasks[0].ID])
+					assert.Exactly(t, []model.LabelID{work.ID, labels[0].ID}, taskLabels[tasks[1].ID])
+				}
+
+				if assert.Len(t, histories, 2) {
+					for i, h := range histories {
+						assert.Exactly(t, model.HistoryCreate, h.Action)
+						assert.Exactly(t, tasks[i].ID, h.TaskID)
+					}
+				}
+
+				return nil
+			}).Times(tt.expectedCreateTimes)
+
+			var (
+				output *model.TaskImport
+				err    error
+			)
+
+			if tt.preview {
+				output, err = usecase.Preview(session, tt.workspaceID, tt.records)
+			} else {
+				output, err = usecase.Import(session, tt.workspaceID, tt.records)
+			}
+
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+
+			if tt.expectedErrorCount > 0 && assert.NotNil(t, output) {
+				assert.Exactly(t, tt.expectedErrorCount, output.ErrorCount())
+				assert.Contains(t, output.Rows[0].Err.Error(), "unknown priority")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"time"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type TrashUsecase interface {
+	Purge(now time.Time) (int, error)
+}
+
+type trashUsecase struct {
+	taskRepository repository.TaskRepository
+	fileStorage    FileStorage
+	retention      time.Duration
+}
+
+func NewTrashUsecase(tr repository.TaskRepository, fs FileStorage, retention time.Duration) TrashUsecase {
+	return &trashUsecase{
+		taskRepository: tr,
+		fileStorage:    fs,
+		retention:      retention,
+	}
+}
+
+// Purge permanently deletes the tasks which have been in the trash longer than the retention period, with their files.
+func (u *trashUsecase) Purge(now time.Time) (int, error) {
+	count, attachments, err := u.taskRepository.DeleteTrashedBefore(now.Add(-u.retention))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge trashed tasks")
+	}
+
+	deleteFiles(u.fileStorage, attachments)
+
+	return count, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashPurgeUseCase(t *testing.T) {
+	now := time.Date(2022, 1, 27, 10, 10, 10, 0, time.Local)
+	retention := 7 * 24 * time.Hour
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepository := mock.NewMockTaskRepository(ctrl)
+	fileStorage := mock.NewMockFileStorage(ctrl)
+	usecase := NewTrashUsecase(taskRepository, fileStorage, retention)
+
+	attachments := []*model.Attachment{{ID: "a1", StorageKey: "attachments/t1/a1"}, {ID: "a2", StorageKey: "attachments/t2/a2"}}
+
+	taskRepository.EXPECT().DeleteTrashedBefore(time.Date(2022, 1, 20, 10, 10, 10, 0, time.Local)).Return(2, attachments, nil).Times(1)
+	// INFO: a file which fails to be deleted does not fail the purge, since the tasks are already deleted
+	fileStorage.EXPECT().Delete("attachments/t1/a1").Return(errors.New("failed to delete")).Times(1)
+	fileStorage.EXPECT().Delete("attachments/t2/a2").Return(nil).Times(1)
+
+	output, err := usecase.Purge(now)
+	assert.Nil(t, err)
+	assert.Exactly(t, 2, output)
+}
@@ -11,6 +11,10 @@ import (
 type UserUsecase interface {
 	SignUp(email, password string) error
 	Authenticate(email, password string) (model.UserID, error)
+	Find(session Session) (*model.User, error)
+	SetTimeZone(session Session, timeZone string) error
+	IssueCalendarToken(session Session) (string, error)
+	RevokeCalendarToken(session Session) error
 }
 
 type userUsecase struct {
@@ -25,10 +29,11 @@ func NewUserUsecase(ur repository.UserRepository, us service.UserService) UserUs
 	}
 }
 
+// SignUp registers a user together with the personal workspace of the user.
 func (u *userUsecase) SignUp(email, password string) error {
 	ok, err := u.userService.IsExists(model.Email(email))
 	if ok {
-		return errors.Errorf("already registered email. email: %s", email)
+		return errors.Wrapf(ErrConflict, "already registered email. email: %s", email)
 	} else if err != nil {
 		return err
 	}
@@ -37,27 +42,104 @@ func (u *userUsecase) SignUp(email, password string) error {
 
 	user, err := model.NewUser(model.UserID(id), model.Email(email), password)
 	if err != nil {
-		return errors.Wrap(err, "failed to create user")
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create user")
 	}
 
-	if err := u.userRepository.Create(user); err != nil {
+	w, m, err := model.NewPersonalWorkspace(user.ID, getNow())
+	if err != nil {
+		return errors.Wrap(err, "failed to create personal workspace")
+	}
+
+	if err := u.userRepository.Create(user, w, m); err != nil {
 		return errors.Wrap(err, "failed to store user")
 	}
 
 	return nil
 }
 
+// unregisteredUser has the hash of a random password, which the passwords for unknown emails are compared with.
+var unregisteredUser = model.User{Password: "$2a$10$Lqu1t2X2E.fAm7gO6KvbueD.4w187V.8mE10e3wSgPydJuiUzQs3W"}
+
+// Authenticate returns the ID of the user of the email and password.
+// An unknown email fails with the same error as a wrong password, so that nobody can tell which emails are registered.
 func (u *userUsecase) Authenticate(email, password string) (model.UserID, error) {
 	user, err := u.userRepository.FindByEmail(model.Email(email))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to find user")
+	}
+
+	// INFO: the password is checked even for an unknown email, so that it takes as long as a wrong password
+	registered := user != nil
+	if !registered {
+		user = &unregisteredUser
+	}
+
+	if err := user.ValidatePassword(password); err != nil || !registered {
+		return "", errors.Wrap(ErrUnauthenticated, "email or password is incorrect")
+	}
+
+	return user.ID, nil
+}
+
+// Find finds the session user.
+func (u *userUsecase) Find(s Session) (*model.User, error) {
+	user, err := u.userRepository.FindByID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find user, userID: %s", s.UserID)
 	} else if user == nil {
-		return "", errors.New("user is not registered")
+		return nil, errors.Wrapf(ErrNotFound, "user is not found, userID: %s", s.UserID)
 	}
 
-	if err := user.ValidatePassword(password); err != nil {
+	return user, nil
+}
+
+// SetTimeZone changes the time zone in which the deadlines the session user sets are interpreted.
+func (u *userUsecase) SetTimeZone(s Session, timeZone string) error {
+	user, err := u.Find(s)
+	if err != nil {
+		return err
+	}
+
+	user, err = model.UserSetTimeZone(*user, timeZone)
+	if err != nil {
+		return errors.Wrap(withKind(ErrInvalidArgument, err), "failed to set time zone")
+	}
+
+	if err := u.userRepository.Update(user); err != nil {
+		return errors.Wrapf(err, "failed to update user, userID: %s", user.ID)
+	}
+
+	return nil
+}
+
+// IssueCalendarToken enables the iCalendar feed of the session user, replacing the token issued before.
+func (u *userUsecase) IssueCalendarToken(s Session) (string, error) {
+	user, err := u.Find(s)
+	if err != nil {
 		return "", err
 	}
 
-	return user.ID, nil
+	user = model.UserIssueCalendarToken(*user)
+
+	if err := u.userRepository.Update(user); err != nil {
+		return "", errors.Wrapf(err, "failed to update user, userID: %s", user.ID)
+	}
+
+	return user.CalendarToken, nil
+}
+
+// RevokeCalendarToken disables the iCalendar feed of the session user.
+func (u *userUsecase) RevokeCalendarToken(s Session) error {
+	user, err := u.Find(s)
+	if err != nil {
+		return err
+	}
+
+	user = model.UserRevokeCalendarToken(*user)
+
+	if err := u.userRepository.Update(user); err != nil {
+		return errors.Wrapf(err, "failed to update user, userID: %s", user.ID)
+	}
+
+	return nil
 }
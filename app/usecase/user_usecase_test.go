@@ -78,7 +78,13 @@ func TestUserSignupUseCase(t *testing.T) {
 
 			gomock.InOrder(
 				userRepository.EXPECT().FindByEmail(model.Email(tt.email)).Return(tt.findByEmailOutput, tt.findByEmailErrOutput).Times(1),
-				userRepository.EXPECT().Create(gomock.Any()).Return(tt.CreateErrOutput).Times(tt.expectedCallTimes),
+				userRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(u *model.User, w *model.Workspace, m *model.Member) error {
+					assert.Exactly(t, u.ID, model.UserID(w.ID))
+					assert.Exactly(t, u.ID, m.UserID)
+					assert.Exactly(t, model.RoleOwner, m.Role)
+
+					return tt.CreateErrOutput
+				}).Times(tt.expectedCallTimes),
 			)
 
 			if err := usecase.SignUp(tt.email, tt.password); err != nil {
@@ -93,3 +99,171 @@ func TestUserSignupUseCase(t *testing.T) {
 		})
 	}
 }
+
+func TestUserAuthenticateUseCase(t *testing.T) {
+	user, err := model.NewUser("72c24944-f532-4c5d-a695-70fa3e72f3ab", "abc@example.com", "password123")
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		password          string
+		findByEmailOutput *model.User
+		expectedOutput    model.UserID
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			"password123",
+			user,
+			user.ID,
+			nil,
+		},
+		{
+			"wrong password case",
+			"password456",
+			user,
+			"",
+			ErrUnauthenticated,
+		},
+		{
+			"unregistered email case",
+			"password123",
+			nil,
+			"",
+			ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewUserUsecase(userRepository, service.NewUService(userRepository))
+
+			userRepository.EXPECT().FindByEmail(user.Email).Return(tt.findByEmailOutput, nil).Times(1)
+
+			output, err := usecase.Authenticate(string(user.Email), tt.password)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+					// INFO: unregistered emails must not be told from wrong passwords
+					assert.Exactly(t, "email or password is incorrect: authentication failed", err.Error())
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+
+			assert.Exactly(t, tt.expectedOutput, output)
+		})
+	}
+}
+
+func TestUserSetTimeZoneUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("72c24944-f532-4c5d-a695-70fa3e72f3ab")}
+	user := &model.User{ID: session.UserID, Email: "abc@example.com", TimeZone: model.DefaultTimeZone}
+
+	tests := []struct {
+		name              string
+		timeZone          string
+		user              *model.User
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			"Asia/Tokyo",
+			user,
+			1,
+			nil,
+		},
+		{
+			"error case: unknown time zone",
+			"Asia/Edo",
+			user,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: user is not found",
+			"Asia/Tokyo",
+			nil,
+			0,
+			ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewUserUsecase(userRepository, service.NewUService(userRepository))
+
+			gomock.InOrder(
+				userRepository.EXPECT().FindByID(session.UserID).Return(tt.user, nil).Times(1),
+				userRepository.EXPECT().Update(gomock.Any()).DoAndReturn(func(u *model.User) error {
+					assert.Exactly(t, tt.timeZone, u.TimeZone)
+
+					return nil
+				}).Times(tt.expectedCallTimes),
+			)
+
+			err := usecase.SetTimeZone(session, tt.timeZone)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestUserCalendarTokenUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("72c24944-f532-4c5d-a695-70fa3e72f3ab")}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepository := mock.NewMockUserRepository(ctrl)
+	usecase := NewUserUsecase(userRepository, service.NewUService(userRepository))
+
+	user := &model.User{ID: session.UserID, Email: "abc@example.com", TimeZone: model.DefaultTimeZone}
+	userRepository.EXPECT().FindByID(session.UserID).DoAndReturn(func(model.UserID) (*model.User, error) {
+		u := *user
+
+		return &u, nil
+	}).Times(2)
+	userRepository.EXPECT().Update(gomock.Any()).DoAndReturn(func(u *model.User) error {
+		user = u
+
+		return nil
+	}).Times(2)
+
+	token, err := usecase.IssueCalendarToken(session)
+	if err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.NotEmpty(t, token)
+	assert.Exactly(t, token, user.CalendarToken)
+	assert.Exactly(t, model.DefaultTimeZone, user.TimeZone)
+
+	if err := usecase.RevokeCalendarToken(session); err != nil {
+		t.Fatalf("error is not expected but received: %v", err)
+	}
+
+	assert.Empty(t, user.CalendarToken)
+}
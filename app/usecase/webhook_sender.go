@@ -0,0 +1,10 @@
+//go:generate mockgen -source=webhook_sender.go -destination=../mock/mock_webhook_sender.go -package=mock
+package usecase
+
+import "todo-app/domain/model"
+
+type WebhookSender interface {
+	// Send posts the payload of the delivery to the URL of the webhook, signed with its secret,
+	// and returns the status of the response. An error means that no response is received.
+	Send(w model.Webhook, d model.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxDeliveryLogSize is how many of the latest deliveries of a webhook are shown.
+	MaxDeliveryLogSize = 50
+	deliveryBatchSize  = 100
+)
+
+// WebhookUsecase manages the webhooks of workspaces, which only the admins of the workspaces can see and change,
+// and delivers the events of the tasks to them.
+type WebhookUsecase interface {
+	FindByWorkspace(session Session, workspaceID model.WorkspaceID) ([]*model.Webhook, error)
+	Find(session Session, workspaceID model.WorkspaceID, id model.WebhookID) (*model.Webhook, []*model.WebhookDelivery, error)
+	Create(session Session, workspaceID model.WorkspaceID, url string, events []string) (*model.Webhook, error)
+	Update(session Session, workspaceID model.WorkspaceID, id model.WebhookID, url string, events []string, active bool) (*model.Webhook, error)
+	RotateSecret(session Session, workspaceID model.WorkspaceID, id model.WebhookID) (*model.Webhook, error)
+	Delete(session Session, workspaceID model.WorkspaceID, id model.WebhookID) error
+	Dispatch(event model.TaskEvent) error
+	Deliver(now time.Time) (int, error)
+}
+
+type webhookUsecase struct {
+	taskRepository      repository.TaskRepository
+	webhookRepository   repository.WebhookRepository
+	workspaceRepository repository.WorkspaceRepository
+	webhookSender       WebhookSender
+}
+
+func NewWebhookUsecase(tr repository.TaskRepository, whr repository.WebhookRepository, wr repository.WorkspaceRepository, ws WebhookSender) WebhookUsecase {
+	return &webhookUsecase{
+		taskRepository:      tr,
+		webhookRepository:   whr,
+		workspaceRepository: wr,
+		webhookSender:       ws,
+	}
+}
+
+func (u *webhookUsecase) FindByWorkspace(s Session, workspaceID model.WorkspaceID) ([]*model.Webhook, error) {
+	if _, err := findMember(u.workspaceRepository, s, workspaceID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := u.webhookRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find webhooks, workspaceID: %s", workspaceID)
+	}
+
+	return webhooks, nil
+}
+
+// Find finds the webhook with its latest deliveries, up to MaxDeliveryLogSize.
+func (u *webhookUsecase) Find(s Session, workspaceID model.WorkspaceID, id model.WebhookID) (*model.Webhook, []*model.WebhookDelivery, error) {
+	w, err := u.findWebhook(s, workspaceID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deliveries, err := u.webhookRepository.FindDeliveries(id, MaxDeliveryLogSize)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to find deliveries, webhookID: %s", id)
+	}
+
+	return w, deliveries, nil
+}
+
+func (u *webhookUsecase) Create(s Session, workspaceID model.WorkspaceID, url string, events []string) (*model.Webhook, error) {
+	if _, err := findMember(u.workspaceRepository, s, workspaceID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	w, err := model.NewWebhook(model.WebhookID(model.CreateUUID()), workspaceID, s.UserID, url, events, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create webhook")
+	}
+
+	if err := u.webhookRepository.Create(w); err != nil {
+		return nil, errors.Wrap(err, "failed to store webhook")
+	}
+
+	return w, nil
+}
+
+// Update changes the URL and the events of the webhook. The pending deliveries of a webhook which is turned off are not sent.
+func (u *webhookUsecase) Update(s Session, workspaceID model.WorkspaceID, id model.WebhookID, url string, events []string, active bool) (*model.Webhook, error) {
+	fetchedWebhook, err := u.findWebhook(s, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := model.WebhookSet(*fetchedWebhook, url, events, active, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to update webhook")
+	}
+
+	if err := u.webhookRepository.Update(w); err != nil {
+		return nil, errors.Wrap(err, "failed to update webhook")
+	}
+
+	return w, nil
+}
+
+func (u *webhookUsecase) RotateSecret(s Session, workspaceID model.WorkspaceID, id model.WebhookID) (*model.Webhook, error) {
+	fetchedWebhook, err := u.findWebhook(s, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w := model.WebhookRotateSecret(*fetchedWebhook, getNow())
+
+	if err := u.webhookRepository.Update(w); err != nil {
+		return nil, errors.Wrap(err, "failed to update webhook")
+	}
+
+	return w, nil
+}
+
+func (u *webhookUsecase) Delete(s Session, workspaceID model.WorkspaceID, id model.WebhookID) error {
+	if _, err := u.findWebhook(s, workspaceID, id); err != nil {
+		return err
+	}
+
+	if err := u.webhookRepository.Delete(id); err != nil {
+		return errors.Wrap(err, "failed to delete webhook")
+	}
+
+	return nil
+}
+
+// Dispatch queues the deliveries of the event to the active webhooks of the workspace of the task which subscribe to it.
+// They are sent by Deliver, so that the receivers cannot slow down the change of the task.
+func (u *webhookUsecase) Dispatch(e model.TaskEvent) error {
+	t, err := u.taskRepository.FindByID(e.TaskID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find task, taskID: %s", e.TaskID)
+	} else if t == nil {
+		return nil
+	}
+
+	webhooks, err := u.webhookRepository.FindByWorkspaceID(t.WorkspaceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find webhooks, workspaceID: %s", t.WorkspaceID)
+	}
+
+	now := getNow()
+
+	var deliveries []*model.WebhookDelivery
+
+	for _, w := range webhooks {
+		if !w.Active || !w.Subscribes(e.Type) {
+			continue
+		}
+
+		d, err := model.NewWebhookDelivery(model.WebhookDeliveryID(model.CreateUUID()), *w, e, *t, now)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create delivery, webhookID: %s", w.ID)
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if err := u.webhookRepository.CreateDeliveries(deliveries); err != nil {
+		return errors.Wrap(err, "failed to store deliveries")
+	}
+
+	return nil
+}
+
+// Deliver sends the deliveries whose attempts are due, and returns the number of the attempts.
+// A delivery which is not received is retried later, which is recorded in its log rather than returned as an error.
+func (u *webhookUsecase) Deliver(now time.Time) (int, error) {
+	deliveries, err := u.webhookRepository.FindDueDeliveries(now, deliveryBatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find due deliveries")
+	}
+
+	webhooks := make(map[model.WebhookID]*model.Webhook)
+	count := 0
+
+	for _, d := range deliveries {
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			if w, err = u.webhookRepository.FindByID(d.WebhookID); err != nil {
+				return count, errors.Wrapf(err, "failed to find webhook, webhookID: %s", d.WebhookID)
+			}
+
+			webhooks[d.WebhookID] = w
+		}
+
+		var next *model.WebhookDelivery
+
+		if w == nil || !w.Active {
+			next = model.WebhookDeliveryCanceled(*d, "webhook is turned off")
+		} else {
+			status, sendErr := u.webhookSender.Send(*w, *d)
+			next = model.WebhookDeliveryAttempted(*d, status, sendErr, now)
+			count++
+		}
+
+		if err := u.webhookRepository.UpdateDelivery(next); err != nil {
+			return count, errors.Wrapf(err, "failed to update delivery, deliveryID: %s", d.ID)
+		}
+	}
+
+	return count, nil
+}
+
+// findWebhook finds the webhook of the workspace, of which the session user has to be an admin.
+func (u *webhookUsecase) findWebhook(s Session, workspaceID model.WorkspaceID, id model.WebhookID) (*model.Webhook, error) {
+	if _, err := findMember(u.workspaceRepository, s, workspaceID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	w, err := u.webhookRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find webhook, webhookID: %s", id)
+	} else if w == nil || w.WorkspaceID != workspaceID {
+		return nil, errors.Wrapf(ErrNotFound, "webhook is not found, webhookID: %s", id)
+	}
+
+	return w, nil
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookCreateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("0a5c1f9e-3c55-4d4e-9d8b-8f0e8e1f3b11")
+
+	tests := []struct {
+		name              string
+		role              model.Role
+		url               string
+		events            []string
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			model.RoleAdmin,
+			"https://example.com/hook",
+			[]string{"task.created", "task.completed"},
+			1,
+			nil,
+		},
+		{
+			"error case: invalid url",
+			model.RoleOwner,
+			"example.com/hook",
+			[]string{"task.created"},
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: member cannot create",
+			model.RoleMember,
+			"https://example.com/hook",
+			[]string{"task.created"},
+			0,
+			ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			webhookRepository := mock.NewMockWebhookRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			webhookSender := mock.NewMockWebhookSender(ctrl)
+			usecase := NewWebhookUsecase(taskRepository, webhookRepository, workspaceRepository, webhookSender)
+
+			workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+			webhookRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(w *model.Webhook) error {
+				assert.Exactly(t, workspaceID, w.WorkspaceID)
+				assert.Exactly(t, session.UserID, w.UserID)
+				assert.Exactly(t, tt.url, w.URL)
+				assert.Exactly(t, "task.created,task.completed", w.Events)
+				assert.True(t, w.Active)
+				assert.NotEmpty(t, w.Secret)
+
+				return nil
+			}).Times(tt.expectedCallTimes)
+
+			_, err := usecase.Create(session, workspaceID, tt.url, tt.events)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestWebhookUpdateUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("0a5c1f9e-3c55-4d4e-9d8b-8f0e8e1f3b11")
+	webhookID := model.WebhookID("5d3b0c8e-2f0f-4a39-9c53-1f0e7c2a6b21")
+
+	webhook := &model.Webhook{ID: webhookID, WorkspaceID: workspaceID, UserID: session.UserID, URL: "https://example.com/hook", Secret: "secret", Events: "task.created", Active: true}
+	otherWebhook := &model.Webhook{ID: webhookID, WorkspaceID: "other", URL: "https://example.com/hook", Secret: "secret", Events: "task.created", Active: true}
+
+	tests := []struct {
+		name              string
+		role              model.Role
+		webhook           *model.Webhook
+		expectedCallTimes int
+		expectedErr       error
+	}{
+		{
+			"normal case",
+			model.RoleAdmin,
+			webhook,
+			1,
+			nil,
+		},
+		{
+			"error case: webhook is not found",
+			model.RoleAdmin,
+			nil,
+			0,
+			ErrNotFound,
+		},
+		{
+			"error case: webhook of another workspace",
+			model.RoleOwner,
+			otherWebhook,
+			0,
+			ErrNotFound,
+		},
+		{
+			"error case: viewer cannot update",
+			model.RoleViewer,
+			webhook,
+			0,
+			ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			webhookRepository := mock.NewMockWebhookRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			webhookSender := mock.NewMockWebhookSender(ctrl)
+			usecase := NewWebhookUsecase(taskRepository, webhookRepository, workspaceRepository, webhookSender)
+
+			workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: tt.role}, nil).AnyTimes()
+			webhookRepository.EXPECT().FindByID(webhookID).Return(tt.webhook, nil).AnyTimes()
+			webhookRepository.EXPECT().Update(gomock.Any()).DoAndReturn(func(w *model.Webhook) error {
+				assert.Exactly(t, "https://example.com/other", w.URL)
+				assert.Exactly(t, "task.updated,task.behind", w.Events)
+				assert.False(t, w.Active)
+				assert.Exactly(t, "secret", w.Secret)
+
+				return nil
+			}).Times(tt.expectedCallTimes)
+
+			_, err := usecase.Update(session, workspaceID, webhookID, "https://example.com/other", []string{"task.behind", "task.updated"}, false)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestWebhookDispatchUseCase(t *testing.T) {
+	workspaceID := model.WorkspaceID("0a5c1f9e-3c55-4d4e-9d8b-8f0e8e1f3b11")
+	taskID := model.TaskID("19742914-f296-4855-aa8d-f099727e288f")
+
+	task := &model.Task{ID: taskID, UserID: "u1", WorkspaceID: workspaceID, Name: "Venue Reservation", Status: model.Completed, TimeZone: "UTC"}
+	event := model.NewTaskEvent(model.TaskCompleted, *task, time.Date(2022, 1, 26, 10, 0, 0, 0, time.UTC))
+
+	webhooks := []*model.Webhook{
+		{ID: "subscribed", WorkspaceID: workspaceID, Events: "task.created,task.completed", Active: true},
+		{ID: "unsubscribed", WorkspaceID: workspaceID, Events: "task.created", Active: true},
+		{ID: "inactive", WorkspaceID: workspaceID, Events: "task.completed", Active: false},
+	}
+
+	tests := []struct {
+		name               string
+		task               *model.Task
+		expectedFindTimes  int
+		expectedWebhookIDs []model.WebhookID
+	}{
+		{
+			"normal case",
+			task,
+			1,
+			[]model.WebhookID{"subscribed"},
+		},
+		{
+			"task is already deleted",
+			nil,
+			0,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			webhookRepository := mock.NewMockWebhookRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			webhookSender := mock.NewMockWebhookSender(ctrl)
+			usecase := NewWebhookUsecase(taskRepository, webhookRepository, workspaceRepository, webhookSender)
+
+			taskRepository.EXPECT().FindByID(taskID).Return(tt.task, nil).Times(1)
+			webhookRepository.EXPECT().FindByWorkspaceID(workspaceID).Return(webhooks, nil).Times(tt.expectedFindTimes)
+			webhookRepository.EXPECT().CreateDeliveries(gomock.Any()).DoAndReturn(func(deliveries []*model.WebhookDelivery) error {
+				var ids []model.WebhookID
+				for _, d := range deliveries {
+					assert.Exactly(t, model.TaskCompleted, d.EventType)
+					assert.Exactly(t, model.DeliveryPending, d.Status)
+					ids = append(ids, d.WebhookID)
+				}
+
+				assert.Exactly(t, tt.expectedWebhookIDs, ids)
+
+				return nil
+			}).Times(tt.expectedFindTimes)
+
+			if err := usecase.Dispatch(event); err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliverUseCase(t *testing.T) {
+	now := time.Date(2022, 1, 26, 10, 0, 0, 0, time.UTC)
+
+	active := &model.Webhook{ID: "active", URL: "https://example.com/hook", Events: "task.created", Active: true}
+	inactive := &model.Webhook{ID: "inactive", URL: "https://example.com/hook", Events: "task.created", Active: false}
+
+	tests := []struct {
+		name                string
+		webhook             *model.Webhook
+		attempts            int
+		statusCode          int
+		sendErr             error
+		expectedSendTimes   int
+		expectedCount       int
+		expectedStatus      model.DeliveryStatus
+		expectedAttempts    int
+		expectedNextAttempt time.Time
+	}{
+		{
+			"normal case",
+			active,
+			0,
+			200,
+			nil,
+			1,
+			1,
+			model.DeliverySucceeded,
+			1,
+			now,
+		},
+		{
+			"failure is retried",
+			active,
+			1,
+			503,
+			nil,
+			1,
+			1,
+			model.DeliveryPending,
+			2,
+			now.Add(2 * time.Minute),
+		},
+		{
+			"unreachable receiver is retried",
+			active,
+			0,
+			0,
+			errors.New("connection refused"),
+			1,
+			1,
+			model.DeliveryPending,
+			1,
+			now.Add(time.Minute),
+		},
+		{
+			"last attempt fails",
+			active,
+			model.MaxWebhookAttempts - 1,
+			500,
+			nil,
+			1,
+			1,
+			model.DeliveryFailed,
+			model.MaxWebhookAttempts,
+			now,
+		},
+		{
+			"inactive webhook is canceled",
+			inactive,
+			0,
+			0,
+			nil,
+			0,
+			0,
+			model.DeliveryFailed,
+			0,
+			now,
+		},
+		{
+			"deleted webhook is canceled",
+			nil,
+			0,
+			0,
+			nil,
+			0,
+			0,
+			model.DeliveryFailed,
+			0,
+			now,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepository := mock.NewMockTaskRepository(ctrl)
+			webhookRepository := mock.NewMockWebhookRepository(ctrl)
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			webhookSender := mock.NewMockWebhookSender(ctrl)
+			usecase := NewWebhookUsecase(taskRepository, webhookRepository, workspaceRepository, webhookSender)
+
+			deliveries := []*model.WebhookDelivery{
+				{ID: "d1", WebhookID: "wh", Status: model.DeliveryPending, Attempts: tt.attempts, NextAttemptAt: now},
+				{ID: "d2", WebhookID: "wh", Status: model.DeliveryPending, Attempts: tt.attempts, NextAttemptAt: now},
+			}
+
+			webhookRepository.EXPECT().FindDueDeliveries(now, gomock.Any()).Return(deliveries, nil).Times(1)
+			// INFO: the webhook is found once for the deliveries to it
+			webhookRepository.EXPECT().FindByID(model.WebhookID("wh")).Return(tt.webhook, nil).Times(1)
+			webhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(tt.statusCode, tt.sendErr).Times(2 * tt.expectedSendTimes)
+			webhookRepository.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d *model.WebhookDelivery) error {
+				assert.Exactly(t, tt.expectedStatus, d.Status)
+				assert.Exactly(t, tt.expectedAttempts, d.Attempts)
+				assert.Exactly(t, tt.expectedNextAttempt, d.NextAttemptAt)
+
+				return nil
+			}).Times(2)
+
+			count, err := usecase.Deliver(now)
+			if err != nil {
+				t.Fatalf("error is not expected but received: %v", err)
+			}
+
+			assert.Exactly(t, 2*tt.expectedCount, count)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+
+	"github.com/pkg/errors"
+)
+
+type WorkspaceUsecase interface {
+	Create(session Session, name string) (*model.Workspace, error)
+	FindByUser(session Session) ([]*model.Workspace, error)
+	FindByID(session Session, id model.WorkspaceID) (*model.WorkspaceDetail, error)
+	Invite(session Session, id model.WorkspaceID, role model.Role) (*model.Invitation, error)
+	FindInvitation(session Session, token string) (*model.Invitation, *model.Workspace, error)
+	Accept(session Session, token string) (*model.Member, error)
+	ChangeRole(session Session, id model.WorkspaceID, userID model.UserID, role model.Role) (*model.Member, error)
+	RemoveMember(session Session, id model.WorkspaceID, userID model.UserID) error
+}
+
+type workspaceUsecase struct {
+	workspaceRepository  repository.WorkspaceRepository
+	invitationRepository repository.InvitationRepository
+	userRepository       repository.UserRepository
+}
+
+func NewWorkspaceUsecase(wr repository.WorkspaceRepository, ir repository.InvitationRepository, ur repository.UserRepository) WorkspaceUsecase {
+	return &workspaceUsecase{
+		workspaceRepository:  wr,
+		invitationRepository: ir,
+		userRepository:       ur,
+	}
+}
+
+// Create creates a workspace owned by the session user.
+func (u *workspaceUsecase) Create(s Session, name string) (*model.Workspace, error) {
+	now := getNow()
+
+	w, err := model.NewWorkspace(model.WorkspaceID(model.CreateUUID()), name, now)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to create workspace")
+	}
+
+	if err := u.workspaceRepository.Create(w, model.NewMember(w.ID, s.UserID, model.RoleOwner, now)); err != nil {
+		return nil, errors.Wrap(err, "failed to store workspace")
+	}
+
+	return w, nil
+}
+
+// FindByUser finds the workspaces which the session user is a member of.
+func (u *workspaceUsecase) FindByUser(s Session) ([]*model.Workspace, error) {
+	workspaces, err := u.workspaceRepository.FindByUserID(s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find workspaces, userID: %s", s.UserID)
+	}
+
+	return workspaces, nil
+}
+
+func (u *workspaceUsecase) FindByID(s Session, id model.WorkspaceID) (*model.WorkspaceDetail, error) {
+	m, err := findMember(u.workspaceRepository, s, id, model.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := u.workspaceRepository.FindByID(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find workspace, workspaceID: %s", id)
+	} else if w == nil {
+		return nil, errors.Wrapf(ErrNotFound, "workspace is not found, workspaceID: %s", id)
+	}
+
+	members, err := u.workspaceRepository.FindMembers(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find members, workspaceID: %s", id)
+	}
+
+	detail := &model.WorkspaceDetail{Workspace: w, Role: m.Role, Members: members, Users: map[model.UserID]*model.User{}}
+
+	for _, member := range members {
+		user, err := u.userRepository.FindByID(member.UserID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find user, userID: %s", member.UserID)
+		}
+
+		detail.Users[member.UserID] = user
+	}
+
+	if m.Role >= model.RoleAdmin {
+		if detail.Invitations, err = u.invitationRepository.FindPendingByWorkspaceID(id, getNow()); err != nil {
+			return nil, errors.Wrapf(err, "failed to find invitations, workspaceID: %s", id)
+		}
+	}
+
+	return detail, nil
+}
+
+// Invite issues an invitation link to join the workspace with the role. Admins and owners can invite.
+func (u *workspaceUsecase) Invite(s Session, id model.WorkspaceID, role model.Role) (*model.Invitation, error) {
+	m, err := findMember(u.workspaceRepository, s, id, model.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := model.NewInvitation(model.InvitationID(model.CreateUUID()), *m, role, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to invite")
+	}
+
+	if err := u.invitationRepository.Create(i); err != nil {
+		return nil, errors.Wrap(err, "failed to store invitation")
+	}
+
+	return i, nil
+}
+
+// FindInvitation finds the invitation of the token together with the workspace to join.
+// Anyone who has the link can see the invitation.
+func (u *workspaceUsecase) FindInvitation(s Session, token string) (*model.Invitation, *model.Workspace, error) {
+	i, err := u.invitationRepository.FindByToken(token)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find invitation")
+	} else if i == nil {
+		return nil, nil, errors.Wrap(ErrNotFound, "invitation is not found")
+	}
+
+	w, err := u.workspaceRepository.FindByID(i.WorkspaceID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to find workspace, workspaceID: %s", i.WorkspaceID)
+	} else if w == nil {
+		return nil, nil, errors.Wrapf(ErrNotFound, "workspace is not found, workspaceID: %s", i.WorkspaceID)
+	}
+
+	return i, w, nil
+}
+
+// Accept makes the session user a member of the workspace with the invited role. An invitation can be accepted only once.
+func (u *workspaceUsecase) Accept(s Session, token string) (*model.Member, error) {
+	fetched, err := u.invitationRepository.FindByToken(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find invitation")
+	} else if fetched == nil {
+		return nil, errors.Wrap(ErrNotFound, "invitation is not found")
+	}
+
+	if fetched.IsAccepted() {
+		return nil, errors.Wrapf(ErrConflict, "invitation is already accepted, invitationID: %s", fetched.ID)
+	}
+
+	current, err := u.workspaceRepository.FindMember(fetched.WorkspaceID, s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member, workspaceID: %s", fetched.WorkspaceID)
+	} else if current != nil {
+		return nil, errors.Wrapf(ErrConflict, "session user is already a member, workspaceID: %s", fetched.WorkspaceID)
+	}
+
+	i, m, err := model.InvitationAccept(*fetched, s.UserID, getNow())
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrInvalidArgument, err), "failed to accept invitation")
+	}
+
+	if err := u.invitationRepository.Accept(i, m); errors.Is(err, repository.ErrVersionConflict) {
+		return nil, errors.Wrap(withKind(ErrConflict, err), "failed to accept invitation")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to store member")
+	}
+
+	return m, nil
+}
+
+// ChangeRole changes the role of the member. The session user has to outrank the member and be able to grant the role.
+func (u *workspaceUsecase) ChangeRole(s Session, id model.WorkspaceID, userID model.UserID, role model.Role) (*model.Member, error) {
+	actor, err := findMember(u.workspaceRepository, s, id, model.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := u.findTargetMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := model.MemberChangeRole(*actor, *target, role)
+	if err != nil {
+		return nil, errors.Wrap(withKind(ErrForbidden, err), "failed to change role")
+	}
+
+	if err := u.workspaceRepository.UpdateMember(m); err != nil {
+		return nil, errors.Wrap(err, "failed to update member")
+	}
+
+	return m, nil
+}
+
+// RemoveMember removes the member from the workspace. Members can leave by themselves, and admins can remove others.
+func (u *workspaceUsecase) RemoveMember(s Session, id model.WorkspaceID, userID model.UserID) error {
+	actor, err := findMember(u.workspaceRepository, s, id, model.RoleViewer)
+	if err != nil {
+		return err
+	}
+
+	target, err := u.findTargetMember(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := model.MemberRemovable(*actor, *target); err != nil {
+		return errors.Wrap(withKind(ErrForbidden, err), "failed to remove member")
+	}
+
+	if err := u.workspaceRepository.DeleteMember(id, userID); err != nil {
+		return errors.Wrap(err, "failed to delete member")
+	}
+
+	return nil
+}
+
+func (u *workspaceUsecase) findTargetMember(id model.WorkspaceID, userID model.UserID) (*model.Member, error) {
+	m, err := u.workspaceRepository.FindMember(id, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member, workspaceID: %s", id)
+	} else if m == nil {
+		return nil, errors.Wrapf(ErrNotFound, "member is not found, workspaceID: %s, userID: %s", id, userID)
+	}
+
+	return m, nil
+}
+
+// findMember finds the membership of the session user in the workspace, which has to have at least the required role.
+// Workspaces of which the user is not a member are reported as not found.
+func findMember(wr repository.WorkspaceRepository, s Session, id model.WorkspaceID, required model.Role) (*model.Member, error) {
+	m, err := wr.FindMember(id, s.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find member, workspaceID: %s", id)
+	} else if m == nil {
+		return nil, errors.Wrapf(ErrNotFound, "workspace is not found, workspaceID: %s", id)
+	}
+
+	if m.Role < required {
+		return nil, errors.Wrapf(ErrForbidden, "session user is %s of workspace, workspaceID: %s", m.Role, id)
+	}
+
+	return m, nil
+}
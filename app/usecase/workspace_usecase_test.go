@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"todo-app/domain/model"
+	"todo-app/domain/repository"
+	"todo-app/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceInviteUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("team")
+
+	tests := []struct {
+		name              string
+		member            *model.Member
+		role              model.Role
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"normal case",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleAdmin},
+			model.RoleMember,
+			nil,
+			1,
+		},
+		{
+			"error case: admin cannot invite admin",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleAdmin},
+			model.RoleAdmin,
+			ErrInvalidArgument,
+			0,
+		},
+		{
+			"error case: member cannot invite",
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			model.RoleViewer,
+			ErrForbidden,
+			0,
+		},
+		{
+			"error case: not member of workspace",
+			nil,
+			model.RoleViewer,
+			ErrNotFound,
+			0,
+		},
+		{
+			"error case: personal workspace",
+			&model.Member{WorkspaceID: model.PersonalWorkspaceID(session.UserID), UserID: session.UserID, Role: model.RoleOwner},
+			model.RoleMember,
+			ErrInvalidArgument,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			invitationRepository := mock.NewMockInvitationRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewWorkspaceUsecase(workspaceRepository, invitationRepository, userRepository)
+
+			gomock.InOrder(
+				workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(tt.member, nil).Times(1),
+				invitationRepository.EXPECT().Create(gomock.Any()).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			output, err := usecase.Invite(session, workspaceID, tt.role)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, tt.role, output.Role)
+				assert.Exactly(t, session.UserID, output.InviterID)
+				assert.NotEmpty(t, output.Token)
+			}
+		})
+	}
+}
+
+func TestWorkspaceAcceptUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("team")
+	token := "0b3a7f0e-2a52-4d4b-8a6e-5f4c2f0f6c1d"
+	now := time.Now()
+
+	pending := &model.Invitation{ID: "pending", WorkspaceID: workspaceID, Token: token, Role: model.RoleMember, ExpiresAt: now.Add(time.Hour)}
+	expired := &model.Invitation{ID: "expired", WorkspaceID: workspaceID, Token: token, Role: model.RoleMember, ExpiresAt: now.Add(-time.Hour)}
+	accepted := &model.Invitation{ID: "accepted", WorkspaceID: workspaceID, Token: token, Role: model.RoleMember, ExpiresAt: now.Add(time.Hour), AcceptedBy: "other", AcceptedAt: &now}
+
+	tests := []struct {
+		name                    string
+		invitation              *model.Invitation
+		member                  *model.Member
+		acceptErr               error
+		expectedFindCallTimes   int
+		expectedAcceptCallTimes int
+		expectedErr             error
+	}{
+		{
+			"normal case",
+			pending,
+			nil,
+			nil,
+			1,
+			1,
+			nil,
+		},
+		{
+			"error case: invitation is not found",
+			nil,
+			nil,
+			nil,
+			0,
+			0,
+			ErrNotFound,
+		},
+		{
+			"error case: invitation is already accepted",
+			accepted,
+			nil,
+			nil,
+			0,
+			0,
+			ErrConflict,
+		},
+		{
+			"error case: invitation is expired",
+			expired,
+			nil,
+			nil,
+			1,
+			0,
+			ErrInvalidArgument,
+		},
+		{
+			"error case: already member of workspace",
+			pending,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleViewer},
+			nil,
+			1,
+			0,
+			ErrConflict,
+		},
+		{
+			"error case: invitation is accepted meanwhile",
+			pending,
+			nil,
+			repository.ErrVersionConflict,
+			1,
+			1,
+			ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			invitationRepository := mock.NewMockInvitationRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewWorkspaceUsecase(workspaceRepository, invitationRepository, userRepository)
+
+			gomock.InOrder(
+				invitationRepository.EXPECT().FindByToken(token).Return(tt.invitation, nil).Times(1),
+				workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(tt.member, nil).Times(tt.expectedFindCallTimes),
+				invitationRepository.EXPECT().Accept(gomock.Any(), gomock.Any()).DoAndReturn(func(i *model.Invitation, m *model.Member) error {
+					assert.Exactly(t, session.UserID, i.AcceptedBy)
+					assert.True(t, i.IsAccepted())
+					assert.Exactly(t, &model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember, CreatedAt: m.CreatedAt}, m)
+
+					return tt.acceptErr
+				}).Times(tt.expectedAcceptCallTimes),
+			)
+
+			output, err := usecase.Accept(session, token)
+			if err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+				assert.Exactly(t, model.RoleMember, output.Role)
+			}
+		})
+	}
+}
+
+func TestWorkspaceChangeRoleUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("team")
+	userID := model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")
+
+	tests := []struct {
+		name              string
+		actorRole         model.Role
+		targetRole        model.Role
+		role              model.Role
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"owner makes admin case",
+			model.RoleOwner,
+			model.RoleMember,
+			model.RoleAdmin,
+			nil,
+			1,
+		},
+		{
+			"admin makes viewer case",
+			model.RoleAdmin,
+			model.RoleMember,
+			model.RoleViewer,
+			nil,
+			1,
+		},
+		{
+			"admin makes admin case",
+			model.RoleAdmin,
+			model.RoleMember,
+			model.RoleAdmin,
+			ErrForbidden,
+			0,
+		},
+		{
+			"admin demotes admin case",
+			model.RoleAdmin,
+			model.RoleAdmin,
+			model.RoleMember,
+			ErrForbidden,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			invitationRepository := mock.NewMockInvitationRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewWorkspaceUsecase(workspaceRepository, invitationRepository, userRepository)
+
+			actor := &model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: tt.actorRole}
+			target := &model.Member{WorkspaceID: workspaceID, UserID: userID, Role: tt.targetRole}
+			changed := *target
+			changed.Role = tt.role
+
+			gomock.InOrder(
+				workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(actor, nil).Times(1),
+				workspaceRepository.EXPECT().FindMember(workspaceID, userID).Return(target, nil).Times(1),
+				workspaceRepository.EXPECT().UpdateMember(&changed).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if _, err := usecase.ChangeRole(session, workspaceID, userID, tt.role); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRemoveMemberUseCase(t *testing.T) {
+	session := Session{UserID: model.UserID("477ecd7f-48fe-6b1c-499a-ec9f52b15a33")}
+	workspaceID := model.WorkspaceID("team")
+	otherID := model.UserID("yyyecd7f-48fe-6b1c-499a-ec9f52b15a33")
+
+	tests := []struct {
+		name              string
+		actorRole         model.Role
+		target            *model.Member
+		expectedErr       error
+		expectedCallTimes int
+	}{
+		{
+			"member leaves case",
+			model.RoleMember,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleMember},
+			nil,
+			1,
+		},
+		{
+			"owner leaves case",
+			model.RoleOwner,
+			&model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: model.RoleOwner},
+			ErrForbidden,
+			0,
+		},
+		{
+			"admin removes member case",
+			model.RoleAdmin,
+			&model.Member{WorkspaceID: workspaceID, UserID: otherID, Role: model.RoleMember},
+			nil,
+			1,
+		},
+		{
+			"member removes member case",
+			model.RoleMember,
+			&model.Member{WorkspaceID: workspaceID, UserID: otherID, Role: model.RoleViewer},
+			ErrForbidden,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspaceRepository := mock.NewMockWorkspaceRepository(ctrl)
+			invitationRepository := mock.NewMockInvitationRepository(ctrl)
+			userRepository := mock.NewMockUserRepository(ctrl)
+			usecase := NewWorkspaceUsecase(workspaceRepository, invitationRepository, userRepository)
+
+			actor := &model.Member{WorkspaceID: workspaceID, UserID: session.UserID, Role: tt.actorRole}
+
+			gomock.InOrder(
+				workspaceRepository.EXPECT().FindMember(workspaceID, session.UserID).Return(actor, nil).Times(1),
+				workspaceRepository.EXPECT().FindMember(workspaceID, tt.target.UserID).Return(tt.target, nil).Times(1),
+				workspaceRepository.EXPECT().DeleteMember(workspaceID, tt.target.UserID).Return(nil).Times(tt.expectedCallTimes),
+			)
+
+			if err := usecase.RemoveMember(session, workspaceID, tt.target.UserID); err != nil {
+				if tt.expectedErr != nil {
+					assert.True(t, errors.Is(err, tt.expectedErr), "expected %v but received: %v", tt.expectedErr, err)
+				} else {
+					t.Fatalf("error is not expected but received: %v", err)
+				}
+			} else {
+				assert.Exactly(t, tt.expectedErr, nil, "error is expected but received nil")
+			}
+		})
+	}
+}